@@ -1,53 +1,322 @@
 package main
 
 import (
+	"errors"
+	"flag"
+	"fmt"
 	"log"
+	"net/http"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
 
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/achievements"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/frontend"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/game"
 	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/graphics" // Adjust import path
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/inputlog"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/leaderboard"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/levelgen"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/model"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/paths"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/persistence"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/platform"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/scoresync"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/spectate"
 	"github.com/hajimehoshi/ebiten/v2"
 )
 
 func main() {
+	scoreServer := flag.String("scoreserver", "", "URL of a score server to submit high scores to (e.g. http://localhost:8080)")
+	scoreSync := flag.String("scoresync", "", "host:port of a score-sync gRPC server to follow leaderboard changes live")
+	spectateAddr := flag.String("spectate-addr", "", "address to stream the run to spectators on (e.g. :8090)")
+	dirOptions := paths.AddFlags(flag.CommandLine)
+	encryptSaves := flag.String("encrypt-saves", "", `encrypt saves and high scores: "passphrase" (from $PACMAN_SAVE_PASSPHRASE) or "machine" (key in the data directory)`)
+	twitchSettings := flag.String("twitch-settings", "twitch.json", "file holding the Twitch chat settings, relative to the config directory (empty hides the Twitch mode)")
+	levelFile := flag.String("level-file", "", "start right away on the level in this file, skipping the menu")
+	logPath := flag.String("log", "", "write log output to this file, relative to the state directory, instead of stderr")
+	recordInputs := flag.String("record-inputs", "", "log every click and key action to this file for bug reports, relative to the state directory")
+	spriteScale := flag.Float64("sprite-scale", 1, fmt.Sprintf("accessibility: enlarge every Pacman and its hitbox by this factor, one of %v; such scores are marked as assisted", model.SpriteScales))
+	audioCues := flag.Bool("audio-cues", false, "accessibility: play a beep, placed left or right, shortly before a Pacman hits a wall")
+	reducedMotion := flag.Bool("reduced-motion", false, "accessibility: turn off decorative animation and effects, gameplay stays the same")
+	soundIndicators := flag.Bool("sound-indicators", false, "accessibility: show sounds on screen, a glowing edge for wall hits and a ring for catches")
+	oneSwitch := flag.Bool("one-switch", false, "accessibility: play with a single switch (SPACE, ENTER or a click), catching the Pacman a cycling highlight is on")
+	switchScan := flag.Duration("switch-scan", time.Second, "time the -one-switch highlight stays on each Pacman")
+	practice := flag.Bool("practice", false, "practice mode: P pauses, period steps one tick, hitboxes and velocities are drawn; scores don't count")
+	showGhost := flag.Bool("ghost", false, "race a ghost of your best recorded run of each level")
+	profile := flag.String("profile", paths.DefaultProfile, "player profile personal bests are kept for")
+	handicapSpeed := flag.Float64("handicap-speed", 0, fmt.Sprintf("handicap saved to the -profile: run the game at this speed, one of %v (1 removes it)", model.Slowdowns))
+	handicapBounces := flag.Float64("handicap-bounces", 0, fmt.Sprintf("handicap saved to the -profile: count bounces with this factor, one of %v (1 removes it)", model.BounceMultipliers))
+	accuracyScoring := flag.Bool("accuracy-scoring", false, fmt.Sprintf("count every missed click as %d extra bounces, so camping and spamming clicks don't pay off", model.MissPenalty))
+	twoStageCatch := flag.Bool("two-stage-catch", false, fmt.Sprintf("the first click stuns a Pacman for %v and a second one catches it; if you miss the window it breaks free faster", game.StunDuration))
+	powerUps := flag.Bool("power-ups", true, fmt.Sprintf("power-ups show up about every %v while playing, click them for %v of slow motion, frozen Pacmans or a bigger hitbox", game.PowerUpInterval, game.PowerUpDuration))
+	lives := flag.Int("lives", 0, "lives of every level instead of their own: each click that catches nothing costs one, and losing the last fails the run (0 keeps the levels' own)")
+	arcade := flag.Bool("arcade", false, fmt.Sprintf("arcade mode: caught Pacmans respawn faster at an edge and the run lasts %v, scoring catches minus bounces in its own Hall of Fame", game.ArcadeDuration))
+	timeAttack := flag.Bool("time-attack", false, fmt.Sprintf("time attack mode: catch every Pacman before the level's countdown runs out (%v unless the level sets one), scoring %d points a second left minus bounces in its own Hall of Fame", game.TimeAttackDuration, game.TimeAttackBonus))
+	theme := flag.String("theme", graphics.ThemeSprites, fmt.Sprintf("how Pacmans are drawn, one of %v", graphics.Themes))
+	shaders := flag.Bool("shaders", true, "draw shader effects, such as the colors draining during slow motion")
+	crt := flag.Bool("crt", false, "display: retro CRT filter with scanlines, a curved screen and darker corners (needs -shaders)")
+	palette := flag.String("palette", frontend.PaletteClock, fmt.Sprintf("colors of the background and the HUD, one of %v: %q follows the time of day, %q goes through a day every few minutes", frontend.PaletteNames, frontend.PaletteClock, frontend.PaletteCycle))
+	dynamicResolution := flag.Bool("dynamic-resolution", true, "draw at a lower resolution while the game can't keep up, e.g. with thousands of Pacmans")
+	captureScale := flag.Int("capture-scale", graphics.MinCaptureScale, fmt.Sprintf("resolution of screenshots (F12) and GIFs (F11), %d-%d times the game's, whatever the window size", graphics.MinCaptureScale, graphics.MaxCaptureScale))
+	layoutMode := flag.String("layout", graphics.LayoutLetterbox, fmt.Sprintf("display: how the game fits a resized window, one of %v", graphics.LayoutModes))
+	trails := flag.Bool("trails", true, "effects: draw fading trails behind fast Pacmans (off with -reduced-motion)")
+	music := flag.String("music", graphics.MusicShuffle, fmt.Sprintf("music played during levels from %s, one of %v; ] and [ skip tracks", graphics.MusicDir, graphics.MusicModes))
+	quickplay := flag.Bool("quickplay", false, "start right away on a randomly generated level")
+	seed := flag.Uint64("seed", 0, "seed of the -quickplay level, or of the first -headless run; 0 picks a new one")
+	difficulty := flag.Int("difficulty", 3, fmt.Sprintf("difficulty of the -quickplay level (%d-%d)", levelgen.MinDifficulty, levelgen.MaxDifficulty))
+	headless := flag.Bool("headless", false, "play the -level-file or -quickplay level (level 0 otherwise) with a bot that clicks the Pacmans one by one, without drawing anything, and print the bounces of each run")
+	headlessRuns := flag.Int("headless-runs", 1, "runs -headless plays, each with the next seed")
+	headlessReaction := flag.Duration("headless-reaction", 500*time.Millisecond, "time the -headless bot takes to click the next Pacman")
+	headlessLimit := flag.Duration("headless-limit", 5*time.Minute, "simulated time a -headless run is given up after")
+	resume := flag.Bool("resume", false, `start right away in the most recent save, skipping the menu; the profile's "Resume on launch" setting does it every time`)
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] [play level-file]\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	// "play <file>" is the same as -level-file <file>
+	if flag.NArg() == 2 && flag.Arg(0) == "play" {
+		*levelFile = flag.Arg(1)
+	} else if flag.NArg() > 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *quickplay && *levelFile != "" {
+		fmt.Fprintln(flag.CommandLine.Output(), "-quickplay and -level-file can't be used together")
+		os.Exit(2)
+	}
+	if *resume && (*quickplay || *levelFile != "") {
+		fmt.Fprintln(flag.CommandLine.Output(), "-resume can't be used with -quickplay or -level-file")
+		os.Exit(2)
+	}
+	if *arcade && *timeAttack {
+		fmt.Fprintln(flag.CommandLine.Output(), "-arcade and -time-attack can't be used together")
+		os.Exit(2)
+	}
+	if *headless && *resume {
+		fmt.Fprintln(flag.CommandLine.Output(), "-headless and -resume can't be used together")
+		os.Exit(2)
+	}
+	if (*quickplay || *headless) && *seed == 0 {
+		*seed = levelgen.NewSeed()
+	}
+
 	// Ensure necessary directories exist before game starts
-	ensureDir("assets/saves")
-	ensureDir("assets/highscores")
+	dirs, err := paths.UserDirs(*dirOptions)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	prepareErr := paths.Prepare(dirs)
+	dataDir := dirs.Data
+	if *logPath != "" {
+		*logPath = paths.Resolve(dirs.State, *logPath)
+		logFile, err := os.OpenFile(*logPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			log.Fatalf("Could not open log file %s: %v", *logPath, err)
+		}
+		defer logFile.Close()
+		log.SetOutput(logFile)
+	}
+	if prepareErr != nil {
+		log.Printf("Warning: Could not prepare data directory %s: %v", dataDir, prepareErr)
+	}
+
+	if *encryptSaves != "" {
+		saveCipher, err := persistence.NewCipher(*encryptSaves, os.Getenv("PACMAN_SAVE_PASSPHRASE"), filepath.Join(dataDir, "machine.key"))
+		if err != nil {
+			log.Fatalf("Failed to set up save encryption: %v", err)
+		}
+		persistence.SetCipher(saveCipher)
+		if err := persistence.MigrateToCipher(dataDir); err != nil {
+			log.Printf("Warning: %v", err)
+		}
+	}
+
+	// Headless runs need none of the window, audio or network setup below
+	if *headless {
+		coreGame := game.NewGame(frontend.ScreenWidth, frontend.ScreenHeight, nil)
+		coreGame.SetDataDir(dataDir)
+		if err := coreGame.SetSpriteScale(*spriteScale); err != nil {
+			log.Fatalf("%v", err)
+		}
+		coreGame.SetAccuracyScoring(*accuracyScoring)
+		coreGame.SetTwoStageCatch(*twoStageCatch)
+		coreGame.SetPowerUps(*powerUps)
+		coreGame.SetLives(*lives)
+		coreGame.SetArcade(*arcade)
+		coreGame.SetTimeAttack(*timeAttack)
+		err := frontend.RunHeadless(os.Stdout, coreGame, frontend.HeadlessOptions{LevelFile: *levelFile, Quickplay: *quickplay, Difficulty: *difficulty,
+			Seed: *seed, Runs: *headlessRuns, Reaction: *headlessReaction, Limit: *headlessLimit})
+		if err != nil {
+			log.Fatalf("Headless run failed: %v", err)
+		}
+		return
+	}
 
 	// Create the main game object
 	gameInstance, err := graphics.NewEbitenGame()
 	if err != nil {
 		log.Fatalf("Failed to initialize game: %v", err)
 	}
+	gameInstance.SetDataDir(dataDir)
+	if err := gameInstance.SetTheme(*theme); err != nil {
+		log.Fatalf("%v", err)
+	}
+	gameInstance.SetShaders(*shaders)
+	gameInstance.SetCRT(*crt)
+	gameInstance.SetDynamicResolution(*dynamicResolution)
+	if err := gameInstance.SetLayoutMode(*layoutMode); err != nil {
+		log.Fatalf("%v", err)
+	}
+	if err := gameInstance.SetCapture(paths.ScreenshotsDir(dataDir), *captureScale); err != nil {
+		log.Fatalf("%v", err)
+	}
+	if err := gameInstance.SetSpriteScale(*spriteScale); err != nil {
+		log.Fatalf("%v", err)
+	}
+	gameInstance.SetAudioCues(*audioCues)
+	gameInstance.SetReducedMotion(*reducedMotion)
+	if err := gameInstance.SetPalette(*palette); err != nil {
+		log.Fatalf("%v", err)
+	}
+	if *soundIndicators {
+		gameInstance.ShowSoundIndicators()
+	}
+	if *oneSwitch {
+		gameInstance.EnableOneSwitch(*switchScan)
+	}
+	gameInstance.SetPractice(*practice)
+	gameInstance.SetAccuracyScoring(*accuracyScoring)
+	gameInstance.SetTwoStageCatch(*twoStageCatch)
+	gameInstance.SetPowerUps(*powerUps)
+	gameInstance.SetLives(*lives)
+	gameInstance.SetArcade(*arcade)
+	gameInstance.SetTimeAttack(*timeAttack)
+	gameInstance.SetShowGhost(*showGhost)
+	gameInstance.SetMotionTrails(*trails)
+	if err := gameInstance.SetMusic(graphics.MusicDir, *music); err != nil {
+		log.Fatalf("%v", err)
+	}
+	if *scoreServer != "" {
+		gameInstance.SetLeaderboard(leaderboard.NewClient(*scoreServer, []byte(os.Getenv("PACMAN_SCORESERVER_SECRET"))))
+	}
+	if *scoreSync != "" {
+		watcher, err := scoresync.NewWatcher(*scoreSync)
+		if err != nil {
+			log.Printf("Live scores disabled: %v", err)
+		} else {
+			gameInstance.SetLiveScores(watcher)
+		}
+	}
+	gamePlatform, err := platform.New()
+	if err != nil {
+		log.Printf("Platform integration disabled: %v", err)
+		gamePlatform = platform.Stub{}
+	}
+	defer gamePlatform.Close()
+	tracker, err := achievements.Load(filepath.Join(dataDir, "achievements.json"), gamePlatform)
+	if err != nil {
+		log.Printf("Achievements disabled: %v", err)
+	} else {
+		gameInstance.SetAchievements(tracker)
+	}
+	playerProfile, err := persistence.LoadProfile(paths.ProfilePath(dataDir, *profile), *profile)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	if err := playerProfile.UpdateHandicap(*handicapSpeed, *handicapBounces); err != nil {
+		log.Fatalf("%v", err)
+	}
+	if err := gameInstance.SetHandicap(playerProfile.Handicap); err != nil {
+		log.Fatalf("%v", err)
+	}
+	gameInstance.SetProfile(playerProfile)
+	records, err := persistence.LoadRecords(paths.RecordsPath(dataDir, *profile), *profile)
+	if err != nil {
+		log.Printf("Personal bests disabled: %v", err)
+	} else {
+		gameInstance.SetRecords(records)
+	}
+	if *twitchSettings != "" {
+		gameInstance.SetTwitchSettings(paths.Resolve(dirs.Config, *twitchSettings))
+	}
+	if *spectateAddr != "" {
+		hub := spectate.NewHub()
+		gameInstance.SetSpectators(hub)
+		go func() {
+			log.Printf("Streaming run to spectators on %s", *spectateAddr)
+			if err := http.ListenAndServe(*spectateAddr, hub); err != nil {
+				log.Printf("Spectator server stopped: %v", err)
+			}
+		}()
+	}
+
+	// Recording starts before the first level so its seed and layout get logged
+	if *recordInputs != "" {
+		*recordInputs = paths.Resolve(dirs.State, *recordInputs)
+		recorder, err := inputlog.Create(*recordInputs, time.Second/ebiten.DefaultTPS)
+		if err != nil {
+			log.Fatalf("Failed to record inputs: %v", err)
+		}
+		gameInstance.SetInputLog(recorder)
+		log.Printf("Recording inputs to %s", *recordInputs)
+	}
+	if *levelFile != "" {
+		if err := gameInstance.PlayLevelFile(*levelFile); err != nil {
+			log.Fatalf("Failed to load level file: %v", err)
+		}
+	}
+	if *quickplay {
+		if err := gameInstance.Quickplay(levelgen.Config{Seed: *seed, Difficulty: *difficulty}); err != nil {
+			log.Fatalf("Failed to start quick play: %v", err)
+		}
+		log.Printf("Quick play seed: %d", *seed)
+	}
+	if *resume || (playerProfile.QuickResume && !*quickplay && *levelFile == "") {
+		if !gameInstance.Resume() {
+			log.Println("No save to resume, starting at the menu.")
+		}
+	}
 
 	// Setup Ebiten window
 	ebiten.SetWindowSize(graphics.ScreenWidth, graphics.ScreenHeight)
+	ebiten.SetWindowResizingMode(ebiten.WindowResizingModeEnabled)
 	ebiten.SetWindowTitle("Catch The Pac-Man (Go Version)")
-	ebiten.SetWindowClosingHandled(true) // Handle Q key or close button manually if needed
+	ebiten.SetWindowClosingHandled(true) // The close button quits like Q, see EbitenGame.Update
+	gameInstance.SetDisplaySettings(filepath.Join(dirs.Config, "display.json"))
+	gameInstance.SetAudioSettings(filepath.Join(dirs.Config, "audio.json"))
+	if geometry, ok := gameInstance.SavedWindowGeometry(); ok {
+		// Where the window was left last time, rather than the OS default position
+		ebiten.SetWindowSize(geometry.Width, geometry.Height)
+		ebiten.SetWindowPosition(geometry.X, geometry.Y)
+	}
+
+	// Ctrl+C and SIGTERM quit through the game loop like Q, so the run is saved
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		sig := <-signals
+		log.Printf("Received %v, quitting.", sig)
+		gameInstance.RequestQuit()
+	}()
 
 	log.Println("Starting Ebiten game loop...")
 	// Run the game loop
 	if err := ebiten.RunGame(gameInstance); err != nil {
-		// Check if it's the specific "user requested quit" error or something else
-		if err.Error() == "user requested quit" {
-			log.Println("Game exited normally by user request (Q key).")
+		if errors.Is(err, frontend.ErrQuit) {
+			log.Println("Game exited normally by user request.")
 		} else {
 			log.Printf("Ebiten loop exited with error: %v", err)
 		}
 	}
+	signal.Stop(signals)
 
-	// Clean up resources (like audio speaker) if necessary
+	// Saves the run, flushes pending saves and closes audio and network connections
 	if err := gameInstance.Close(); err != nil {
 		log.Printf("Error during game cleanup: %v", err)
 	}
 	log.Println("Game finished.")
 }
-
-// ensureDir creates a directory if it doesn't exist.
-func ensureDir(dirName string) {
-	err := os.MkdirAll(dirName, 0755) // Use MkdirAll for convenience (creates parents if needed)
-	if err != nil {
-		// Log the error but don't necessarily make it fatal,
-		// as persistence functions might handle the error later.
-		log.Printf("Warning: Could not create directory %s: %v", dirName, err)
-	}
-}
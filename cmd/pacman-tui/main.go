@@ -0,0 +1,294 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/achievements"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/frontend"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/game"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/inputlog"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/leaderboard"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/levelgen"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/model"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/paths"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/persistence"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/platform"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/scoresync"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/spectate"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/tui"
+)
+
+// Terminal redraws are much more expensive than GPU frames, 30 ticks per second is plenty.
+const tickInterval = time.Second / 30
+
+func main() {
+	logPath := flag.String("log", "pacman-tui.log", "write log output to this file, relative to the state directory; empty discards it (the terminal is used for drawing)")
+	scoreServer := flag.String("scoreserver", "", "URL of a score server to submit high scores to (e.g. http://localhost:8080)")
+	scoreSync := flag.String("scoresync", "", "host:port of a score-sync gRPC server to follow leaderboard changes live")
+	spectateAddr := flag.String("spectate-addr", "", "address to stream the run to spectators on (e.g. :8090)")
+	dirOptions := paths.AddFlags(flag.CommandLine)
+	encryptSaves := flag.String("encrypt-saves", "", `encrypt saves and high scores: "passphrase" (from $PACMAN_SAVE_PASSPHRASE) or "machine" (key in the data directory)`)
+	twitchSettings := flag.String("twitch-settings", "twitch.json", "file holding the Twitch chat settings, relative to the config directory (empty hides the Twitch mode)")
+	watchURL := flag.String("watch", "", "spectate a run streamed at this URL (e.g. ws://host:8090/ws) instead of playing")
+	levelFile := flag.String("level-file", "", "start right away on the level in this file, skipping the menu")
+	recordInputs := flag.String("record-inputs", "", "log every click and key action to this file for bug reports, relative to the state directory")
+	spriteScale := flag.Float64("sprite-scale", 1, fmt.Sprintf("accessibility: enlarge every Pacman and its hitbox by this factor, one of %v; such scores are marked as assisted", model.SpriteScales))
+	reducedMotion := flag.Bool("reduced-motion", false, "accessibility: turn off decorative animation and effects, gameplay stays the same")
+	soundIndicators := flag.Bool("sound-indicators", false, "accessibility: show sounds on screen, a glowing edge for wall hits and a ring for catches")
+	oneSwitch := flag.Bool("one-switch", false, "accessibility: play with a single switch (SPACE, ENTER or a click), catching the Pacman a cycling highlight is on")
+	switchScan := flag.Duration("switch-scan", time.Second, "time the -one-switch highlight stays on each Pacman")
+	practice := flag.Bool("practice", false, "practice mode: P pauses, period steps one tick, hitboxes and velocities are drawn; scores don't count")
+	showGhost := flag.Bool("ghost", false, "race a ghost of your best recorded run of each level")
+	profile := flag.String("profile", paths.DefaultProfile, "player profile personal bests are kept for")
+	handicapSpeed := flag.Float64("handicap-speed", 0, fmt.Sprintf("handicap saved to the -profile: run the game at this speed, one of %v (1 removes it)", model.Slowdowns))
+	handicapBounces := flag.Float64("handicap-bounces", 0, fmt.Sprintf("handicap saved to the -profile: count bounces with this factor, one of %v (1 removes it)", model.BounceMultipliers))
+	accuracyScoring := flag.Bool("accuracy-scoring", false, fmt.Sprintf("count every missed click as %d extra bounces, so camping and spamming clicks don't pay off", model.MissPenalty))
+	twoStageCatch := flag.Bool("two-stage-catch", false, fmt.Sprintf("the first click stuns a Pacman for %v and a second one catches it; if you miss the window it breaks free faster", game.StunDuration))
+	powerUps := flag.Bool("power-ups", true, fmt.Sprintf("power-ups show up about every %v while playing, click them for %v of slow motion, frozen Pacmans or a bigger hitbox", game.PowerUpInterval, game.PowerUpDuration))
+	lives := flag.Int("lives", 0, "lives of every level instead of their own: each click that catches nothing costs one, and losing the last fails the run (0 keeps the levels' own)")
+	arcade := flag.Bool("arcade", false, fmt.Sprintf("arcade mode: caught Pacmans respawn faster at an edge and the run lasts %v, scoring catches minus bounces in its own Hall of Fame", game.ArcadeDuration))
+	timeAttack := flag.Bool("time-attack", false, fmt.Sprintf("time attack mode: catch every Pacman before the level's countdown runs out (%v unless the level sets one), scoring %d points a second left minus bounces in its own Hall of Fame", game.TimeAttackDuration, game.TimeAttackBonus))
+	palette := flag.String("palette", frontend.PaletteClock, fmt.Sprintf("colors of the background and the HUD, one of %v: %q follows the time of day, %q goes through a day every few minutes", frontend.PaletteNames, frontend.PaletteClock, frontend.PaletteCycle))
+	trails := flag.Bool("trails", true, "effects: draw fading trails behind fast Pacmans (off with -reduced-motion)")
+	quickplay := flag.Bool("quickplay", false, "start right away on a randomly generated level")
+	seed := flag.Uint64("seed", 0, "seed of the -quickplay level, or of the first -headless run; 0 picks a new one")
+	difficulty := flag.Int("difficulty", 3, fmt.Sprintf("difficulty of the -quickplay level (%d-%d)", levelgen.MinDifficulty, levelgen.MaxDifficulty))
+	headless := flag.Bool("headless", false, "play the -level-file or -quickplay level (level 0 otherwise) with a bot that clicks the Pacmans one by one, without drawing anything, and print the bounces of each run")
+	headlessRuns := flag.Int("headless-runs", 1, "runs -headless plays, each with the next seed")
+	headlessReaction := flag.Duration("headless-reaction", 500*time.Millisecond, "time the -headless bot takes to click the next Pacman")
+	headlessLimit := flag.Duration("headless-limit", 5*time.Minute, "simulated time a -headless run is given up after")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] [play level-file]\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	// "play <file>" is the same as -level-file <file>
+	if flag.NArg() == 2 && flag.Arg(0) == "play" {
+		*levelFile = flag.Arg(1)
+	} else if flag.NArg() > 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *quickplay && *levelFile != "" {
+		fmt.Fprintln(flag.CommandLine.Output(), "-quickplay and -level-file can't be used together")
+		os.Exit(2)
+	}
+	if *arcade && *timeAttack {
+		fmt.Fprintln(flag.CommandLine.Output(), "-arcade and -time-attack can't be used together")
+		os.Exit(2)
+	}
+	if *headless && *watchURL != "" {
+		fmt.Fprintln(flag.CommandLine.Output(), "-headless and -watch can't be used together")
+		os.Exit(2)
+	}
+	if (*quickplay || *headless) && *seed == 0 {
+		*seed = levelgen.NewSeed()
+	}
+
+	// Ensure necessary directories exist before game starts
+	dirs, err := paths.UserDirs(*dirOptions)
+	if err != nil {
+		log.New(os.Stderr, "", log.LstdFlags).Fatalf("%v", err)
+	}
+	prepareErr := paths.Prepare(dirs)
+	dataDir := dirs.Data
+
+	// Anything printed to stderr would corrupt the screen, so logs go to a file or nowhere
+	log.SetOutput(io.Discard)
+	if *logPath != "" {
+		*logPath = paths.Resolve(dirs.State, *logPath)
+		logFile, err := os.OpenFile(*logPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			log.New(os.Stderr, "", log.LstdFlags).Fatalf("Could not open log file %s: %v", *logPath, err)
+		}
+		defer logFile.Close()
+		log.SetOutput(logFile)
+	}
+	if prepareErr != nil {
+		log.Printf("Warning: Could not prepare data directory %s: %v", dataDir, prepareErr)
+	}
+
+	if *encryptSaves != "" {
+		saveCipher, err := persistence.NewCipher(*encryptSaves, os.Getenv("PACMAN_SAVE_PASSPHRASE"), filepath.Join(dataDir, "machine.key"))
+		if err != nil {
+			log.New(os.Stderr, "", log.LstdFlags).Fatalf("Failed to set up save encryption: %v", err)
+		}
+		persistence.SetCipher(saveCipher)
+		if err := persistence.MigrateToCipher(dataDir); err != nil {
+			log.Printf("Warning: %v", err)
+		}
+	}
+
+	var scene frontend.Scene
+	var coreGame *game.Game // Unless spectating
+	if *watchURL != "" {
+		stream, err := spectate.Dial(*watchURL)
+		if err != nil {
+			log.New(os.Stderr, "", log.LstdFlags).Fatalf("Failed to join spectator stream: %v", err)
+		}
+		defer stream.Close()
+		spectator := frontend.NewSpectator(stream)
+		spectator.ReducedMotion = *reducedMotion
+		scene = spectator
+	} else {
+		// No audio in the terminal frontend
+		coreGame = game.NewGame(frontend.ScreenWidth, frontend.ScreenHeight, nil)
+		coreGame.SetDataDir(dataDir)
+		if err := coreGame.SetSpriteScale(*spriteScale); err != nil {
+			log.New(os.Stderr, "", log.LstdFlags).Fatalf("%v", err)
+		}
+		controller := frontend.NewController(coreGame)
+		defer controller.Close()
+		controller.ReducedMotion = *reducedMotion
+		if err := controller.SetPalette(*palette); err != nil {
+			log.New(os.Stderr, "", log.LstdFlags).Fatalf("%v", err)
+		}
+		if *soundIndicators {
+			controller.ShowSoundIndicators()
+		}
+		if *oneSwitch {
+			controller.EnableOneSwitch(*switchScan)
+		}
+		coreGame.SetPractice(*practice)
+		coreGame.SetAccuracyScoring(*accuracyScoring)
+		coreGame.SetTwoStageCatch(*twoStageCatch)
+		coreGame.SetPowerUps(*powerUps)
+		coreGame.SetLives(*lives)
+		coreGame.SetArcade(*arcade)
+		coreGame.SetTimeAttack(*timeAttack)
+		if *headless {
+			err := frontend.RunHeadless(os.Stdout, coreGame, frontend.HeadlessOptions{LevelFile: *levelFile, Quickplay: *quickplay, Difficulty: *difficulty,
+				Seed: *seed, Runs: *headlessRuns, Reaction: *headlessReaction, Limit: *headlessLimit})
+			if err != nil {
+				log.New(os.Stderr, "", log.LstdFlags).Fatalf("Headless run failed: %v", err)
+			}
+			return
+		}
+		controller.ShowGhost = *showGhost
+		controller.MotionTrails = *trails
+		if *scoreServer != "" {
+			controller.Leaderboard = leaderboard.NewClient(*scoreServer, []byte(os.Getenv("PACMAN_SCORESERVER_SECRET")))
+		}
+		if *scoreSync != "" {
+			watcher, err := scoresync.NewWatcher(*scoreSync)
+			if err != nil {
+				log.Printf("Live scores disabled: %v", err)
+			} else {
+				controller.LiveScores = watcher // Closed by controller.Close
+			}
+		}
+		gamePlatform, err := platform.New()
+		if err != nil {
+			log.Printf("Platform integration disabled: %v", err)
+			gamePlatform = platform.Stub{}
+		}
+		defer gamePlatform.Close()
+		tracker, err := achievements.Load(filepath.Join(dataDir, "achievements.json"), gamePlatform)
+		if err != nil {
+			log.Printf("Achievements disabled: %v", err)
+		} else {
+			controller.Achievements = tracker
+		}
+		playerProfile, err := persistence.LoadProfile(paths.ProfilePath(dataDir, *profile), *profile)
+		if err != nil {
+			log.New(os.Stderr, "", log.LstdFlags).Fatalf("%v", err)
+		}
+		if err := playerProfile.UpdateHandicap(*handicapSpeed, *handicapBounces); err != nil {
+			log.New(os.Stderr, "", log.LstdFlags).Fatalf("%v", err)
+		}
+		if err := coreGame.SetHandicap(playerProfile.Handicap); err != nil {
+			log.New(os.Stderr, "", log.LstdFlags).Fatalf("%v", err)
+		}
+		controller.Profile = playerProfile
+		records, err := persistence.LoadRecords(paths.RecordsPath(dataDir, *profile), *profile)
+		if err != nil {
+			log.Printf("Personal bests disabled: %v", err)
+		} else {
+			controller.Records = records
+		}
+		if *twitchSettings != "" {
+			controller.EnableTwitch(paths.Resolve(dirs.Config, *twitchSettings))
+		}
+		if *spectateAddr != "" {
+			controller.Spectators = spectate.NewHub()
+			go serveSpectators(*spectateAddr, controller.Spectators)
+		}
+		// Recording starts before the first level so its seed and layout get logged
+		if *recordInputs != "" {
+			*recordInputs = paths.Resolve(dirs.State, *recordInputs)
+			recorder, err := inputlog.Create(*recordInputs, tickInterval)
+			if err != nil {
+				log.New(os.Stderr, "", log.LstdFlags).Fatalf("Failed to record inputs: %v", err)
+			}
+			controller.InputLog = recorder
+			defer recorder.Close()
+		}
+		if *levelFile != "" {
+			if err := controller.PlayLevelFile(*levelFile); err != nil {
+				log.New(os.Stderr, "", log.LstdFlags).Fatalf("Failed to load level file: %v", err)
+			}
+		}
+		if *quickplay {
+			if err := controller.Quickplay(levelgen.Config{Seed: *seed, Difficulty: *difficulty}); err != nil {
+				log.New(os.Stderr, "", log.LstdFlags).Fatalf("Failed to start quick play: %v", err)
+			}
+		}
+		scene = controller
+	}
+
+	screen, err := tui.NewScreen()
+	if err != nil {
+		log.New(os.Stderr, "", log.LstdFlags).Fatalf("Failed to initialize terminal: %v", err)
+	}
+
+	ticker := time.NewTicker(tickInterval)
+	defer ticker.Stop()
+
+	log.Println("Starting terminal game loop...")
+	for range ticker.C {
+		if err := scene.Update(screen.PollInput()); err != nil {
+			if errors.Is(err, frontend.ErrQuit) {
+				log.Println("Game exited normally by user request (Q key).")
+			} else {
+				log.Printf("Game loop exited with error: %v", err)
+			}
+			break
+		}
+		scene.Draw(screen)
+		if err := screen.Show(); err != nil {
+			log.Printf("Failed to draw frame: %v", err)
+			break
+		}
+	}
+
+	if err := screen.Close(); err != nil {
+		log.Printf("Error restoring terminal: %v", err)
+	}
+	if coreGame != nil {
+		coreGame.WaitForSaves()
+	}
+	if *quickplay {
+		// Printed after the screen is restored so it stays visible for sharing
+		fmt.Printf("Quick play seed: %d (difficulty %d)\n", *seed, *difficulty)
+	}
+	if *recordInputs != "" {
+		fmt.Printf("Inputs recorded to %s, attach it to bug reports\n", *recordInputs)
+	}
+	log.Println("Game finished.")
+}
+
+// serveSpectators runs the spectator page and stream until the process exits.
+func serveSpectators(addr string, hub *spectate.Hub) {
+	log.Printf("Streaming run to spectators on %s", addr)
+	if err := http.ListenAndServe(addr, hub); err != nil {
+		log.Printf("Spectator server stopped: %v", err)
+	}
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/paths"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/persistence"
+)
+
+// addEncryptionFlag adds the -encrypt-saves flag the game takes to a
+// command. The returned function applies it once the flags are parsed.
+func addEncryptionFlag(flags *flag.FlagSet) func() error {
+	dirOptions := paths.AddFlags(flags)
+	mode := flags.String("encrypt-saves", "", `files are encrypted: "passphrase" (from $PACMAN_SAVE_PASSPHRASE) or "machine" (key in the data directory)`)
+	return func() error {
+		if *mode == "" {
+			return nil
+		}
+		dirs, err := paths.UserDirs(*dirOptions)
+		if err != nil {
+			return err
+		}
+		saveCipher, err := persistence.NewCipher(*mode, os.Getenv("PACMAN_SAVE_PASSPHRASE"), filepath.Join(dirs.Data, "machine.key"))
+		if err != nil {
+			return err
+		}
+		persistence.SetCipher(saveCipher)
+		return persistence.MigrateToCipher(dirs.Data)
+	}
+}
@@ -0,0 +1,53 @@
+// Command pacman holds maintenance tools for the game's files.
+//
+//	pacman migrate [-dir dir] [-dry-run] [file...]
+//	pacman repair [-dry-run] [-encrypt-saves mode] save...
+//	pacman merge [-o out.json] [-encrypt-saves mode] scores.json other.json
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+)
+
+type command struct {
+	name    string
+	summary string
+	run     func(args []string) error
+}
+
+var commands = []command{
+	{"migrate", "upgrade level files, saves and high scores to the current formats", runMigrate},
+	{"repair", "salvage what's left of damaged save files", runRepair},
+	{"merge", "merge two high score files, e.g. from two computers", runMerge},
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	for _, cmd := range commands {
+		if cmd.name == os.Args[1] {
+			if err := cmd.run(os.Args[2:]); err != nil {
+				if err != flag.ErrHelp {
+					fmt.Fprintf(os.Stderr, "pacman %s: %v\n", cmd.name, err)
+				}
+				os.Exit(1)
+			}
+			return
+		}
+	}
+	fmt.Fprintf(os.Stderr, "pacman: unknown command %q\n", os.Args[1])
+	usage()
+	os.Exit(2)
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "Usage: pacman <command> [arguments]")
+	fmt.Fprintln(os.Stderr, "\nCommands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(os.Stderr, "  %-10s %s\n", cmd.name, cmd.summary)
+	}
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/persistence"
+)
+
+func runMerge(args []string) error {
+	flags := flag.NewFlagSet("merge", flag.ContinueOnError)
+	out := flags.String("o", "", "file to write the merged scores to (default: the first file)")
+	applyEncryption := addEncryptionFlag(flags)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: pacman merge [-o out.json] [-encrypt-saves mode] scores.json other.json")
+		fmt.Fprintln(flags.Output(), "\nMerges two high score files of the same level, keeping the best scores of both.")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := applyEncryption(); err != nil {
+		return err
+	}
+	if flags.NArg() != 2 {
+		flags.Usage()
+		return flag.ErrHelp
+	}
+	first, second := flags.Arg(0), flags.Arg(1)
+	if *out == "" {
+		*out = first
+	}
+
+	a, err := persistence.LoadHighScores(first)
+	if err != nil {
+		return err
+	}
+	b, err := persistence.LoadHighScores(second)
+	if err != nil {
+		return err
+	}
+	merged := persistence.MergeHighScores(a, b)
+	if err := persistence.SaveHighScores(merged, *out); err != nil {
+		return err
+	}
+
+	fmt.Printf("Merged %d + %d scores into %s:\n", len(a), len(b), *out)
+	for i, sc := range merged {
+		fmt.Printf("%3d. %-15s %d\n", i+1, sc.Name, sc.Score)
+	}
+	return nil
+}
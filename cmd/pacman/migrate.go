@@ -0,0 +1,75 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/migrate"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/paths"
+)
+
+func runMigrate(args []string) error {
+	flags := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	dir := flags.String("dir", "", "directory to migrate when no files are given, holding levels/, saves/ and highscores/ (default: the game's data directory)")
+	dryRun := flags.Bool("dry-run", false, "only report what would be upgraded")
+	dirOptions := paths.AddFlags(flags)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: pacman migrate [-dir dir] [-dry-run] [file...]")
+		fmt.Fprintln(flags.Output(), "\nUpgrades game files in place, keeping a .v<N>.bak copy of each changed file.")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	var results []migrate.Result
+	var err error
+	if flags.NArg() == 0 {
+		if *dir == "" {
+			dirs, err := paths.UserDirs(*dirOptions)
+			if err != nil {
+				return err
+			}
+			*dir = dirs.Data
+		}
+		results, err = migrate.Dir(*dir, *dryRun)
+	} else {
+		for _, path := range flags.Args() {
+			var result migrate.Result
+			result, err = migrate.File(path, kindOf(path), *dryRun)
+			if err != nil {
+				break
+			}
+			results = append(results, result)
+		}
+	}
+
+	for _, r := range results {
+		switch {
+		case !r.Upgraded():
+			fmt.Printf("%s: up to date (%s v%d)\n", r.Path, r.Kind, r.To)
+		case *dryRun:
+			fmt.Printf("%s: would upgrade %s v%d -> v%d\n", r.Path, r.Kind, r.From, r.To)
+		case r.Target != r.Path:
+			fmt.Printf("%s: upgraded %s v%d -> v%d as %s (backup: %s)\n", r.Path, r.Kind, r.From, r.To, r.Target, r.Backup)
+		default:
+			fmt.Printf("%s: upgraded %s v%d -> v%d (backup: %s)\n", r.Path, r.Kind, r.From, r.To, r.Backup)
+		}
+	}
+	return err
+}
+
+// kindOf guesses the kind of a file named on the command line from its
+// name and directory, like the game lays them out.
+func kindOf(path string) migrate.Kind {
+	switch {
+	case filepath.Ext(path) == ".gob" || filepath.Ext(path) == ".json":
+		return migrate.KindHighScores
+	case strings.Contains(filepath.Base(path), "save") || filepath.Base(filepath.Dir(path)) == "saves":
+		return migrate.KindSave
+	default:
+		return migrate.KindLevel
+	}
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/persistence"
+)
+
+func runRepair(args []string) error {
+	flags := flag.NewFlagSet("repair", flag.ContinueOnError)
+	dryRun := flags.Bool("dry-run", false, "only report the damage")
+	applyEncryption := addEncryptionFlag(flags)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: pacman repair [-dry-run] [-encrypt-saves mode] save...")
+		fmt.Fprintln(flags.Output(), "\nSalvages what it can from damaged save files, keeping each original as <file>.damaged.")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := applyEncryption(); err != nil {
+		return err
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return flag.ErrHelp
+	}
+
+	for _, path := range flags.Args() {
+		var problems []string
+		var err error
+		if *dryRun {
+			_, problems, err = persistence.RecoverGame(path)
+		} else {
+			problems, err = persistence.RepairSave(path)
+		}
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case len(problems) == 0:
+			fmt.Printf("%s: no damage found\n", path)
+			continue
+		case *dryRun:
+			fmt.Printf("%s: %d problems, repair would keep the rest:\n", path, len(problems))
+		default:
+			fmt.Printf("%s: repaired, original kept as %s.damaged. Problems found:\n", path, path)
+		}
+		for _, problem := range problems {
+			fmt.Printf("  %s\n", problem)
+		}
+	}
+	return nil
+}
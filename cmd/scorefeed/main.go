@@ -0,0 +1,46 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/paths"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/scorefeed"
+)
+
+func main() {
+	scoresDir := flag.String("scores", "", "directory holding the per-level high score files (default: the game's, in its data directory)")
+	outDir := flag.String("out", "feed", "directory to write index.html and scores.json to")
+	dirOptions := paths.AddFlags(flag.CommandLine)
+	watch := flag.Duration("watch", 0, "keep running and regenerate when scores change, checking this often (e.g. 5s); 0 generates once")
+	flag.Parse()
+
+	if *scoresDir == "" {
+		dirs, err := paths.UserDirs(*dirOptions)
+		if err != nil {
+			log.Fatalf("%v", err)
+		}
+		*scoresDir = paths.HighScoresDir(dirs.Data)
+	}
+
+	if *watch <= 0 {
+		if err := scorefeed.Generate(*scoresDir, *outDir); err != nil {
+			log.Fatalf("Failed to generate score feed: %v", err)
+		}
+		log.Printf("Score feed written to %s", *outDir)
+		return
+	}
+
+	stop := make(chan struct{})
+	go func() {
+		sig := make(chan os.Signal, 1)
+		signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+		<-sig
+		close(stop)
+	}()
+	log.Printf("Watching %s for score changes", *scoresDir)
+	scorefeed.Watch(*scoresDir, *outDir, *watch, stop)
+}
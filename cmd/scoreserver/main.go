@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/scorefeed"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/scoreserver"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/scoresync"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/scoresync/scoresyncpb"
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "address to listen on")
+	dataDir := flag.String("data", "scoreserver-data", "directory holding the score database; high score files of the game found in it are imported")
+	submitInterval := flag.Duration("submit-interval", 10*time.Second, "time for a client to earn one more score submission")
+	submitBurst := flag.Int("submit-burst", 5, "score submissions a client may make in a row")
+	boardSize := flag.Int("board-size", 100, "scores kept per level")
+	feedDir := flag.String("feed-dir", "", "directory to keep a static HTML page and JSON feed of the leaderboards in (empty disables it)")
+	requireSessions := flag.Bool("require-sessions", false, "only accept scores of runs started with a session and checked for being plausible (turns away older game versions)")
+	grpcAddr := flag.String("grpc-addr", "", "address for the score-sync gRPC service pushing live leaderboard updates (empty disables it)")
+	flag.Parse()
+
+	// Secrets come from the environment so they don't show up in process listings
+	adminToken := os.Getenv("SCORESERVER_ADMIN_TOKEN")
+	if adminToken == "" {
+		log.Println("SCORESERVER_ADMIN_TOKEN not set, admin API disabled.")
+	}
+	signingSecret := os.Getenv("SCORESERVER_SIGNING_SECRET")
+	if signingSecret == "" {
+		log.Println("SCORESERVER_SIGNING_SECRET not set, accepting unsigned submissions.")
+	}
+
+	store, err := scoreserver.NewStore(*dataDir, *boardSize)
+	if err != nil {
+		log.Fatalf("Failed to open score store: %v", err)
+	}
+	defer store.Close()
+
+	server := scoreserver.NewServer(store, scoreserver.Config{
+		AdminToken:     adminToken,
+		SigningSecret:  []byte(signingSecret),
+		SubmitInterval: *submitInterval,
+		SubmitBurst:    *submitBurst,
+
+		RequireSessions: *requireSessions,
+	})
+
+	if *feedDir != "" {
+		feed := scorefeed.NewRegenerator(func() (scorefeed.Feed, error) {
+			levels, err := store.Levels()
+			return scorefeed.Build(levels), err
+		}, *feedDir)
+		store.Watch(func(int) { feed.Request() })
+	}
+
+	stop := make(chan struct{})
+	go server.CleanupLoop(stop)
+
+	var grpcServer *grpc.Server
+	if *grpcAddr != "" {
+		listener, err := net.Listen("tcp", *grpcAddr)
+		if err != nil {
+			log.Fatalf("Failed to listen for score-sync on %s: %v", *grpcAddr, err)
+		}
+		grpcServer = grpc.NewServer()
+		scoresyncpb.RegisterScoreSyncServer(grpcServer, scoresync.NewServer(store))
+		go func() {
+			log.Printf("Score-sync gRPC service listening on %s", *grpcAddr)
+			if err := grpcServer.Serve(listener); err != nil {
+				log.Printf("Score-sync gRPC service failed: %v", err)
+			}
+		}()
+	}
+
+	httpServer := &http.Server{
+		Addr:              *addr,
+		Handler:           server,
+		ReadHeaderTimeout: 5 * time.Second,
+		ReadTimeout:       10 * time.Second,
+		WriteTimeout:      10 * time.Second,
+	}
+
+	go func() {
+		sig := make(chan os.Signal, 1)
+		signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+		<-sig
+		log.Println("Shutting down score server...")
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := httpServer.Shutdown(ctx); err != nil {
+			log.Printf("Error during shutdown: %v", err)
+		}
+		if grpcServer != nil {
+			grpcServer.Stop() // Watch streams never finish on their own, so don't wait for them
+		}
+	}()
+
+	log.Printf("Score server listening on %s (data in %s)", *addr, *dataDir)
+	if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Fatalf("Score server failed: %v", err)
+	}
+	close(stop)
+	log.Println("Score server stopped.")
+}
@@ -0,0 +1,104 @@
+// Package achievements keeps track of the player's unlocked achievements and
+// mirrors them to the platform the game was built for.
+package achievements
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/platform"
+)
+
+// ID names an achievement. The same IDs are used as platform API names.
+type ID string
+
+const (
+	LevelCleared ID = "LEVEL_CLEARED" // Catch every Pacman of a level
+	PerfectRun   ID = "PERFECT_RUN"   // Clear a level without a single bounce
+	HallOfFame   ID = "HALL_OF_FAME"  // Get a score into a level's Hall of Fame
+)
+
+// Tracker records unlocked achievements in a JSON file.
+type Tracker struct {
+	path     string
+	platform platform.Platform
+
+	mu       sync.Mutex
+	unlocked map[ID]bool
+}
+
+// Load reads the unlocked achievements from path (a missing file means none yet)
+// and mirrors them all to the platform, so unlocks earned offline or in another
+// build catch up.
+func Load(path string, p platform.Platform) (*Tracker, error) {
+	t := &Tracker{path: path, platform: p, unlocked: make(map[ID]bool)}
+
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("error reading achievements file %s: %w", path, err)
+	}
+	if err == nil {
+		var ids []ID
+		if err := json.Unmarshal(data, &ids); err != nil {
+			return nil, fmt.Errorf("error decoding achievements file %s: %w", path, err)
+		}
+		for _, id := range ids {
+			t.unlocked[id] = true
+			t.mirror(id)
+		}
+	}
+	return t, nil
+}
+
+// Unlock marks an achievement as unlocked and reports whether it was new.
+func (t *Tracker) Unlock(id ID) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.unlocked[id] {
+		return false
+	}
+	t.unlocked[id] = true
+	log.Printf("Achievement unlocked: %s", id)
+	if err := t.save(); err != nil {
+		log.Printf("Could not save achievements: %v", err)
+	}
+	t.mirror(id)
+	return true
+}
+
+// IsUnlocked reports whether an achievement has been unlocked.
+func (t *Tracker) IsUnlocked(id ID) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.unlocked[id]
+}
+
+func (t *Tracker) mirror(id ID) {
+	if err := t.platform.UnlockAchievement(string(id)); err != nil {
+		log.Printf("Could not mirror achievement %s to %s: %v", id, t.platform.Name(), err)
+	}
+}
+
+// save writes the unlocked IDs. Caller must hold t.mu.
+func (t *Tracker) save() error {
+	ids := make([]ID, 0, len(t.unlocked))
+	for id := range t.unlocked {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	data, err := json.MarshalIndent(ids, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(t.path), 0755); err != nil {
+		return fmt.Errorf("could not create achievements directory: %w", err)
+	}
+	return os.WriteFile(t.path, data, 0644)
+}
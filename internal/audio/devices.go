@@ -0,0 +1,100 @@
+package audio
+
+import (
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/faiface/beep/speaker"
+)
+
+// deviceCheckInterval is how often the AudioManager looks for outputs
+// being plugged in or unplugged.
+const deviceCheckInterval = 2 * time.Second
+
+// Device is an audio output of the system.
+type Device struct {
+	ID   string // What SelectDevice takes, "" for the system's default output
+	Name string
+}
+
+// Devices lists the outputs sound can be played on, the system's default
+// output first. Systems whose outputs can't be listed only have the default.
+func Devices() []Device {
+	return append([]Device{{Name: "System default"}}, systemDevices()...)
+}
+
+// Device returns the ID of the output picked with SelectDevice.
+func (am *AudioManager) Device() string {
+	am.mu.Lock()
+	defer am.mu.Unlock()
+	return am.device
+}
+
+// SelectDevice moves the sound to the output with id, one of Devices.
+func (am *AudioManager) SelectDevice(id string) error {
+	if err := selectDevice(id); err != nil {
+		return err
+	}
+	am.mu.Lock()
+	am.device = id
+	am.mu.Unlock()
+	return am.Restart()
+}
+
+// Restart reopens the speaker on the current output, every sound going on
+// from where it was. It's how the sound recovers from its output going away.
+func (am *AudioManager) Restart() error {
+	if !am.isInitialized {
+		return fmt.Errorf("audio manager not initialized, cannot restart it")
+	}
+	am.playingMu.Lock()
+	defer am.playingMu.Unlock()
+	speaker.Close()
+	if err := speaker.Init(am.sampleRate, am.sampleRate.N(time.Second/10)); err != nil {
+		return fmt.Errorf("could not reopen the audio output: %w", err)
+	}
+	am.playing = slices.DeleteFunc(am.playing, func(p *Playback) bool { return p.ended.Load() })
+	for _, p := range am.playing {
+		speaker.Play(p.stream)
+	}
+	log.Printf("Audio output reopened with %d sounds playing", len(am.playing))
+	return nil
+}
+
+// watchDevices restarts the speaker whenever the system's outputs change,
+// so unplugging headphones moves the sound to the output the system falls
+// back to rather than leaving it on a dead one. An output picked with
+// SelectDevice that goes away falls back to the default. It runs until
+// Close.
+func (am *AudioManager) watchDevices(stop <-chan struct{}) {
+	ticker := time.NewTicker(deviceCheckInterval)
+	defer ticker.Stop()
+	last := systemDevices()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+		devices := systemDevices()
+		if slices.Equal(devices, last) {
+			continue
+		}
+		last = devices
+		am.mu.Lock()
+		if am.device != "" && !slices.ContainsFunc(devices, func(d Device) bool { return d.ID == am.device }) {
+			log.Printf("Audio output %q is gone, falling back to the default", am.device)
+			am.device = ""
+			if err := selectDevice(""); err != nil {
+				log.Printf("Could not go back to the default audio output: %v", err)
+			}
+		}
+		am.mu.Unlock()
+		log.Println("Audio outputs changed, restarting the speaker")
+		if err := am.Restart(); err != nil {
+			log.Printf("Could not restart audio: %v", err)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package audio
+
+import (
+	"bufio"
+	"os"
+	"strings"
+)
+
+// systemDevices lists the ALSA sound cards, the outputs the speaker plays on.
+func systemDevices() []Device {
+	f, err := os.Open("/proc/asound/cards")
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+	// Every card's first line reads " 0 [PCH            ]: HDA-Intel - HDA Intel PCH"
+	var devices []Device
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		i, j := strings.Index(line, "["), strings.Index(line, "]")
+		if i < 0 || j < i {
+			continue
+		}
+		d := Device{ID: strings.TrimSpace(line[i+1 : j]), Name: line[j+1:]}
+		if _, name, ok := strings.Cut(d.Name, " - "); ok {
+			d.Name = name
+		}
+		d.Name = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(d.Name), ":"))
+		devices = append(devices, d)
+	}
+	return devices
+}
+
+// selectDevice points ALSA's default output at a card, or back at the
+// system's choice for "". ALSA reads it when the speaker is reopened. Sound
+// servers such as PulseAudio or PipeWire pick their output themselves.
+func selectDevice(id string) error {
+	if id == "" {
+		return os.Unsetenv("ALSA_CARD")
+	}
+	return os.Setenv("ALSA_CARD", id)
+}
@@ -0,0 +1,18 @@
+//go:build !linux
+
+package audio
+
+import "errors"
+
+// systemDevices can't list the outputs here, the speaker only plays on the
+// system's default one.
+func systemDevices() []Device {
+	return nil
+}
+
+func selectDevice(id string) error {
+	if id != "" {
+		return errors.New("picking an audio output isn't supported on this system")
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package audio
+
+import (
+	"time"
+
+	"github.com/faiface/beep"
+	"github.com/faiface/beep/speaker"
+)
+
+// crossfade is how long music takes to fade in or out when it changes.
+const crossfade = time.Second
+
+// fader is the volume envelope of a Playback: its gain glides to a target,
+// sample by sample. Once faded out it pauses or stops the Playback's Ctrl,
+// as asked. It also applies the volume of the Playback's bus. Its fields
+// are guarded by the speaker lock.
+type fader struct {
+	streamer beep.Streamer
+	ctrl     *beep.Ctrl // Paused or stopped when faded out
+	bus      *bus
+
+	gain   float64 // 0 to 1, on top of the Playback's volume
+	target float64
+	step   float64 // Change of gain per sample
+	then   fadeEnd // Once the gain reaches 0
+}
+
+// fadeEnd is what a fader does with its Playback once faded out.
+type fadeEnd int
+
+const (
+	fadeHold fadeEnd = iota // Keep playing, silent
+	fadePause
+	fadeStop
+)
+
+func (f *fader) Stream(samples [][2]float64) (n int, ok bool) {
+	if f.gain == 0 && f.target == 0 {
+		switch f.then {
+		case fadePause:
+			f.ctrl.Paused = true
+		case fadeStop:
+			f.ctrl.Streamer = nil
+		}
+		f.then = fadeHold
+	}
+	n, ok = f.streamer.Stream(samples)
+	for i := range samples[:n] {
+		switch {
+		case f.gain < f.target:
+			f.gain = min(f.gain+f.step, f.target)
+		case f.gain > f.target:
+			f.gain = max(f.gain-f.step, f.target)
+		}
+		samples[i][0] *= f.gain * f.bus.gain
+		samples[i][1] *= f.gain * f.bus.gain
+	}
+	return n, ok
+}
+
+func (f *fader) Err() error {
+	return f.streamer.Err()
+}
+
+// FadeIn resumes the sound if it was paused and brings it up to full
+// volume over d.
+func (p *Playback) FadeIn(d time.Duration) {
+	p.fadeTo(1, d, fadeHold)
+}
+
+// FadeOut brings the sound down to silence over d, then pauses it until
+// FadeIn or Resume, or ends it for good with stop.
+func (p *Playback) FadeOut(d time.Duration, stop bool) {
+	then := fadePause
+	if stop {
+		then = fadeStop
+	}
+	p.fadeTo(0, d, then)
+}
+
+func (p *Playback) fadeTo(target float64, d time.Duration, then fadeEnd) {
+	if p.fader == nil {
+		return
+	}
+	speaker.Lock()
+	defer speaker.Unlock()
+	f := p.fader
+	if target > 0 {
+		p.ctrl.Paused = false
+	}
+	f.target, f.then = target, then
+	f.step = 1
+	if samples := p.rate.N(d); samples > 0 {
+		f.step = 1 / float64(samples)
+	}
+}
+
+// fadeFromSilence makes a sound that hasn't started yet fade in from
+// silence over the crossfade.
+func (p *Playback) fadeFromSilence() {
+	p.fader.gain = 0
+	p.FadeIn(crossfade)
+}
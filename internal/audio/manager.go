@@ -3,27 +3,47 @@ package audio
 import (
 	"fmt"
 	"log"
+	"math"
 	"os"
 	"sync"
 	"time"
 
 	"github.com/faiface/beep"
+	"github.com/faiface/beep/effects"
 	"github.com/faiface/beep/speaker"
 	"github.com/faiface/beep/wav"
+
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/game"
 )
 
 // AudioManager handles loading and playing sound effects.
 type AudioManager struct {
 	sounds        map[string]*beep.Buffer // Store preloaded sound buffers
 	format        beep.Format             // Store the format (assuming all WAVs have same format)
-	mu            sync.Mutex              // Protect access to sounds map
+	mu            sync.Mutex              // Protect access to sounds map and the music
 	isInitialized bool
+
+	sampleRate beep.SampleRate // Of the speaker, music is resampled to it
+	music      playlist        // See SetPlaylist
+	menu       theme           // See SetMenuMusic
+
+	volumes    Volumes // See SetVolumes
+	musicBus   bus
+	effectsBus bus
+
+	device    string        // See SelectDevice
+	playing   []*Playback   // Started sounds, to play on after Restart; some may have ended
+	playingMu sync.Mutex    // Protects playing, and the speaker while it restarts
+	stopWatch chan struct{} // Closed by Close to stop watchDevices
 }
 
 // NewAudioManager creates a new audio manager and initializes the speaker.
 func NewAudioManager() (*AudioManager, error) {
 	am := &AudioManager{
-		sounds: make(map[string]*beep.Buffer),
+		sounds:     make(map[string]*beep.Buffer),
+		volumes:    DefaultVolumes,
+		musicBus:   bus{gain: 1},
+		effectsBus: bus{gain: 1},
 	}
 
 	// Initialize speaker (needs to be done only once)
@@ -38,21 +58,25 @@ func NewAudioManager() (*AudioManager, error) {
 	}
 	am.isInitialized = true
 	am.format.SampleRate = sampleRate // Store sample rate
+	am.sampleRate = sampleRate
 	log.Println("Audio speaker initialized successfully.")
+	am.stopWatch = make(chan struct{})
+	go am.watchDevices(am.stopWatch)
 
 	return am, nil
 }
 
-// LoadSound loads a WAV file into a buffer.
+// LoadSound loads a WAV file into a buffer. Sounds can be loaded
+// concurrently, each is decoded without holding the lock.
 func (am *AudioManager) LoadSound(name, filepath string) error {
 	if !am.isInitialized {
 		return fmt.Errorf("audio manager not initialized, cannot load sound")
 	}
 
 	am.mu.Lock()
-	defer am.mu.Unlock()
-
-	if _, exists := am.sounds[name]; exists {
+	_, exists := am.sounds[name]
+	am.mu.Unlock()
+	if exists {
 		log.Printf("Sound '%s' already loaded.", name)
 		return nil // Avoid reloading
 	}
@@ -71,6 +95,7 @@ func (am *AudioManager) LoadSound(name, filepath string) error {
 	// Loading into a buffer allows reusing the sound data safely.
 
 	// Assuming first loaded sound dictates the format for the speaker
+	am.mu.Lock()
 	if am.format.NumChannels == 0 {
 		am.format = format
 		// Re-initialize speaker if format mismatch? Beep handles resampling usually.
@@ -80,42 +105,100 @@ func (am *AudioManager) LoadSound(name, filepath string) error {
 		log.Printf("Warning: Sound '%s' format (%v) differs from expected (%v). Beep will attempt resampling.", name, format, am.format)
 		// Beep usually handles resampling, but good to be aware.
 	}
+	bufferFormat := am.format
+	am.mu.Unlock()
 
-	buffer := beep.NewBuffer(am.format) // Create buffer with the initialized format
+	buffer := beep.NewBuffer(bufferFormat) // Create buffer with the initialized format
 	buffer.Append(streamer)
 	streamer.Close() // Close the streamer after appending to buffer
 
+	am.mu.Lock()
 	am.sounds[name] = buffer
+	am.mu.Unlock()
 	log.Printf("Loaded sound '%s' from %s", name, filepath)
 	return nil
 }
 
-// PlaySound plays a preloaded sound by name.
-func (am *AudioManager) PlaySound(name string) {
+// LoadTone synthesizes a short sine beep, faded in and out so it doesn't
+// click, and stores it under name like a loaded sound.
+func (am *AudioManager) LoadTone(name string, freq float64, duration time.Duration) error {
 	if !am.isInitialized {
-		return // Silently fail if audio isn't working
+		return fmt.Errorf("audio manager not initialized, cannot load sound")
 	}
 
 	am.mu.Lock()
-	buffer, ok := am.sounds[name]
-	am.mu.Unlock() // Unlock after getting buffer reference
+	defer am.mu.Unlock()
+
+	if am.format.NumChannels == 0 {
+		am.format.NumChannels, am.format.Precision = 2, 2
+	}
+	sampleRate := float64(am.format.SampleRate)
+	total := am.format.SampleRate.N(duration)
+	fade := am.format.SampleRate.N(duration / 5)
+	pos := 0
+	tone := beep.StreamerFunc(func(samples [][2]float64) (n int, ok bool) {
+		for i := range samples {
+			if pos >= total {
+				return i, i > 0
+			}
+			gain := 0.4 * math.Min(1, float64(min(pos, total-pos))/float64(fade))
+			v := gain * math.Sin(2*math.Pi*freq*float64(pos)/sampleRate)
+			samples[i] = [2]float64{v, v}
+			pos++
+		}
+		return len(samples), true
+	})
+
+	buffer := beep.NewBuffer(am.format)
+	buffer.Append(tone)
+	am.sounds[name] = buffer
+	log.Printf("Synthesized sound '%s' (%.0f Hz, %v)", name, freq, duration)
+	return nil
+}
 
+// PlaySoundAt plays a preloaded sound by name, panned from -1 (left) to 1 (right).
+func (am *AudioManager) PlaySoundAt(name string, pan float64) game.Playback {
+	buffer, ok := am.buffer(name)
 	if !ok {
-		log.Printf("Attempted to play unloaded sound: %s", name)
-		return
+		return &Playback{}
+	}
+	return am.play(&effects.Pan{Streamer: buffer.Streamer(0, buffer.Len()), Pan: math.Max(-1, math.Min(1, pan))})
+}
+
+// PlaySound plays a preloaded sound by name.
+func (am *AudioManager) PlaySound(name string) game.Playback {
+	buffer, ok := am.buffer(name)
+	if !ok {
+		return &Playback{}
 	}
 
 	// Create a streamer from the buffer's data. This allows playing the sound
 	// from the beginning each time PlaySound is called, even if it's already playing.
-	soundStreamer := buffer.Streamer(0, buffer.Len())
+	return am.play(buffer.Streamer(0, buffer.Len()))
+}
+
+// buffer returns a preloaded sound, if audio works and it was loaded.
+func (am *AudioManager) buffer(name string) (*beep.Buffer, bool) {
+	if !am.isInitialized {
+		return nil, false // Silently fail if audio isn't working
+	}
+
+	am.mu.Lock()
+	buffer, ok := am.sounds[name]
+	am.mu.Unlock() // Unlock after getting buffer reference
 
-	// Play the sound without blocking. Speaker handles concurrency.
-	speaker.Play(soundStreamer)
+	if !ok {
+		log.Printf("Attempted to play unloaded sound: %s", name)
+	}
+	return buffer, ok
 }
 
 // Close cleans up audio resources (if necessary in future).
 func (am *AudioManager) Close() {
-	// Speaker doesn't have an explicit Close function in current Beep versions.
-	// Resources are usually managed globally or via context.
+	if am.stopWatch != nil {
+		close(am.stopWatch)
+		am.stopWatch = nil
+	}
+	// The speaker itself is left to close with the process.
 	log.Println("Audio Manager closed (speaker cleanup is implicit).")
 }
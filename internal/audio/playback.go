@@ -0,0 +1,101 @@
+package audio
+
+import (
+	"math"
+	"slices"
+	"sync/atomic"
+
+	"github.com/faiface/beep"
+	"github.com/faiface/beep/effects"
+	"github.com/faiface/beep/speaker"
+)
+
+// Playback controls a sound the AudioManager is playing, see game.Playback.
+// Its zero value is a sound that has already ended, for the ones that
+// couldn't be played.
+type Playback struct {
+	ctrl   *beep.Ctrl
+	volume *effects.Volume
+	fader  *fader
+	stream beep.Streamer   // What the speaker plays, see AudioManager.Restart
+	rate   beep.SampleRate // Of the speaker, for fades
+	ended  atomic.Bool
+}
+
+// play starts streamer on the speaker as a sound effect, returning its
+// Playback.
+func (am *AudioManager) play(streamer beep.Streamer) *Playback {
+	p := am.newPlayback(streamer, &am.effectsBus)
+	am.start(p)
+	return p
+}
+
+// newPlayback wraps streamer in a Playback on bus, ready to start.
+func (am *AudioManager) newPlayback(streamer beep.Streamer, b *bus) *Playback {
+	p := &Playback{rate: am.sampleRate}
+	p.volume = &effects.Volume{Streamer: streamer, Base: 2}
+	p.ctrl = &beep.Ctrl{Streamer: p.volume}
+	p.fader = &fader{streamer: p.ctrl, ctrl: p.ctrl, bus: b, gain: 1, target: 1}
+	p.stream = beep.Seq(p.fader, beep.Callback(func() { p.ended.Store(true) }))
+	return p
+}
+
+// start plays a sound on the speaker.
+func (am *AudioManager) start(p *Playback) {
+	am.playingMu.Lock()
+	defer am.playingMu.Unlock()
+	am.playing = slices.DeleteFunc(am.playing, func(p *Playback) bool { return p.ended.Load() })
+	am.playing = append(am.playing, p)
+	// Play without blocking, the speaker mixes it with the other sounds
+	speaker.Play(p.stream)
+}
+
+// Stop ends the sound for good.
+func (p *Playback) Stop() {
+	if p.ctrl == nil {
+		return
+	}
+	speaker.Lock()
+	p.ctrl.Streamer = nil // The speaker drops it on its next read
+	speaker.Unlock()
+	p.ended.Store(true)
+}
+
+// Pause holds the sound where it is, until Resume.
+func (p *Playback) Pause() {
+	p.setPaused(true)
+}
+
+// Resume goes on playing the sound from where it was paused.
+func (p *Playback) Resume() {
+	p.setPaused(false)
+}
+
+func (p *Playback) setPaused(paused bool) {
+	if p.ctrl == nil {
+		return
+	}
+	speaker.Lock()
+	p.ctrl.Paused = paused
+	speaker.Unlock()
+}
+
+// SetVolume changes the sound's volume: 1 is as it was recorded, 0 is silent.
+func (p *Playback) SetVolume(volume float64) {
+	if p.volume == nil {
+		return
+	}
+	speaker.Lock()
+	// Volume is in powers of Base, 2
+	p.volume.Silent = volume <= 0
+	if !p.volume.Silent {
+		p.volume.Volume = math.Log2(volume)
+	}
+	speaker.Unlock()
+}
+
+// IsPlaying reports whether the sound hasn't ended or been stopped yet.
+// Paused sounds are still playing.
+func (p *Playback) IsPlaying() bool {
+	return p.ctrl != nil && !p.ended.Load()
+}
@@ -0,0 +1,302 @@
+package audio
+
+import (
+	"fmt"
+	"log"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/faiface/beep"
+	"github.com/faiface/beep/wav"
+)
+
+// musicVolume keeps the music under the sound effects.
+const musicVolume = 0.5
+
+// Track is a piece of music of the playlist.
+type Track struct {
+	Title string
+	Path  string // WAV file
+}
+
+// LoadPlaylist lists the WAV files in dir as tracks, titled after their
+// file names: "title_theme.wav" is "Title Theme". A missing dir is an
+// empty playlist.
+func LoadPlaylist(dir string) ([]Track, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.wav"))
+	if err != nil {
+		return nil, fmt.Errorf("could not list music in %s: %w", dir, err)
+	}
+	var tracks []Track
+	for _, path := range paths {
+		words := strings.Fields(strings.NewReplacer("_", " ", "-", " ").Replace(strings.TrimSuffix(filepath.Base(path), ".wav")))
+		for i, w := range words {
+			words[i] = strings.ToUpper(w[:1]) + w[1:]
+		}
+		tracks = append(tracks, Track{Title: strings.Join(words, " "), Path: path})
+	}
+	return tracks, nil
+}
+
+// playlist is the music of an AudioManager, played one track after another.
+type playlist struct {
+	tracks  []Track
+	order   []int // Indices of tracks in playing order, reshuffled every round with shuffle
+	pos     int   // In order
+	shuffle bool
+
+	current *Playback             // nil until the playlist starts
+	file    beep.StreamSeekCloser // Of the current track
+	faded   bool                  // Out, paused by PauseMusic or PlayMenuMusic
+}
+
+// theme is the music of the menus, a single track over and over.
+type theme struct {
+	path    string
+	current *Playback // nil when not playing
+	file    beep.StreamSeekCloser
+	fading  bool // Out, to stop for good
+}
+
+// SetPlaylist sets the music played during the game, going through the
+// tracks in order, or in a new random order every round with shuffle.
+func (am *AudioManager) SetPlaylist(tracks []Track, shuffle bool) {
+	am.mu.Lock()
+	defer am.mu.Unlock()
+	am.music.stop()
+	am.music = playlist{tracks: tracks, shuffle: shuffle}
+	am.music.newRound()
+}
+
+// SetMenuMusic sets the WAV file looped on the menus, "" for none.
+func (am *AudioManager) SetMenuMusic(path string) {
+	am.mu.Lock()
+	defer am.mu.Unlock()
+	am.menu.stop()
+	am.menu = theme{path: path}
+}
+
+// PlayMusic keeps the playlist going: it starts it, resumes it, or moves on
+// once a track ends. Coming from the menu music, or from silence, the
+// playlist crossfades in. Call it every tick music should play.
+func (am *AudioManager) PlayMusic() {
+	am.mu.Lock()
+	defer am.mu.Unlock()
+	if !am.isInitialized {
+		return
+	}
+	am.menu.fadeOut()
+	m := &am.music
+	switch {
+	case len(m.tracks) == 0:
+	case m.current == nil:
+		am.startTrack(true)
+	case !m.current.IsPlaying():
+		m.stop()
+		m.advance(1)
+		am.startTrack(false)
+	case m.faded:
+		m.current.FadeIn(crossfade)
+		m.faded = false
+	}
+}
+
+// PlayMenuMusic keeps the menu music looping, crossfading from the
+// playlist, which pauses where it was. Call it every tick of the menus.
+func (am *AudioManager) PlayMenuMusic() {
+	am.mu.Lock()
+	defer am.mu.Unlock()
+	if !am.isInitialized {
+		return
+	}
+	am.music.fadeOut()
+	t := &am.menu
+	if t.current != nil && !t.current.IsPlaying() {
+		t.stop()
+	}
+	switch {
+	case t.path == "":
+	case t.current == nil:
+		am.startTheme()
+	case t.fading:
+		t.current.FadeIn(crossfade)
+		t.fading = false
+	}
+}
+
+// PauseMusic fades the music out, for PlayMusic to fade the playlist back
+// in where it was. The game's own sounds, like the game over jingle, play
+// on as it fades.
+func (am *AudioManager) PauseMusic() {
+	am.mu.Lock()
+	defer am.mu.Unlock()
+	am.music.fadeOut()
+	am.menu.fadeOut()
+}
+
+// NextTrack skips to the next track.
+func (am *AudioManager) NextTrack() {
+	am.skip(1)
+}
+
+// PreviousTrack goes back to the track before.
+func (am *AudioManager) PreviousTrack() {
+	am.skip(-1)
+}
+
+// CurrentTrack returns the title of the track playing, or paused, and ""
+// before the playlist started.
+func (am *AudioManager) CurrentTrack() string {
+	am.mu.Lock()
+	defer am.mu.Unlock()
+	m := &am.music
+	if m.current == nil || len(m.tracks) == 0 {
+		return ""
+	}
+	return m.tracks[m.order[m.pos]].Title
+}
+
+// skip stops the current track and starts the one step tracks away.
+func (am *AudioManager) skip(step int) {
+	am.mu.Lock()
+	defer am.mu.Unlock()
+	m := &am.music
+	if !am.isInitialized || m.current == nil || len(m.tracks) == 0 {
+		return
+	}
+	m.stop()
+	m.advance(step)
+	am.startTrack(false)
+}
+
+// startTrack streams the current track from its file, rising from silence
+// with fadeIn. A track that can't be played is dropped from the playlist.
+// Assumes am.mu is held.
+func (am *AudioManager) startTrack(fadeIn bool) {
+	m := &am.music
+	for len(m.tracks) > 0 {
+		track := m.tracks[m.order[m.pos]]
+		streamer, format, err := openTrack(track.Path)
+		if err != nil {
+			log.Printf("Dropping %q from the music: %v", track.Title, err)
+			m.tracks = slices.Delete(m.tracks, m.order[m.pos], m.order[m.pos]+1)
+			m.newRound()
+			continue
+		}
+		m.file = streamer
+		m.current = am.newPlayback(beep.Resample(4, format.SampleRate, am.sampleRate, streamer), &am.musicBus)
+		m.current.SetVolume(musicVolume)
+		if fadeIn {
+			m.current.fadeFromSilence()
+		}
+		am.start(m.current)
+		log.Printf("Playing %q", track.Title)
+		return
+	}
+	m.current = nil
+}
+
+// startTheme loops the menu music from its file, fading it in. Music that
+// can't be played is dropped. Assumes am.mu is held.
+func (am *AudioManager) startTheme() {
+	t := &am.menu
+	streamer, format, err := openTrack(t.path)
+	if err != nil {
+		log.Printf("Dropping the menu music: %v", err)
+		t.path = ""
+		return
+	}
+	t.file = streamer
+	t.current = am.newPlayback(beep.Resample(4, format.SampleRate, am.sampleRate, beep.Loop(-1, streamer)), &am.musicBus)
+	t.current.SetVolume(musicVolume)
+	t.current.fadeFromSilence()
+	am.start(t.current)
+}
+
+// openTrack opens a WAV file for streaming.
+func openTrack(path string) (beep.StreamSeekCloser, beep.Format, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, beep.Format{}, err
+	}
+	streamer, format, err := wav.Decode(f)
+	if err != nil {
+		f.Close()
+		return nil, beep.Format{}, fmt.Errorf("could not decode wav file %s: %w", path, err)
+	}
+	return streamer, format, nil
+}
+
+// advance moves step tracks along the playing order, starting a new round
+// past its end.
+func (m *playlist) advance(step int) {
+	m.pos += step
+	switch {
+	case m.pos >= len(m.order):
+		last := m.order[len(m.order)-1]
+		m.newRound()
+		if m.shuffle && len(m.order) > 1 && m.order[0] == last {
+			// Don't play the same track twice in a row
+			m.order[0], m.order[1] = m.order[1], m.order[0]
+		}
+	case m.pos < 0:
+		m.pos = len(m.order) - 1
+	}
+}
+
+// newRound puts the tracks in a new playing order, from the first.
+func (m *playlist) newRound() {
+	m.pos = 0
+	if m.shuffle {
+		m.order = rand.Perm(len(m.tracks))
+		return
+	}
+	m.order = m.order[:0]
+	for i := range m.tracks {
+		m.order = append(m.order, i)
+	}
+}
+
+// stop ends the current track and closes its file.
+func (m *playlist) stop() {
+	if m.current == nil {
+		return
+	}
+	m.current.Stop()
+	m.file.Close()
+	m.current, m.file, m.faded = nil, nil, false
+}
+
+// fadeOut fades the current track out, pausing it.
+func (m *playlist) fadeOut() {
+	if m.current != nil && !m.faded {
+		m.current.FadeOut(crossfade, false)
+		m.faded = true
+	}
+}
+
+// fadeOut fades the menu music out and stops it, closing its file once it
+// ended.
+func (t *theme) fadeOut() {
+	switch {
+	case t.current == nil:
+	case !t.current.IsPlaying():
+		t.stop()
+	case !t.fading:
+		t.current.FadeOut(crossfade, true)
+		t.fading = true
+	}
+}
+
+// stop ends the menu music and closes its file.
+func (t *theme) stop() {
+	if t.current == nil {
+		return
+	}
+	t.current.Stop()
+	t.file.Close()
+	t.current, t.file, t.fading = nil, nil, false
+}
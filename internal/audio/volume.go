@@ -0,0 +1,65 @@
+package audio
+
+import (
+	"fmt"
+
+	"github.com/faiface/beep/speaker"
+)
+
+// Volumes are the levels an AudioManager plays at, each 0 to 1. Master
+// applies on top of the other two: sound effects play at Master*Effects.
+// A muted channel keeps its level for when it's unmuted.
+type Volumes struct {
+	Master, Music, Effects                float64
+	MasterMuted, MusicMuted, EffectsMuted bool
+}
+
+// DefaultVolumes plays everything as loud as it was recorded.
+var DefaultVolumes = Volumes{Master: 1, Music: 1, Effects: 1}
+
+// bus is the volume of a group of sounds, the music or the sound effects,
+// on top of each sound's own. Its gain is guarded by the speaker lock.
+type bus struct {
+	gain float64
+}
+
+// Volumes returns the levels the sounds play at.
+func (am *AudioManager) Volumes() Volumes {
+	am.mu.Lock()
+	defer am.mu.Unlock()
+	return am.volumes
+}
+
+// SetVolumes changes the levels of every sound, the ones already playing
+// included.
+func (am *AudioManager) SetVolumes(v Volumes) {
+	am.mu.Lock()
+	defer am.mu.Unlock()
+	am.volumes = v
+	master := channelGain(v.Master, v.MasterMuted)
+	music := master * channelGain(v.Music, v.MusicMuted)
+	effects := master * channelGain(v.Effects, v.EffectsMuted)
+	if !am.isInitialized {
+		am.musicBus.gain, am.effectsBus.gain = music, effects
+		return
+	}
+	speaker.Lock()
+	am.musicBus.gain, am.effectsBus.gain = music, effects
+	speaker.Unlock()
+}
+
+// channelGain is the gain of a channel at volume, clamped to 0 to 1.
+func channelGain(volume float64, muted bool) float64 {
+	if muted {
+		return 0
+	}
+	return max(0, min(1, volume))
+}
+
+// OutputDevice describes where the sounds go, or why they don't.
+func (am *AudioManager) OutputDevice() string {
+	if !am.isInitialized {
+		return "No audio output"
+	}
+	return fmt.Sprintf("System default output, %d Hz stereo", am.sampleRate)
+}
@@ -0,0 +1,104 @@
+// Package campaign chains the standard levels into one run: the bounces of
+// every level add up, and a failed level costs one of a few lives. Progress
+// is saved after each level so a campaign can be resumed later.
+package campaign
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Campaign progress files are JSON documents:
+//
+//	{"schema": "catch-the-pacman/campaign", "version": 1, "level": 1,
+//	 "total_bounces": 14, "lives": 2, "levels": [14]}
+const (
+	Schema  = "catch-the-pacman/campaign"
+	Version = 1
+)
+
+// StartingLives is how many failed levels a campaign survives, minus one.
+const StartingLives = 3
+
+// Progress is how far a campaign got.
+type Progress struct {
+	Schema       string `json:"schema"`
+	Version      int    `json:"version"`
+	Level        int    `json:"level"`         // Level to play next
+	TotalBounces int    `json:"total_bounces"` // Bounces of every run so far, failed ones included
+	Lives        int    `json:"lives"`
+	Levels       []int  `json:"levels"` // Bounces of the run that cleared each level, in order
+}
+
+// New starts a campaign at level 0.
+func New() *Progress {
+	return &Progress{Schema: Schema, Version: Version, Lives: StartingLives, Levels: []int{}}
+}
+
+// Record adds the run of the current level that just ended. A cleared
+// level moves the campaign on to the next one, a failed one costs a life
+// and has to be played again.
+func (p *Progress) Record(bounces int, failed bool) {
+	p.TotalBounces += bounces
+	if failed {
+		p.Lives--
+		return
+	}
+	p.Levels = append(p.Levels, bounces)
+	p.Level++
+}
+
+// Over reports whether the campaign ran out of lives.
+func (p *Progress) Over() bool {
+	return p.Lives <= 0
+}
+
+// Load reads campaign progress.
+func Load(path string) (*Progress, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var p Progress
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("invalid campaign file %s: %w", path, err)
+	}
+	if p.Schema != Schema {
+		return nil, fmt.Errorf("%s is not a campaign file (schema %q)", path, p.Schema)
+	}
+	if p.Version > Version {
+		return nil, fmt.Errorf("campaign file %s has version %d, newer than supported version %d", path, p.Version, Version)
+	}
+	return &p, nil
+}
+
+// Save writes campaign progress to path through a temporary file renamed
+// into place, creating its directory if needed.
+func Save(p *Progress, path string) error {
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("could not create directory for %s: %w", path, err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("error creating %s: %w", path, err)
+	}
+	defer os.Remove(tmp.Name()) // No-op once renamed
+
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return fmt.Errorf("error writing %s: %w", path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("error writing %s: %w", path, err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("error replacing %s: %w", path, err)
+	}
+	return nil
+}
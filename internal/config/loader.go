@@ -2,13 +2,44 @@ package config
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
+	"image/color"
 	"log"
+	"math"
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/fileformat"
 	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/game" // Adjust path
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/model"
+)
+
+// ErrInvalidLevel is returned, wrapped, for a level file that can't be read
+// as a level. One written by a newer game fails with
+// fileformat.ErrUnsupportedVersion instead.
+var ErrInvalidLevel = errors.New("invalid level file")
+
+// LevelFormatVersion is the version of the level file format this game reads (see package fileformat).
+const LevelFormatVersion = 1
+
+// Level metadata lives in comment lines like "# bounce-limit: 20", so older
+// versions of the game still read levels that use it.
+const (
+	metaBounceLimit = "# bounce-limit:"
+	metaTimeLimit   = "# time-limit:" // Seconds, the level's countdown in time attack mode
+	metaLives       = "# lives:"
+	metaBackground  = "# background:" // Colors of the level's theme, as #rrggbb, see game.LevelTheme
+	metaAccent      = "# accent:"
+	metaTint        = "# tint:" // Any number of colors, separated by spaces
+
+	// Rules of the level's Hall of Fame, see model.HallOfFameRules
+	metaHallOfFameSize      = "# hall-of-fame-size:"
+	metaHallOfFameThreshold = "# hall-of-fame-threshold:"
+	metaOnePerPlayer        = "# hall-of-fame-one-per-player:" // true or false
+	metaChains              = "# hall-of-fame-chains:"         // true or false
 )
 
 // LoadLevelConfig reads a level configuration file and creates a new Game object.
@@ -26,12 +57,50 @@ func LoadLevelConfig(filepath string) (*game.Game, error) {
 	level := -1
 	pacmans := []*game.Pacman{}
 	idCounter := 0
+	bounceLimit := 0
+	var timeLimit time.Duration
+	lives := 0
+	var theme game.LevelTheme
+	var hallOfFame model.HallOfFameRules
 
 	for scanner.Scan() {
 		lineNum++
 		line := strings.TrimSpace(scanner.Text())
 
 		if line == "" || strings.HasPrefix(line, "#") {
+			if err := fileformat.Check(line, fileformat.KindLevel, LevelFormatVersion); err != nil {
+				if !errors.Is(err, fileformat.ErrUnsupportedVersion) {
+					err = fmt.Errorf("%w: %w", ErrInvalidLevel, err)
+				}
+				return nil, fmt.Errorf("level file %s: %w", filepath, err)
+			}
+			if value, ok := strings.CutPrefix(line, metaBounceLimit); ok {
+				limit, err := strconv.Atoi(strings.TrimSpace(value))
+				if err != nil || limit < 0 {
+					return nil, fmt.Errorf("%w: line %d: invalid bounce limit '%s' in %s", ErrInvalidLevel, lineNum, strings.TrimSpace(value), filepath)
+				}
+				bounceLimit = limit
+			}
+			if value, ok := strings.CutPrefix(line, metaTimeLimit); ok {
+				seconds, err := strconv.Atoi(strings.TrimSpace(value))
+				if err != nil || seconds <= 0 {
+					return nil, fmt.Errorf("%w: line %d: invalid time limit '%s' in %s", ErrInvalidLevel, lineNum, strings.TrimSpace(value), filepath)
+				}
+				timeLimit = time.Duration(seconds) * time.Second
+			}
+			if value, ok := strings.CutPrefix(line, metaLives); ok {
+				n, err := strconv.Atoi(strings.TrimSpace(value))
+				if err != nil || n < 0 {
+					return nil, fmt.Errorf("%w: line %d: invalid lives '%s' in %s", ErrInvalidLevel, lineNum, strings.TrimSpace(value), filepath)
+				}
+				lives = n
+			}
+			if err := parseThemeLine(line, &theme); err != nil {
+				return nil, fmt.Errorf("%w: line %d: %w in %s", ErrInvalidLevel, lineNum, err, filepath)
+			}
+			if err := parseHallOfFameLine(line, &hallOfFame); err != nil {
+				return nil, fmt.Errorf("%w: line %d: %w in %s", ErrInvalidLevel, lineNum, err, filepath)
+			}
 			continue // Skip blank lines and comments
 		}
 
@@ -39,7 +108,7 @@ func LoadLevelConfig(filepath string) (*game.Game, error) {
 		if level == -1 {
 			levelVal, err := strconv.Atoi(line)
 			if err != nil {
-				return nil, fmt.Errorf("line %d: expected level number, got '%s': %w", lineNum, line, err)
+				return nil, fmt.Errorf("%w: line %d: expected level number, got '%s': %w", ErrInvalidLevel, lineNum, line, err)
 			}
 			if levelVal < 0 || levelVal > 2 {
 				log.Printf("Warning line %d: Invalid level number %d in %s. Defaulting to 0.", lineNum, levelVal, filepath)
@@ -52,7 +121,8 @@ func LoadLevelConfig(filepath string) (*game.Game, error) {
 
 		// Subsequent valid lines are Pac-Man definitions
 		parts := strings.Split(line, "\t")
-		// Expected format: diameter, posX, posY, waitTimeMs, direction, bounces, isStopped (7 fields)
+		// Expected format: diameter, posX, posY, waitTimeMs, direction, bounces, isStopped (7 fields),
+		// optionally followed by an angle that overrides the direction
 		if len(parts) < 7 { // Be flexible if fields are added later, but require minimum
 			log.Printf("Warning line %d: Invalid Pac-Man definition in %s. Expected 7 tab-separated fields, got %d. Skipping line.", lineNum, filepath, len(parts))
 			continue
@@ -71,6 +141,15 @@ func LoadLevelConfig(filepath string) (*game.Game, error) {
 				lineNum, filepath, errDia, errX, errY, errWait, errBounce)
 			continue
 		}
+		// Values a save couldn't hold either, see persistence.SaveGame
+		if !finite(diameter) || !finite(posX) || !finite(posY) {
+			log.Printf("Warning line %d: Pac-Man size or position isn't a number in %s. Skipping line.", lineNum, filepath)
+			continue
+		}
+		if waitTimeMs <= 0 || bounces < 0 {
+			log.Printf("Warning line %d: Invalid wait time %d or bounces %d for Pac-Man in %s. Skipping line.", lineNum, waitTimeMs, bounces, filepath)
+			continue
+		}
 
 		var direction rune
 		if len(directionStr) > 0 {
@@ -88,6 +167,15 @@ func LoadLevelConfig(filepath string) (*game.Game, error) {
 
 		// Initial sub-direction (Assume 1 for right/down unless specified otherwise - format doesn't include it)
 		initialSubDirection := 1
+		dirX, dirY := game.AxisHeading(direction, initialSubDirection)
+		if len(parts) > 7 && strings.TrimSpace(parts[7]) != "" {
+			angle, err := strconv.ParseFloat(strings.TrimSpace(parts[7]), 64)
+			if err != nil || !finite(angle) {
+				log.Printf("Warning line %d: Invalid angle '%s' for Pac-Man in %s. Using its direction.", lineNum, parts[7], filepath)
+			} else {
+				dirX, dirY = game.AngleHeading(angle)
+			}
+		}
 
 		isStopped := (isStoppedStr == "true" || isStoppedStr == "1")
 
@@ -97,7 +185,7 @@ func LoadLevelConfig(filepath string) (*game.Game, error) {
 			continue
 		}
 
-		pacman := game.NewPacman(idCounter, radius, posX, posY, direction, initialSubDirection, waitTimeMs, bounces, isStopped)
+		pacman := game.NewPacman(idCounter, radius, posX, posY, dirX, dirY, waitTimeMs, bounces, isStopped)
 		pacmans = append(pacmans, pacman)
 		idCounter++
 	}
@@ -107,13 +195,18 @@ func LoadLevelConfig(filepath string) (*game.Game, error) {
 	}
 
 	if level == -1 {
-		return nil, fmt.Errorf("level file %s did not contain a valid level number", filepath)
+		return nil, fmt.Errorf("%w: level file %s did not contain a valid level number", ErrInvalidLevel, filepath)
 	}
 
 	// Return a *partial* Game struct containing the loaded level data
 	loadedGame := &game.Game{
-		Level:   level,
-		Pacmans: pacmans,
+		Level:      level,
+		Pacmans:    pacmans,
+		MaxBounces: bounceLimit,
+		TimeLimit:  timeLimit,
+		Lives:      lives,
+		Theme:      theme,
+		HallOfFame: hallOfFame,
 		// TotalBounces will be initialized by the main Game logic when loading
 	}
 
@@ -121,3 +214,75 @@ func LoadLevelConfig(filepath string) (*game.Game, error) {
 
 	return loadedGame, nil
 }
+
+// finite reports whether f is a number, neither NaN nor infinite.
+func finite(f float64) bool {
+	return !math.IsNaN(f) && !math.IsInf(f, 0)
+}
+
+// parseThemeLine reads a theme color line of a level file into theme. Other
+// lines are left alone.
+func parseThemeLine(line string, theme *game.LevelTheme) error {
+	if value, ok := strings.CutPrefix(line, metaBackground); ok {
+		return parseColor(strings.TrimSpace(value), &theme.Background)
+	}
+	if value, ok := strings.CutPrefix(line, metaAccent); ok {
+		return parseColor(strings.TrimSpace(value), &theme.Accent)
+	}
+	if value, ok := strings.CutPrefix(line, metaTint); ok {
+		theme.Tints = nil
+		for _, field := range strings.Fields(value) {
+			var tint color.RGBA
+			if err := parseColor(field, &tint); err != nil {
+				return err
+			}
+			theme.Tints = append(theme.Tints, tint)
+		}
+	}
+	return nil
+}
+
+// parseHallOfFameLine reads a Hall of Fame rule line of a level file into
+// rules. Other lines are left alone.
+func parseHallOfFameLine(line string, rules *model.HallOfFameRules) error {
+	if value, ok := strings.CutPrefix(line, metaHallOfFameSize); ok {
+		size, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || size < 1 {
+			return fmt.Errorf("invalid Hall of Fame size '%s'", strings.TrimSpace(value))
+		}
+		rules.Size = size
+	}
+	if value, ok := strings.CutPrefix(line, metaHallOfFameThreshold); ok {
+		threshold, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || threshold < 0 {
+			return fmt.Errorf("invalid Hall of Fame threshold '%s'", strings.TrimSpace(value))
+		}
+		rules.Threshold = threshold
+	}
+	if value, ok := strings.CutPrefix(line, metaOnePerPlayer); ok {
+		one, err := strconv.ParseBool(strings.TrimSpace(value))
+		if err != nil {
+			return fmt.Errorf("invalid Hall of Fame one-per-player '%s', expected true or false", strings.TrimSpace(value))
+		}
+		rules.OnePerPlayer = one
+	}
+	if value, ok := strings.CutPrefix(line, metaChains); ok {
+		chains, err := strconv.ParseBool(strings.TrimSpace(value))
+		if err != nil {
+			return fmt.Errorf("invalid Hall of Fame chains '%s', expected true or false", strings.TrimSpace(value))
+		}
+		rules.Chains = chains
+	}
+	return nil
+}
+
+// parseColor reads an opaque color written as #rrggbb.
+func parseColor(s string, clr *color.RGBA) error {
+	hex, ok := strings.CutPrefix(s, "#")
+	rgb, err := strconv.ParseUint(hex, 16, 32)
+	if !ok || len(hex) != 6 || err != nil {
+		return fmt.Errorf("invalid color '%s', expected #rrggbb", s)
+	}
+	*clr = color.RGBA{R: uint8(rgb >> 16), G: uint8(rgb >> 8), B: uint8(rgb), A: 255}
+	return nil
+}
@@ -0,0 +1,55 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/game"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/persistence"
+)
+
+// kept returns what a save keeps of a loaded game.
+func kept(g *game.Game) *game.Game {
+	return &game.Game{Level: g.Level, TotalBounces: g.TotalBounces, Seed: g.Seed, Pacmans: g.Pacmans}
+}
+
+func FuzzLoadLevelConfig(f *testing.F) {
+	bundled, err := os.ReadFile(filepath.Join("..", "..", "assets", "levels", "level_0.txt"))
+	if err != nil {
+		f.Fatal(err)
+	}
+	f.Add(bundled)
+	f.Add([]byte("# format: level 1\n# bounce-limit: 20\n# hall-of-fame-size: 5\n1\n40\t100.5\t200\t80\tV\t0\tfalse\t135\n30\t300\t400\t60\tH\t2\ttrue\n"))
+	f.Add([]byte("2\n40\t1\t2\t80\th\t0\t1\t\n"))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "level_0.txt")
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			t.Fatal(err)
+		}
+		g, err := LoadLevelConfig(path)
+		if err != nil {
+			return
+		}
+
+		// Saved right away, the level loads back from its save as it started
+		level, totalBounces, pacmans := g.GetDataForSave()
+		savePath := filepath.Join(dir, "savegame_0.txt")
+		if err := persistence.SaveGame(savePath, level, totalBounces, g.Seed, pacmans); err != nil {
+			t.Fatalf("SaveGame: %v", err)
+		}
+		loaded, err := persistence.LoadGame(savePath)
+		if err != nil {
+			t.Fatalf("saved level doesn't load back: %v", err)
+		}
+		if !reflect.DeepEqual(kept(loaded), kept(g)) {
+			save, _ := os.ReadFile(savePath)
+			_, _, loadedPacmans := loaded.GetDataForSave()
+			t.Fatalf("saved level loads back different:\nlevel %d, %d bounces, seed %d: %+v\nwas level %d, %d bounces, seed %d: %+v\nsave:\n%s",
+				loaded.Level, loaded.TotalBounces, loaded.Seed, loadedPacmans, level, totalBounces, g.Seed, pacmans, save)
+		}
+	})
+}
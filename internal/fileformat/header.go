@@ -0,0 +1,88 @@
+// Package fileformat handles the version header of the game's text files.
+//
+// A versioned file starts with a comment line naming its kind and format
+// version, e.g. "# format: save 1". Files written before versioning have no
+// header and count as version 0. Since the header is a comment, older
+// versions of the game still read newer files as long as the rows are
+// compatible.
+package fileformat
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Kinds of versioned files.
+const (
+	KindLevel = "level"
+	KindSave  = "save"
+)
+
+const headerPrefix = "# format:"
+
+// ErrUnsupportedVersion is returned, wrapped, for a file written in a newer
+// format than this game reads.
+var ErrUnsupportedVersion = errors.New("unsupported format version")
+
+// Header returns the header line (without newline) of a file of the given kind and version.
+func Header(kind string, version int) string {
+	return fmt.Sprintf("%s %s %d", headerPrefix, kind, version)
+}
+
+// ParseHeader reads a header line. ok is false if line isn't one.
+func ParseHeader(line string) (kind string, version int, ok bool) {
+	rest, found := strings.CutPrefix(strings.TrimSpace(line), headerPrefix)
+	if !found {
+		return "", 0, false
+	}
+	fields := strings.Fields(rest)
+	if len(fields) != 2 {
+		return "", 0, false
+	}
+	version, err := strconv.Atoi(fields[1])
+	if err != nil || version < 1 {
+		return "", 0, false
+	}
+	return fields[0], version, true
+}
+
+// Version returns the format version of a file of the given kind, read from
+// its first non-blank line. It's 0 if the file has no header.
+func Version(data []byte, kind string) (int, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		fileKind, version, ok := ParseHeader(line)
+		if !ok {
+			return 0, nil
+		}
+		if fileKind != kind {
+			return 0, fmt.Errorf("file is a %s file, not a %s file", fileKind, kind)
+		}
+		return version, nil
+	}
+	return 0, scanner.Err()
+}
+
+// Check returns an error if a header line announces a file newer than
+// supported. Lines that aren't headers pass.
+func Check(line, kind string, supported int) error {
+	fileKind, version, ok := ParseHeader(line)
+	if !ok {
+		return nil
+	}
+	if fileKind != kind {
+		return fmt.Errorf("file is a %s file, not a %s file", fileKind, kind)
+	}
+	if version > supported {
+		return fmt.Errorf("%w: %s format version %d is newer than this game supports (%d)", ErrUnsupportedVersion, kind, version, supported)
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package frontend
+
+import (
+	"log"
+
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/game"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/model"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/persistence"
+)
+
+// playActions turns a tick's input while a level is played into the game
+// actions it asks for.
+func (c *Controller) playActions(in Input) []game.Action {
+	var actions []game.Action
+	// P pauses, period advances one tick while a practice run is paused
+	if in.Pressed(KeyPause) {
+		actions = append(actions, game.Action{Kind: game.ActionPause})
+	}
+	if c.GameLogic.Practice() && in.Pressed(KeyStep) {
+		actions = append(actions, game.Action{Kind: game.ActionStep})
+	}
+	if in.Pressed(KeyMagnet) {
+		actions = append(actions, game.Action{Kind: game.ActionUseMagnet})
+	}
+	if c.oneSwitch {
+		if c.switched(in) {
+			actions = append(actions, game.Action{Kind: game.ActionCatchHighlighted})
+		}
+	} else if in.Clicked {
+		actions = append(actions, game.CatchAt(in.ClickX, in.ClickY))
+	}
+	if in.Pressed(KeySave) {
+		actions = append(actions, game.Action{Kind: game.ActionSave})
+	}
+	return actions
+}
+
+// nameActions turns a tick's input while a high score's name is typed into
+// the game actions it asks for, but confirming it, see confirmName.
+func (c *Controller) nameActions(in Input) []game.Action {
+	var actions []game.Action
+	// A single switch can't type, its press confirms the default name
+	if len(in.Chars) > 0 && !(c.oneSwitch && in.Pressed(KeySwitch)) {
+		actions = append(actions, game.TypeName(in.Chars))
+	}
+	if in.Backspace {
+		actions = append(actions, game.Action{Kind: game.ActionEraseName})
+	}
+	return actions
+}
+
+// apply does actions to the game, with the frontend's side of them.
+func (c *Controller) apply(actions ...game.Action) {
+	for _, a := range actions {
+		if a.Kind == game.ActionSave {
+			// Written in the background, see reportSaves
+			if c.requestSave(true) {
+				c.saves = append(c.saves, false)
+			} else {
+				log.Println("Save failed: not playing a level")
+			}
+			continue
+		}
+		done := c.GameLogic.Apply(a)
+		switch a.Kind {
+		case game.ActionCatchAt:
+			c.addClickRipple(a.X, a.Y)
+		case game.ActionUseMagnet:
+			if done {
+				log.Printf("Magnet on for %v", game.MagnetDuration)
+				c.autosaveDue()
+			}
+		}
+	}
+}
+
+// confirmName keeps the high score with the name typed, on the score
+// server if there is one, and on the period boards it makes. Arcade scores
+// and those of levels with a board of their own are always kept locally.
+func (c *Controller) confirmName() {
+	// Grab the entry before confirming clears the name buffer
+	_, _, level := c.GameLogic.GetGameState()
+	_, _, name := c.GameLogic.GetHighScoreData()
+	score := c.GameLogic.Score()
+	score.Name = name
+
+	c.scoresShared = c.Leaderboard != nil && c.levelBoards()
+	c.apply(game.Action{Kind: game.ActionConfirmName})
+	if score.Name == "" {
+		score.Name = "Anonymous" // Same default as HandleEnter
+	}
+	c.addPeriodScore(level, score)
+	if c.scoresShared {
+		c.submitScore(level, score)
+	}
+}
+
+// saveHighScores is the game's high score saver, see NewController. The
+// score server keeps the shared list, so then there's nothing to write
+// locally.
+func (c *Controller) saveHighScores(scores []model.Score, path string) error {
+	if c.scoresShared {
+		return nil
+	}
+	return persistence.SaveHighScores(scores, path)
+}
@@ -0,0 +1,142 @@
+package frontend
+
+import (
+	"log"
+
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/ui"
+)
+
+// Channels of a Mixer, each with its own volume and mute switch. The master
+// channel applies on top of the other two.
+const (
+	ChannelMaster = iota
+	ChannelMusic
+	ChannelEffects
+)
+
+// audioChannels names the channels in the order the audio settings show them.
+var audioChannels = []string{ChannelMaster: "Master", ChannelMusic: "Music", ChannelEffects: "Sound effects"}
+
+// Mixer is a frontend's sound volumes. Frontends without sound (the
+// terminal) leave Controller.Mixer nil and the audio settings are hidden.
+type Mixer interface {
+	Volume(channel int) (percent int, muted bool)
+	SetVolume(channel, percent int, muted bool) // Right away, Save keeps it for the next session
+	Save() error
+	TestSound()     // Plays a sound effect at the current volumes
+	Device() string // Where the sound goes, for the settings page
+
+	// The outputs sound can go to, the system's default first. SetOutput
+	// moves the sound right away, and Save keeps it for the next session.
+	Outputs() []string
+	Output() int
+	SetOutput(output int) error
+	RestartOutput() error // Reopens the output, for when it went silent
+}
+
+// audioSettingsPage is the screen for setting the output and the volume of
+// every channel.
+type audioSettingsPage struct {
+	menu         *ui.Menu
+	outputs      *ui.List
+	before       []channelVolume // By channel, to go back to on cancel
+	outputBefore int
+	status       string // Outcome of the last output change
+}
+
+// channelVolume is the setting of a single Mixer channel.
+type channelVolume struct {
+	percent int
+	muted   bool
+}
+
+// openAudioSettings shows the audio settings page filled with the current volumes.
+func (c *Controller) openAudioSettings() {
+	page := &audioSettingsPage{
+		outputs:      &ui.List{Label: "Output:", Items: c.Mixer.Outputs(), Rows: 2, Selected: c.Mixer.Output()},
+		outputBefore: c.Mixer.Output(),
+	}
+	// ENTER on an output moves the sound there and goes on to the volumes
+	page.outputs.OnSelect = func(output int) {
+		c.setAudioOutput(output)
+		page.menu.Focus = 2
+	}
+	widgets := []ui.Widget{page.outputs, &ui.Button{Label: "Restart audio output", OnPress: c.restartAudioOutput}}
+	for channel, name := range audioChannels {
+		percent, muted := c.Mixer.Volume(channel)
+		page.before = append(page.before, channelVolume{percent, muted})
+		volume := &ui.Slider{Label: name, Value: percent, Min: 0, Max: 100, Step: 10, Unit: "%"}
+		mute := &ui.Toggle{Label: "Mute " + name, On: muted}
+		// Changes are heard right away, ENTER on Save keeps them
+		volume.OnChange = func(percent int) { c.Mixer.SetVolume(channel, percent, mute.On) }
+		mute.OnChange = func(muted bool) { c.Mixer.SetVolume(channel, volume.Value, muted) }
+		widgets = append(widgets, volume, mute)
+	}
+	widgets = append(widgets,
+		&ui.Button{Label: "Test sound", OnPress: c.Mixer.TestSound},
+		&ui.Button{Label: "Save", OnPress: c.saveAudioSettings},
+		&ui.Button{Label: "Cancel", OnPress: c.cancelAudioSettings})
+	page.menu = newMenu(80, widgets...)
+	c.audioSettings = page
+}
+
+// setAudioOutput moves the sound to one of the Mixer's outputs.
+func (c *Controller) setAudioOutput(output int) {
+	if err := c.Mixer.SetOutput(output); err != nil {
+		log.Printf("Could not switch the audio output: %v", err)
+		c.audioSettings.status = "Could not switch the audio output"
+		return
+	}
+	c.audioSettings.status = ""
+}
+
+// restartAudioOutput reopens the audio output, for sound that went silent.
+func (c *Controller) restartAudioOutput() {
+	if err := c.Mixer.RestartOutput(); err != nil {
+		log.Printf("Could not restart the audio output: %v", err)
+		c.audioSettings.status = "Could not restart the audio output"
+		return
+	}
+	c.audioSettings.status = "Audio output restarted"
+}
+
+// cancelAudioSettings closes the page, putting back the output and the
+// volumes it was opened with.
+func (c *Controller) cancelAudioSettings() {
+	page := c.audioSettings
+	for channel, v := range page.before {
+		c.Mixer.SetVolume(channel, v.percent, v.muted)
+	}
+	if c.Mixer.Output() != page.outputBefore {
+		c.setAudioOutput(page.outputBefore)
+	}
+	c.audioSettings = nil
+}
+
+// saveAudioSettings keeps the volumes set on the page and closes it.
+func (c *Controller) saveAudioSettings() {
+	if err := c.Mixer.Save(); err != nil {
+		log.Printf("Could not save audio settings: %v", err)
+	}
+	c.audioSettings = nil
+}
+
+// updateAudioSettings handles input while the audio settings page is open.
+func (c *Controller) updateAudioSettings(in Input) {
+	if in.Pressed(KeyBack) {
+		c.cancelAudioSettings()
+		return
+	}
+	c.audioSettings.menu.Update(c.menuInput(in))
+}
+
+// drawAudioSettings renders the audio settings page.
+func (c *Controller) drawAudioSettings(r Renderer) {
+	r.DrawText("Audio Settings", ScreenWidth/2, 45, ColorYellow, true)
+	c.audioSettings.menu.Draw(r)
+	if status := c.audioSettings.status; status != "" {
+		r.DrawText(status, ScreenWidth/2, ScreenHeight-70, ColorYellow, true)
+	}
+	r.DrawText("Output: "+c.Mixer.Device(), ScreenWidth/2, ScreenHeight-50, ColorGray, true)
+	r.DrawText("UP/DOWN=Choose LEFT/RIGHT=Adjust ENTER=Select ESC=Cancel", 10, ScreenHeight-20, ColorGray, false)
+}
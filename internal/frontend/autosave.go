@@ -0,0 +1,50 @@
+package frontend
+
+import (
+	"log"
+	"time"
+
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/game"
+)
+
+// autosaveInterval is the least time between two autosaves. Changes made
+// meanwhile are saved once it's up.
+const autosaveInterval = 3 * time.Second
+
+// autosaveDue asks for an autosave after a change worth keeping, a catch
+// or a power-up, see updateAutosave.
+func (c *Controller) autosaveDue() {
+	c.autosavePending = true
+}
+
+// updateAutosave saves the level being played if something changed since
+// the last autosave and that was long enough ago.
+func (c *Controller) updateAutosave(state game.GameState, level int) {
+	if !c.autosavePending || state != game.StatePlaying || level < 0 {
+		return
+	}
+	if time.Since(c.lastAutosave) < autosaveInterval {
+		return
+	}
+	c.autosavePending = false
+	c.lastAutosave = time.Now()
+	if !c.requestSave(true) {
+		log.Println("Autosave failed: not playing a level")
+		return
+	}
+	c.saves = append(c.saves, true)
+}
+
+// SaveOnExit saves the level being played, if any, when the game is about to
+// exit, so the run can be picked up again with L. WaitForSaves on the game
+// waits for it to be written. The frontend may be gone, so the save goes
+// without a thumbnail.
+func (c *Controller) SaveOnExit() {
+	state, _, level := c.GameLogic.GetGameState()
+	if (state != game.StatePlaying && state != game.StatePaused) || level < 0 {
+		return
+	}
+	if c.requestSave(false) {
+		log.Printf("Saving level %d on exit.", level)
+	}
+}
@@ -0,0 +1,185 @@
+package frontend
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/campaign"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/game"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/model"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/paths"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/persistence"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/ui"
+)
+
+// campaignEnd is the screen shown once a campaign is won or lost, with name
+// entry for the campaign Hall of Fame when the total made it.
+type campaignEnd struct {
+	progress *campaign.Progress
+	won      bool
+	entering bool // Typing a name for the campaign Hall of Fame
+	name     []rune
+	scores   []model.Score
+}
+
+// StartCampaign resumes the saved campaign, or starts a new one at level 0.
+// Practice runs can be paused and rewound, so there's no campaign in
+// practice mode.
+func (c *Controller) StartCampaign() error {
+	if c.GameLogic.Practice() {
+		return errors.New("campaigns can't be played in practice mode")
+	}
+	if mode := c.GameLogic.Mode(); mode != game.ModeClassic {
+		return fmt.Errorf("campaigns can't be played in %s mode", strings.ToLower(mode.String()))
+	}
+	progress, err := campaign.Load(paths.CampaignPath(c.GameLogic.DataDir()))
+	if errors.Is(err, fs.ErrNotExist) {
+		progress = campaign.New()
+	} else if err != nil {
+		return err
+	}
+	c.campaign = progress
+	return c.loadCampaignLevel()
+}
+
+// leaveCampaign stops playing the campaign, e.g. when another level is
+// picked. Its progress stays saved to be resumed.
+func (c *Controller) leaveCampaign() {
+	c.campaign = nil
+}
+
+// loadCampaignLevel starts the level the campaign is at.
+func (c *Controller) loadCampaignLevel() error {
+	if err := c.loadLevel(c.campaign.Level, func(error) { c.campaign = nil }); err != nil {
+		c.campaign = nil
+		return err
+	}
+	return nil
+}
+
+// recordCampaignRun adds the run that just ended to the campaign and saves
+// its progress.
+func (c *Controller) recordCampaignRun(bounces int) {
+	if c.campaign == nil {
+		return
+	}
+	c.campaign.Record(bounces, c.GameLogic.Failed())
+	if err := campaign.Save(c.campaign, paths.CampaignPath(c.GameLogic.DataDir())); err != nil {
+		log.Printf("Could not save campaign progress: %v", err)
+	}
+}
+
+// continueCampaign goes on once the screens of the level that just ended
+// are done: to the next level, the same one again after a failure, or the
+// end of the campaign.
+func (c *Controller) continueCampaign() {
+	progress := c.campaign
+	_, err := os.Stat(standardLevelPath(progress.Level))
+	switch {
+	case progress.Over():
+		c.endCampaign(false)
+	case err != nil:
+		c.endCampaign(true) // Every level cleared
+	default:
+		if err := c.loadCampaignLevel(); err != nil {
+			log.Printf("Could not load campaign level %d: %v", progress.Level, err)
+		}
+	}
+}
+
+// endCampaign shows the end of the campaign and forgets its saved progress.
+func (c *Controller) endCampaign(won bool) {
+	progress := c.campaign
+	c.campaign = nil
+	path := paths.CampaignPath(c.GameLogic.DataDir())
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		log.Printf("Could not remove finished campaign %s: %v", path, err)
+	}
+	log.Printf("Campaign over (won: %t): %d bounces over %d levels.", won, progress.TotalBounces, len(progress.Levels))
+
+	end := &campaignEnd{progress: progress, won: won}
+	if won {
+		scores, err := persistence.LoadHighScores(paths.CampaignHighScorePath(c.GameLogic.DataDir()))
+		if err != nil {
+			log.Printf("Could not load campaign high scores: %v", err)
+			scores = []model.Score{}
+		}
+		end.scores = scores
+		_, end.entering = model.AddScore(scores, model.Score{Score: progress.TotalBounces}, time.Now())
+	}
+	c.campaignEnd = end
+}
+
+// updateCampaignEnd handles input on the campaign end screen.
+func (c *Controller) updateCampaignEnd(in Input) {
+	end := c.campaignEnd
+	if !end.entering {
+		if c.switched(in) {
+			c.campaignEnd = nil
+			c.GameLogic.ResetToStart()
+		}
+		return
+	}
+
+	// A single switch can't type, its press confirms the default name
+	switchPressed := c.oneSwitch && in.Pressed(KeySwitch)
+	if len(in.Chars) > 0 && !switchPressed && len(end.name) < 15 {
+		end.name = append(end.name, in.Chars...)
+	}
+	if in.Backspace && len(end.name) > 0 {
+		end.name = end.name[:len(end.name)-1]
+	}
+	if in.Pressed(KeyConfirm) || switchPressed {
+		name := string(end.name)
+		if name == "" {
+			name = "Anonymous" // Same default as the level Hall of Fame
+		}
+		end.scores, _ = model.AddScore(end.scores, model.Score{Name: name, Score: end.progress.TotalBounces}, time.Now())
+		if err := persistence.SaveHighScores(end.scores, paths.CampaignHighScorePath(c.GameLogic.DataDir())); err != nil {
+			log.Printf("Failed to save campaign high scores: %v", err)
+		}
+		end.entering = false
+	}
+}
+
+// drawCampaignEnd renders the campaign end screen.
+func (c *Controller) drawCampaignEnd(r Renderer) {
+	end := c.campaignEnd
+	if end.won {
+		r.DrawText("CAMPAIGN COMPLETE!", ScreenWidth/2, 50, ColorYellow, true)
+	} else {
+		r.DrawText("CAMPAIGN OVER - out of lives", ScreenWidth/2, 50, ColorRed, true)
+	}
+	r.DrawText(fmt.Sprintf("Total: %d bounces over %d levels", end.progress.TotalBounces, len(end.progress.Levels)), ScreenWidth/2, 80, ColorWhite, true)
+
+	if end.entering {
+		r.DrawText("New Campaign High Score!", ScreenWidth/2, ScreenHeight/2-60, ColorYellow, true)
+		r.DrawText("Enter Your Name:", ScreenWidth/2, ScreenHeight/2-20, ColorWhite, true)
+		r.DrawText(ui.ComposedText(string(end.name), c.composing, false), ScreenWidth/2, nameEntryY, ColorWhite, true)
+		r.DrawText("Press ENTER to Confirm", ScreenWidth/2, ScreenHeight/2+60, ColorWhite, true)
+		return
+	}
+
+	if end.won {
+		r.DrawText("Campaign Hall of Fame", ScreenWidth/2, 120, ColorYellow, true)
+		for i, score := range end.scores {
+			y := 160 + float64(i)*25
+			r.DrawText(fmt.Sprintf("%d.", i+1), ScreenWidth/3, y, ColorWhite, false)
+			r.DrawText(fmt.Sprintf("%s  -  %d Bounces", score.Name, score.Score), ScreenWidth/2+20, y, ColorWhite, false)
+		}
+	}
+	r.DrawText("Press ENTER or Click to Continue", ScreenWidth/2, ScreenHeight-50, ColorWhite, true)
+}
+
+// drawCampaignStatus shows where the campaign stands while a level is played.
+func (c *Controller) drawCampaignStatus(r Renderer, bounces int) {
+	if c.campaign == nil {
+		return
+	}
+	r.DrawText(fmt.Sprintf("Campaign - Lives: %d  Total: %d", c.campaign.Lives, c.campaign.TotalBounces+bounces), 10, 60, ColorYellow, false)
+}
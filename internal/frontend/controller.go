@@ -0,0 +1,862 @@
+package frontend
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"log"
+	"math"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/achievements"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/campaign"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/config"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/game"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/ghost"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/heatmap"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/inputlog"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/leaderboard"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/levelgen"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/model"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/persistence"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/scoresync"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/spectate"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/twitch"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/ui"
+)
+
+// Snapshots are sent to spectators at most this often, well below the tick rate.
+const spectateInterval = time.Second / 20
+
+// nameEntryY is where the name being typed is shown on the name entry screens.
+const nameEntryY = ScreenHeight/2 + 20
+
+// ErrQuit is returned by Controller.Update when the player asks to quit.
+var ErrQuit = errors.New("user requested quit")
+
+// Define colors used
+var (
+	ColorBlack    = color.RGBA{0, 0, 0, 255}
+	ColorWhite    = color.RGBA{255, 255, 255, 255}
+	ColorYellow   = color.RGBA{R: 255, G: 255, B: 0, A: 255}
+	ColorRed      = color.RGBA{R: 255, G: 50, B: 50, A: 255}
+	ColorGray     = color.Gray{Y: 150}
+	ColorDarkBlue = color.RGBA{0, 0, 10, 255}
+)
+
+// Controller drives a game.Game from frontend-agnostic input and draws it
+// through a Renderer, so every frontend shares the same screens and key handling.
+type Controller struct {
+	GameLogic    *game.Game
+	Leaderboard  *leaderboard.Client   // Optional score server shared high scores live on instead of local files
+	LiveScores   *scoresync.Watcher    // Optional score-sync stream keeping the shown high scores up to date
+	Spectators   *spectate.Hub         // Optional WebSocket hub the run is streamed to
+	Achievements *achievements.Tracker // Optional tracker recording unlocks and mirroring them to the platform
+	InputLog     *inputlog.Recorder    // Optional log of every click and key action, for bug reports
+	Records      *persistence.Records  // Optional personal bests of the player's profile
+	Profile      *persistence.Profile  // Optional player profile Hall of Fame names are marked as rivals in
+	Display      Display               // Optional window whose monitor and fullscreen mode can be picked
+	Music        Music                 // Optional playlist played during levels
+	Mixer        Mixer                 // Optional sound volumes, set on the audio settings page
+	Thumbnail    func() image.Image    // Optional capture of the frame shown, kept with saves for the save browser
+
+	// ReducedMotion drops purely decorative motion, such as the Pacmans'
+	// chomping, for players sensitive to it. Gameplay is unchanged, and any
+	// motion-heavy feedback (shake, particles, flashes) must check it first.
+	ReducedMotion bool
+
+	ShowGhost bool // Draw the level's best recorded run as a ghost to race against
+
+	MotionTrails bool // Draw fading trails behind fast Pacmans, see recordTrails
+
+	palette      string    // See SetPalette
+	paletteStart time.Time // When PaletteCycle's day started
+
+	// The run being played, see startRun
+	runStart    time.Time
+	standardRun bool             // Run of a standard level from its start, counts for personal bests
+	newPB       *newPersonalBest // Personal best the last run set
+	runClicks   []heatmap.Point  // Clicks of the run, for the level's heatmap
+	session     *scoreSession    // Score server session of the run, see startSession
+
+	// Recording of the run being played, and the best one to race, see startGhost
+	ghostRecorder *ghost.Recorder
+	ghostBest     *ghost.Run
+
+	indicators   []soundIndicator // Sound events shown on screen, see ShowSoundIndicators
+	catchEffects []catchEffect    // Catches still being shown, see drawCatchEffects
+	ripples      []clickRipple    // Clicks still being shown, see drawClickRipples
+	impacts      []wallImpact     // Wall bounces still being shown, see drawWallImpacts
+	trails       [][]trailPoint   // Recent positions of the fast Pacmans, see recordTrails
+	trailTicks   int
+	oneSwitch    bool // See EnableOneSwitch
+
+	lastSnapshot time.Time
+	loggedCursor inputlog.Click // Last pointer position written to the input log
+
+	hasCursor        bool    // The frontend has reported the pointer, see updateHover
+	cursorX, cursorY float64 // Last pointer position
+	composing        string  // See Input.Composing
+
+	musicTrack string // Title of the track last shown, see updateMusic
+	toast      *toast // Non-nil while a toast is up, see showToast
+
+	// Saves being written in the background, see reportSaves
+	saves           []bool // Whether each one, oldest first, is an autosave
+	autosavePending bool   // Something changed since the last autosave, see updateAutosave
+	lastAutosave    time.Time
+
+	scoresShared bool          // The high score being confirmed goes to the score server, see saveHighScores
+	periodBoards *periodBoards // The loaded level's boards of this week and month, see periodScores
+	hallTab      int           // Hall of Fame board shown, 0 for all time and then one of periods
+	hallTop      int           // First Hall of Fame entry shown, see navigateHallOfFame
+	nameKeyboard *nameKeyboard // Made when a name is first entered after a run, see keyboard
+
+	// Twitch chat interaction mode, see EnableTwitch
+	twitchSettingsPath string
+	twitch             *twitch.Client
+	twitchSettings     *twitchSettingsPage // Non-nil while the settings page is open
+
+	displaySettings *displaySettingsPage // Non-nil while the display settings page is open
+	audioSettings   *audioSettingsPage   // Non-nil while the audio settings page is open
+
+	// Menus of the start screen
+	mainMenu       *ui.Menu
+	menuDifficulty int               // Difficulty of the main menu's quick play
+	levelSelect    *levelSelectPage  // Non-nil while the level select is open
+	saveBrowser    *saveBrowserPage  // Non-nil while the save browser is open
+	scoreManager   *scoreManagerPage // Non-nil while the Hall of Fame manager is open
+	progress       *progressPage     // Non-nil while the progress screen is open
+	quitRequested  bool              // The main menu's Quit was picked
+
+	recovery *recoveryPrompt // Non-nil while asking whether to recover a damaged save
+	results  *game.RunStats  // Non-nil while showing the results of the run that just ended
+	heatmap  *heatmapPage    // Non-nil while the click heatmap stats screen is open
+
+	campaign    *campaign.Progress // Campaign being played, see StartCampaign
+	campaignEnd *campaignEnd       // Non-nil while showing how the campaign ended
+
+	// Set while playing something other than the standard levels, see PlayLevelFile and Quickplay
+	replay    func() error     // Starts the same level again
+	quickplay *levelgen.Config // Generated level being played
+
+	levelLoaded func(err error) // Goes on once the level loading in the background is in, see requestLevel
+
+	// Network requests and the watchdog running in the background, see Close
+	ctx         context.Context // Canceled by Close
+	cancel      context.CancelFunc
+	cancelFetch context.CancelFunc // Stops the fetch of the scores of the level played before, see fetchScores
+	background  sync.WaitGroup
+
+	watchdog watchdog // Reports stalled ticks, see runWatchdog
+}
+
+// NewController wraps a game, injects the persistence functions it needs and
+// registers its hooks.
+func NewController(g *game.Game) *Controller {
+	g.SetHighScoreLoader(persistence.LoadHighScores)
+	g.SetSaveWriter(persistence.SaveGame)
+	c := &Controller{GameLogic: g, menuDifficulty: defaultMenuDifficulty}
+	c.ctx, c.cancel = context.WithCancel(context.Background())
+	c.background.Add(1)
+	go c.runWatchdog()
+	g.SetHighScoreSaver(c.saveHighScores)
+	g.OnCatch(c.addCatchEffect)
+	g.OnCatch(func(game.CatchEvent) { c.autosaveDue() })
+	g.OnBounce(c.addWallImpact)
+	return c
+}
+
+// Update applies one tick of input and advances the game state. A tick that
+// stalls is reported by the watchdog and recovered from once it's over.
+func (c *Controller) Update(in Input) error {
+	c.watchdog.begin()
+	err := c.update(in)
+	if took, stalled := c.watchdog.end(); stalled {
+		c.recoverFromStall(took)
+	}
+	return err
+}
+
+// update is Update without the watchdog.
+func (c *Controller) update(in Input) error {
+	if finished, err := c.GameLogic.PollLevelLoad(); finished && c.levelLoaded != nil {
+		loaded := c.levelLoaded
+		c.levelLoaded = nil
+		loaded(err)
+	}
+	c.reportSaves()
+	// Use the game's method to get state safely
+	state, _, currentLevel := c.GameLogic.GetGameState()
+	if state == game.StateLoading {
+		return c.updateLoading(in)
+	}
+	c.recordInput(in)
+	c.updateHover(in)
+	c.composing = in.Composing
+	c.updateMusic(state, in)
+
+	// Prompts and the settings page take all input while they're open
+	if c.recovery != nil {
+		c.updateRecoveryPrompt(in)
+		return nil
+	}
+	if c.twitchSettings != nil {
+		c.updateTwitchSettings(in)
+		return nil
+	}
+	if c.displaySettings != nil {
+		c.updateDisplaySettings(in)
+		return nil
+	}
+	if c.audioSettings != nil {
+		c.updateAudioSettings(in)
+		return nil
+	}
+	if c.levelSelect != nil {
+		c.updateLevelSelect(in)
+		return nil
+	}
+	if c.saveBrowser != nil {
+		c.updateSaveBrowser(in)
+		return nil
+	}
+	if c.scoreManager != nil {
+		c.updateScoreManager(in)
+		return nil
+	}
+	if c.heatmap != nil {
+		c.updateHeatmap(in)
+		return nil
+	}
+	if c.progress != nil {
+		c.updateProgress(in)
+		return nil
+	}
+	if c.campaignEnd != nil {
+		c.updateCampaignEnd(in)
+		return nil
+	}
+	if c.results != nil {
+		if in.Pressed(KeyQuit) {
+			return ErrQuit
+		}
+		c.updateResults(in)
+		return nil
+	}
+
+	// --- Global Input Handling ---
+	// Q is a letter of the name while one is being typed
+	if in.Pressed(KeyQuit) && state != game.StateEnteringHighScore {
+		return ErrQuit
+	}
+
+	// --- Input based on Game State ---
+	switch state {
+	case game.StatePlaying:
+		c.updateCursor(in)
+		c.recordClick(in)
+		c.apply(c.playActions(in)...)
+		if in.Pressed(KeyLoad) {
+			if currentLevel >= 0 {
+				c.loadSave(c.GameLogic.SaveGamePath(currentLevel))
+			} else {
+				log.Println("Cannot load: No level currently active to determine save file.")
+			}
+		}
+		if in.Pressed(KeyLevel0) {
+			c.leaveCampaign()
+			c.LoadLevel(0)
+		}
+		if in.Pressed(KeyLevel1) {
+			c.leaveCampaign()
+			c.LoadLevel(1)
+		}
+		if in.Pressed(KeyLevel2) {
+			c.leaveCampaign()
+			c.LoadLevel(2)
+		}
+
+		c.applyChatCommands()
+		if !c.rewind(in) {
+			c.GameLogic.Update()
+		}
+		c.recordGhost(in)
+		c.recordTrails()
+		if newState, bounces, _ := c.GameLogic.GetGameState(); newState != game.StatePlaying && newState != game.StateLoading && newState != game.StatePaused {
+			c.levelFinished(newState, bounces, currentLevel)
+		} else {
+			c.updateAutosave(newState, currentLevel)
+		}
+
+	case game.StatePaused:
+		c.updatePaused(in)
+
+	case game.StateGameOver:
+		if c.rewind(in) {
+			break
+		}
+		c.updateGameOver(in, currentLevel)
+
+	case game.StateEnteringHighScore:
+		c.updateNameEntry(in)
+
+	case game.StateHallOfFame:
+		if in.Pressed(KeyStats) {
+			c.openHeatmap(currentLevel)
+			return nil
+		}
+		c.navigateHallOfFame(in, currentLevel)
+		if c.switched(in) {
+			if c.campaign != nil {
+				c.continueCampaign()
+			} else {
+				c.continueAfterHallOfFame(currentLevel)
+			}
+		}
+
+	case game.StateStarting:
+		if in.Pressed(KeySettings) && c.twitchSettingsPath != "" {
+			c.openTwitchSettings()
+			return nil
+		}
+		if in.Pressed(KeyDisplay) && c.Display != nil {
+			c.openDisplaySettings()
+			return nil
+		}
+		if in.Pressed(KeyAudio) && c.Mixer != nil {
+			c.openAudioSettings()
+			return nil
+		}
+		if in.Pressed(KeyStats) {
+			c.openHeatmap(0)
+			return nil
+		}
+		if in.Pressed(KeyCampaign) {
+			if err := c.StartCampaign(); err != nil {
+				log.Printf("Failed to start campaign: %v", err)
+			}
+			return nil
+		}
+		c.startMenu().Update(c.menuInput(in))
+		if c.quitRequested {
+			return ErrQuit
+		}
+	}
+
+	c.publishSnapshot()
+	return nil
+}
+
+// restart plays the level that just ended again.
+func (c *Controller) restart(level int) {
+	if c.replay != nil {
+		if err := c.replay(); err != nil {
+			log.Printf("Restart failed: %v", err)
+		}
+	} else if level >= 0 {
+		c.LoadLevel(level)
+	} else {
+		c.LoadLevel(0) // Default fallback
+	}
+}
+
+// levelFinished shows the results of the run that just ended and unlocks
+// the achievements it earned.
+func (c *Controller) levelFinished(state game.GameState, bounces, level int) {
+	stats := c.GameLogic.Stats()
+	c.results = &stats
+	c.recordCampaignRun(bounces)
+	c.finishSession(stats)
+	c.finishHeatmap(level, stats)
+	c.finishRun(level, bounces)
+	c.hallTab, c.hallTop = 0, 0
+	c.nameKeyboard = nil
+	c.offerPeriodBoards(state, level)
+	if c.quickplay != nil {
+		log.Printf("Quick play over (%s): %d bounces. Share the seed to challenge others!", c.quickplay, bounces)
+	}
+	// Practice runs can be paused and rewound, they don't count, and failed
+	// and arcade and time attack runs earn nothing
+	if c.Achievements == nil || c.GameLogic.Practice() || c.GameLogic.Failed() || c.GameLogic.Mode() != game.ModeClassic {
+		return
+	}
+	c.Achievements.Unlock(achievements.LevelCleared)
+	if bounces == 0 {
+		c.Achievements.Unlock(achievements.PerfectRun)
+	}
+	if state == game.StateEnteringHighScore {
+		c.Achievements.Unlock(achievements.HallOfFame)
+	}
+}
+
+// publishSnapshot streams the current state to spectators, if a hub is configured.
+func (c *Controller) publishSnapshot() {
+	if c.Spectators == nil || time.Since(c.lastSnapshot) < spectateInterval {
+		return
+	}
+	c.lastSnapshot = time.Now()
+
+	state, bounces, level := c.GameLogic.GetGameState()
+	snapshot := spectate.Snapshot{Level: level, Bounces: bounces, State: int(state), Pacmans: []spectate.Entity{}}
+	for _, p := range c.GameLogic.GetPacmanData() {
+		if !p.IsStopped {
+			snapshot.Pacmans = append(snapshot.Pacmans, spectate.Entity{
+				int(math.Round(p.PosX)), int(math.Round(p.PosY)), int(math.Round(p.Radius)), p.AnimFrame,
+			})
+		}
+	}
+	c.Spectators.Publish(snapshot)
+}
+
+// Draw renders the screen for the current game state.
+func (c *Controller) Draw(r Renderer) {
+	r = c.themedRenderer(r)
+	r.Fill(ColorDarkBlue)
+
+	if state, _, _ := c.GameLogic.GetGameState(); state == game.StateLoading {
+		c.drawLoading(r)
+		return
+	}
+	if c.recovery != nil {
+		c.drawRecoveryPrompt(r)
+		return
+	}
+	if c.twitchSettings != nil {
+		c.drawTwitchSettings(r)
+		return
+	}
+	if c.displaySettings != nil {
+		c.drawDisplaySettings(r)
+		return
+	}
+	if c.audioSettings != nil {
+		c.drawAudioSettings(r)
+		return
+	}
+	if c.levelSelect != nil {
+		c.drawLevelSelect(r)
+		return
+	}
+	if c.saveBrowser != nil {
+		c.drawSaveBrowser(r)
+		return
+	}
+	if c.scoreManager != nil {
+		c.drawScoreManager(r)
+		return
+	}
+	if c.heatmap != nil {
+		c.drawHeatmap(r)
+		return
+	}
+	if c.progress != nil {
+		c.drawProgress(r)
+		return
+	}
+	if c.campaignEnd != nil {
+		c.drawCampaignEnd(r)
+		return
+	}
+	if c.results != nil {
+		c.drawResults(r)
+		return
+	}
+
+	// Use game's method to get state safely
+	state, bounces, level := c.GameLogic.GetGameState()
+
+	switch state {
+	case game.StateStarting:
+		r.DrawText("Catch The Pac-Man!", ScreenWidth/2, 50, ColorWhite, true)
+		c.startMenu().Draw(r)
+		r.DrawText("UP/DOWN=Choose ENTER or Click=Select Q=Quit", 10, ScreenHeight-20, ColorGray, false)
+
+	case game.StatePlaying, game.StatePaused, game.StateGameOver:
+		pacmans := c.GameLogic.GetPacmanData()
+		c.drawTrails(r, pacmans)
+		for i, pData := range pacmans {
+			if !pData.IsStopped {
+				if c.ReducedMotion {
+					pData.AnimFrame = 0
+				}
+				c.squash(i, &pData)
+				r.DrawEntity(pData)
+				if pData.Stunned {
+					r.DrawRing(pData.PosX, pData.PosY, pData.Radius+4, ColorWhite) // Grab it before it breaks free
+				}
+			}
+		}
+		c.drawPowerUps(r)
+		c.drawWallImpacts(r)
+		c.drawHover(r)
+		c.drawClickRipples(r)
+		c.drawCatchEffects(r)
+		c.drawGhost(r)
+		c.drawSoundIndicators(r)
+		c.drawHighlight(r)
+		c.drawPractice(r)
+		c.drawToast(r)
+
+		if c.quickplay != nil {
+			r.DrawText(fmt.Sprintf("Seed: %d", c.quickplay.Seed), 10, 20, ColorWhite, false)
+		} else {
+			r.DrawText(fmt.Sprintf("Level: %d", level), 10, 20, ColorWhite, false)
+		}
+		if limit := c.GameLogic.BounceLimit(); limit > 0 {
+			r.DrawText(fmt.Sprintf("Bounces: %d/%d", bounces, limit), ScreenWidth-150, 20, ColorWhite, false)
+		} else {
+			r.DrawText(fmt.Sprintf("Bounces: %d", bounces), ScreenWidth-150, 20, ColorWhite, false)
+		}
+		if scale := c.GameLogic.SpriteScale(); scale > 1 {
+			r.DrawText(fmt.Sprintf("Sprites %gx", scale), ScreenWidth-150, 40, ColorGray, false)
+		}
+		c.drawMisses(r, ScreenWidth-150, 80)
+		c.drawLives(r, ScreenWidth-150, 120)
+		c.drawChain(r)
+		c.drawRival(r, bounces)
+		c.drawArcade(r)
+		c.drawTimeAttack(r)
+		c.drawMagnet(r)
+		c.drawPowerUpEffects(r)
+		if handicap := c.GameLogic.Handicap(); handicap.Active() {
+			r.DrawText("Handicap: "+handicap.String(), 10, 80, ColorGray, false)
+		}
+		if state != game.StateGameOver {
+			c.drawCampaignStatus(r, bounces)
+		} else {
+			c.drawCampaignStatus(r, 0) // The campaign total already has the run
+		}
+		c.drawHUDAccent(r)
+		r.DrawText("Click PacMan!", ScreenWidth/2, 20, c.hudAccent(), true)
+		r.DrawText("S=Save L=Load P=Pause Q=Quit F1/F2/F3=Level", 10, ScreenHeight-20, ColorGray, false)
+
+		if state == game.StatePaused {
+			c.drawPaused(r)
+		}
+
+		if state == game.StateGameOver {
+			if c.GameLogic.OutOfLives() {
+				r.DrawText("OUT OF LIVES!", ScreenWidth/2, ScreenHeight/2-30, ColorRed, true)
+				r.DrawText("Run failed: every life was lost to a click that caught nothing", ScreenWidth/2, ScreenHeight/2-10, ColorGray, true)
+			} else if c.GameLogic.Failed() && c.GameLogic.Mode() == game.ModeTimeAttack && c.GameLogic.TimeAttackTimeLeft() == 0 {
+				r.DrawText("OUT OF TIME!", ScreenWidth/2, ScreenHeight/2-30, ColorRed, true)
+				r.DrawText("Run failed: not every Pac-Man was caught in time", ScreenWidth/2, ScreenHeight/2-10, ColorGray, true)
+			} else if c.GameLogic.Failed() {
+				r.DrawText("OUT OF BOUNCES!", ScreenWidth/2, ScreenHeight/2-30, ColorRed, true)
+				r.DrawText(fmt.Sprintf("Run failed: over the level's budget of %d bounces", c.GameLogic.BounceLimit()), ScreenWidth/2, ScreenHeight/2-10, ColorGray, true)
+			} else if c.GameLogic.Arcade() {
+				r.DrawText("TIME'S UP!", ScreenWidth/2, ScreenHeight/2-30, ColorRed, true)
+			} else {
+				r.DrawText("GAME OVER!", ScreenWidth/2, ScreenHeight/2-30, ColorRed, true)
+			}
+			c.drawGameOverChoices(r, level)
+			c.drawNewPersonalBest(r, ScreenHeight/2-70)
+			if c.quickplay != nil {
+				r.DrawText("Quick play "+c.quickplay.String(), ScreenWidth/2, ScreenHeight/2+50, ColorYellow, true)
+			} else {
+				r.DrawText(fmt.Sprintf("Seed: %d", c.GameLogic.RunSeed()), ScreenWidth/2, ScreenHeight/2+50, ColorGray, true)
+			}
+		}
+
+	case game.StateEnteringHighScore:
+		r.DrawText(fmt.Sprintf("Level: %d", level), 10, 20, ColorWhite, false)
+		r.DrawText(fmt.Sprintf("Bounces: %d", bounces), ScreenWidth-150, 20, ColorWhite, false)
+		c.drawMisses(r, ScreenWidth-150, 40)
+
+		r.DrawText("New High Score!", ScreenWidth/2, ScreenHeight/2-60, ColorYellow, true)
+		c.drawNewPersonalBest(r, ScreenHeight/2-100)
+		r.DrawText("Enter Your Name:", ScreenWidth/2, ScreenHeight/2-20, ColorWhite, true)
+
+		// Use game's method GetHighScoreData safely
+		_, _, nameInput := c.GameLogic.GetHighScoreData()
+		r.DrawText(ui.ComposedText(nameInput, c.composing, false), ScreenWidth/2, nameEntryY, ColorWhite, true)
+
+		if c.oneSwitch {
+			r.DrawText("Press ENTER to Confirm", ScreenWidth/2, ScreenHeight/2+60, ColorWhite, true)
+		} else {
+			k := c.keyboard()
+			k.menu.Draw(r)
+			if k.keys.Active {
+				r.DrawText("ARROWS=Move ENTER=Press key OK=Confirm", 10, ScreenHeight-20, ColorGray, false)
+			} else {
+				r.DrawText("ENTER=Confirm ARROWS/Click=On-screen keyboard", 10, ScreenHeight-20, ColorGray, false)
+			}
+		}
+		if c.quickplay != nil {
+			r.DrawText("Quick play "+c.quickplay.String(), ScreenWidth/2, ScreenHeight-45, ColorGray, true)
+		}
+
+	case game.StateHallOfFame:
+		mode := c.GameLogic.Mode()
+		if mode != game.ModeClassic {
+			r.DrawText(mode.String()+" Hall of Fame - Level "+strconv.Itoa(level), ScreenWidth/2, 50, ColorYellow, true)
+		} else {
+			r.DrawText("Hall of Fame - Level "+strconv.Itoa(level), ScreenWidth/2, 50, ColorYellow, true)
+		}
+
+		scores, tab := c.hallOfFameScores(level)
+		if c.levelBoards() {
+			r.DrawText("< "+tab+" >", ScreenWidth/2, 75, ColorWhite, true)
+		}
+		yPos := 100.0
+		top := min(c.hallTop, max(len(scores)-hallOfFameRows, 0))
+		run := c.GameLogic.Score().ID // The run just played's entry, if it made the board
+		for i := top; i < min(top+hallOfFameRows, len(scores)); i++ {
+			score := scores[i]
+			rankStr := fmt.Sprintf("%d.", i+1)
+			scoreStr := fmt.Sprintf("%s  -  %s", score.Name, c.scoreText(score))
+			if score.Assisted() {
+				scoreStr += fmt.Sprintf(" (%gx)", score.SpriteScale)
+			}
+			if score.Misses > 0 {
+				scoreStr += fmt.Sprintf(" (%d missed)", score.Misses)
+			}
+			if score.Chain > 1 {
+				scoreStr += fmt.Sprintf(" (x%d chain)", score.Chain)
+			}
+			if score.Handicap.Active() {
+				scoreStr += " [" + score.Handicap.String() + "]"
+			}
+			clr := ColorWhite
+			if run != "" && score.ID == run {
+				clr = ColorYellow
+			}
+			r.DrawText(rankStr, ScreenWidth/3, yPos, clr, false)
+			r.DrawText(scoreStr, ScreenWidth/2+20, yPos, clr, false) // Adjust X slightly for alignment
+			yPos += 30
+		}
+
+		if len(scores) == 0 {
+			r.DrawText("No scores yet!", ScreenWidth/2, ScreenHeight/2, ColorGray, true)
+		}
+		if top > 0 {
+			r.DrawText("^", ScreenWidth-40, 100, ColorGray, false)
+		}
+		if top+hallOfFameRows < len(scores) {
+			r.DrawText("v", ScreenWidth-40, yPos-30, ColorGray, false)
+		}
+
+		r.DrawText("Press ENTER or Click to Continue", ScreenWidth/2, ScreenHeight-50, ColorWhite, true)
+		if !c.levelBoards() {
+			r.DrawText("H=Click heatmap", 10, ScreenHeight-20, ColorGray, false)
+		} else {
+			r.DrawText("LEFT/RIGHT=Board UP/DOWN=Scroll H=Click heatmap", 10, ScreenHeight-20, ColorGray, false)
+		}
+	}
+}
+
+// submitScore sends a new high score to the score server and refreshes the
+// shown list from it. It runs in the background so a slow server never stalls the game loop.
+// Close waits for it rather than cancel it, so a score entered just before
+// quitting isn't lost.
+func (c *Controller) submitScore(level int, score model.Score) {
+	if score.Name == "" {
+		score.Name = "Anonymous" // Same default as HandleEnter
+	}
+	run := c.takeSessionRun()
+	c.background.Add(1)
+	go func() {
+		defer c.background.Done()
+		result, err := c.Leaderboard.Submit(context.Background(), level, score, run)
+		if err != nil {
+			log.Printf("Could not submit score to leaderboard server: %v", err)
+			return
+		}
+		log.Printf("Score submitted to leaderboard server (made the board: %t, rank %d).", result.Added, result.Rank)
+		c.refreshScores(c.ctx, level)
+	}()
+}
+
+// levelBoards reports whether the run played counts on the boards every
+// run of its level number shares beyond its Hall of Fame: the score
+// server's and the period boards. Only classic runs of levels without a
+// board of their own do, see game.Game.Board: level files played directly
+// and generated levels have one.
+func (c *Controller) levelBoards() bool {
+	return c.GameLogic.Mode() == game.ModeClassic && c.GameLogic.LevelBoard() == ""
+}
+
+// fetchScores replaces the level's high scores with the score server's shared
+// list, if a server is configured. Network errors keep the local list. A
+// fetch still under way for the level played before is canceled.
+func (c *Controller) fetchScores(level int) {
+	if c.Leaderboard == nil || !c.levelBoards() {
+		return
+	}
+	if c.cancelFetch != nil {
+		c.cancelFetch()
+	}
+	ctx, cancel := context.WithCancel(c.ctx)
+	c.cancelFetch = cancel
+	c.background.Add(1)
+	go func() {
+		defer c.background.Done()
+		defer cancel()
+		c.refreshScores(ctx, level)
+	}()
+}
+
+// refreshScores replaces the level's high scores with the score server's.
+// It blocks, see fetchScores.
+func (c *Controller) refreshScores(ctx context.Context, level int) {
+	scores, err := c.Leaderboard.Scores(ctx, level)
+	if ctx.Err() != nil {
+		return // Left the level or quit
+	}
+	if err != nil {
+		log.Printf("Could not fetch scores from leaderboard server: %v", err)
+		return
+	}
+	c.GameLogic.SetHighScores(level, scores)
+}
+
+// watchScores switches the live score stream to the given level, if one is configured.
+func (c *Controller) watchScores(level int) {
+	if c.LiveScores == nil || !c.levelBoards() {
+		return
+	}
+	c.LiveScores.Watch(c.ctx, level, func(scores []model.Score) {
+		c.GameLogic.SetHighScores(level, scores)
+	})
+}
+
+// Close stops the background work when the game exits: score fetches are
+// canceled, the live score stream and Twitch chat are closed, and score
+// submissions under way are waited for.
+func (c *Controller) Close() {
+	c.cancel()
+	c.StopTwitch()
+	if c.LiveScores != nil {
+		if err := c.LiveScores.Close(); err != nil {
+			log.Printf("Error closing score-sync stream: %v", err)
+		}
+	}
+	c.background.Wait()
+}
+
+// LoadLevel starts loading a specific level from the standard level
+// directory, in the background. A level that fails to load is logged and
+// the game stays where it was.
+func (c *Controller) LoadLevel(level int) error {
+	return c.loadLevel(level, nil)
+}
+
+// loadLevel starts loading a standard level, like LoadLevel. failed, if not
+// nil, is called if it couldn't be loaded after all.
+func (c *Controller) loadLevel(level int, failed func(error)) error {
+	levelPath := standardLevelPath(level)
+	// Pass the actual LoadLevelConfig function from config
+	return c.requestLevel(levelPath, config.LoadLevelConfig, func(err error) {
+		if err != nil {
+			c.showToast(loadErrorMessage(err))
+			if failed != nil {
+				failed(err)
+			}
+			return
+		}
+		c.replay, c.quickplay = nil, nil
+		c.recordLevel(levelPath)
+		c.startRun(level)
+		c.fetchScores(level)
+		c.watchScores(level)
+	})
+}
+
+// requestLevel starts loading a level in the background, see
+// game.Game.RequestLoadLevel. loaded is called from Update once it's in,
+// or with why it failed.
+func (c *Controller) requestLevel(path string, loadFunc func(string) (*game.Game, error), loaded func(err error)) error {
+	if err := c.GameLogic.RequestLoadLevel(path, loadFunc); err != nil {
+		return err
+	}
+	c.levelLoaded = loaded
+	return nil
+}
+
+// requestSavedGame starts loading a saved game in the background, see
+// game.Game.RequestLoadSavedGame. loaded is called like requestLevel's.
+func (c *Controller) requestSavedGame(path string, loadFunc func(string) (*game.Game, error), loaded func(err error)) error {
+	if err := c.GameLogic.RequestLoadSavedGame(path, loadFunc); err != nil {
+		return err
+	}
+	c.levelLoaded = loaded
+	return nil
+}
+
+// PlayLevelFile starts loading a level from any level file in the
+// background, like LoadLevel, and starts it right away once it's in,
+// skipping the start screen, e.g. to try out a custom level. Its scores
+// are kept on a board of the file's own, see levelFileBoard.
+func (c *Controller) PlayLevelFile(path string) error {
+	return c.requestLevel(path, loadLevelFile, func(err error) {
+		if err != nil {
+			c.showToast(loadErrorMessage(err))
+			return
+		}
+		c.replay = func() error { return c.PlayLevelFile(path) }
+		c.quickplay = nil
+		c.recordLevel(path)
+		c.stopRun()
+	})
+}
+
+// loadLevelFile reads a level file played directly, on its own board.
+func loadLevelFile(path string) (*game.Game, error) {
+	levelData, err := config.LoadLevelConfig(path)
+	if err != nil {
+		return nil, err
+	}
+	if levelData.Board, err = levelFileBoard(path); err != nil {
+		return nil, err
+	}
+	return levelData, nil
+}
+
+// levelFileBoard names the Hall of Fame of a level file played directly
+// after its content, so its scores never mix with those of the standard
+// level it declares, nor with another file's, and an edited file starts
+// a board afresh.
+func levelFileBoard(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("error reading level file %s: %w", path, err)
+	}
+	sum := sha256.Sum256(data)
+	return "file_" + hex.EncodeToString(sum[:8]), nil
+}
+
+// Quickplay generates the random level of cfg and starts it right away.
+// Restarting replays the same level, and its seed is shown at game over
+// so it can be shared.
+func (c *Controller) Quickplay(cfg levelgen.Config) error {
+	levelData, err := levelgen.Generate(cfg, ScreenWidth, ScreenHeight)
+	if err != nil {
+		return err
+	}
+	loaded := func(string) (*game.Game, error) { return levelData, nil }
+	return c.requestLevel("quickplay: "+cfg.String(), loaded, func(err error) {
+		if err != nil {
+			return
+		}
+		c.replay = func() error { return c.Quickplay(cfg) }
+		c.quickplay = &cfg
+		c.recordLevel("quickplay: " + cfg.String())
+		c.stopRun()
+	})
+}
+
+// QuickplaySeed returns the generated level being played, if any.
+func (c *Controller) QuickplaySeed() (levelgen.Config, bool) {
+	if c.quickplay == nil {
+		return levelgen.Config{}, false
+	}
+	return *c.quickplay, true
+}
@@ -0,0 +1,110 @@
+package frontend
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/ui"
+)
+
+// Display modes, how a window fills the monitor.
+const (
+	DisplayWindowed   = "windowed"
+	DisplayFullscreen = "fullscreen" // Exclusive fullscreen
+	DisplayBorderless = "borderless" // A window without decorations covering the monitor, quick to alt-tab out of
+)
+
+// DisplayModes lists the modes in the order the display settings go through them.
+var DisplayModes = []string{DisplayWindowed, DisplayFullscreen, DisplayBorderless}
+
+// Sizes the HUD and menu text can be scaled to, in percent, see Display.SetTextScale.
+const (
+	MinTextScale  = 75
+	MaxTextScale  = 200
+	textScaleStep = 25
+)
+
+// Display is a frontend's window, which can go fullscreen on one of the
+// system's monitors. Frontends without one (the terminal) leave
+// Controller.Display nil and the display settings are hidden.
+type Display interface {
+	Monitors() []string                   // Names of the connected monitors, the primary one first
+	Current() (monitor int, mode string)  // Monitor index fullscreen uses, and one of DisplayModes
+	Apply(monitor int, mode string) error // Switches to them and remembers them for the next session
+
+	// The size of the HUD and menu text in percent, apart from the sprites.
+	// SetTextScale resizes it right away, and Apply keeps it for the next session.
+	TextScale() int
+	SetTextScale(percent int)
+}
+
+// displaySettingsPage is the screen for picking the monitor fullscreen uses.
+type displaySettingsPage struct {
+	menu       *ui.Menu
+	monitors   *ui.List
+	modes      *ui.List
+	textScale  *ui.Slider
+	textBefore int // Text size to go back to on cancel
+}
+
+// openDisplaySettings shows the display settings page filled with the current settings.
+func (c *Controller) openDisplaySettings() {
+	monitor, mode := c.Display.Current()
+	page := &displaySettingsPage{
+		monitors: &ui.List{Label: "Monitor used in fullscreen:", Rows: 4, Selected: monitor},
+		modes:    &ui.List{Label: "Mode:", Items: DisplayModes},
+		textScale: &ui.Slider{Label: "Text size", Value: c.Display.TextScale(), Min: MinTextScale, Max: MaxTextScale,
+			Step: textScaleStep, Unit: "%", OnChange: c.Display.SetTextScale},
+		textBefore: c.Display.TextScale(),
+	}
+	for i, name := range c.Display.Monitors() {
+		page.monitors.Items = append(page.monitors.Items, fmt.Sprintf("%d. %s", i+1, name))
+	}
+	for i, m := range DisplayModes {
+		if m == mode {
+			page.modes.Selected = i
+		}
+	}
+	// ENTER on a list goes on to the next part of the page
+	page.monitors.OnSelect = func(int) { page.menu.Focus = 1 }
+	page.modes.OnSelect = func(int) { page.menu.Focus = 2 }
+	page.menu = newMenu(110, page.monitors, page.modes, page.textScale,
+		&ui.Button{Label: "Save", OnPress: c.saveDisplaySettings},
+		&ui.Button{Label: "Cancel", OnPress: c.cancelDisplaySettings})
+	c.displaySettings = page
+}
+
+// cancelDisplaySettings closes the page, putting back the text size it was opened with.
+func (c *Controller) cancelDisplaySettings() {
+	c.Display.SetTextScale(c.displaySettings.textBefore)
+	c.displaySettings = nil
+}
+
+// saveDisplaySettings applies the settings picked on the page and closes it.
+func (c *Controller) saveDisplaySettings() {
+	page := c.displaySettings
+	if err := c.Display.Apply(page.monitors.Selected, DisplayModes[page.modes.Selected]); err != nil {
+		log.Printf("Could not save display settings: %v", err)
+	}
+	c.displaySettings = nil
+}
+
+// updateDisplaySettings handles input while the display settings page is open.
+func (c *Controller) updateDisplaySettings(in Input) {
+	if in.Pressed(KeyBack) {
+		c.cancelDisplaySettings()
+		return
+	}
+	c.displaySettings.menu.Update(c.menuInput(in))
+}
+
+// drawDisplaySettings renders the display settings page.
+func (c *Controller) drawDisplaySettings(r Renderer) {
+	page := c.displaySettings
+	r.DrawText("Display Settings", ScreenWidth/2, 60, ColorYellow, true)
+	page.menu.Draw(r)
+	if DisplayModes[page.modes.Selected] == DisplayBorderless {
+		r.DrawText("Borderless: a window covering the monitor, quick to switch away from", ScreenWidth/2, ScreenHeight-50, ColorGray, true)
+	}
+	r.DrawText("UP/DOWN=Choose LEFT/RIGHT=Adjust ENTER=Select ESC=Cancel", 10, ScreenHeight-20, ColorGray, false)
+}
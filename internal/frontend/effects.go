@@ -0,0 +1,195 @@
+package frontend
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"time"
+
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/game"
+)
+
+// Timing of the catch effect: the caught Pacman holds its last frame for a
+// beat, then blinks out, while a white ring flashes where it was caught.
+const (
+	catchFreezeDuration = 300 * time.Millisecond
+	catchDeathDuration  = 200 * time.Millisecond // Blinking out after the freeze
+	catchBlinkInterval  = 50 * time.Millisecond
+	catchFlashDuration  = 250 * time.Millisecond
+	catchFlashGrowth    = 12.0 // How far past the Pacman the ring spreads
+	chainPopupDuration  = 700 * time.Millisecond
+	chainPopupRise      = 30.0 // How far the "x2!" of a chain floats up
+)
+
+// catchEffect is a catch still being shown.
+type catchEffect struct {
+	pacman game.PacmanDrawData // As it was drawn when caught
+	chain  int                 // Multiplier of the catch's chain, shown above it from 2 on
+	at     time.Time
+}
+
+// addCatchEffect is the game's catch hook, see NewController.
+func (c *Controller) addCatchEffect(e game.CatchEvent) {
+	c.catchEffects = append(c.catchEffects, catchEffect{pacman: e.Pacman, chain: e.Chain, at: time.Now()})
+}
+
+// drawCatchEffects draws the catches still showing and drops the finished
+// ones. With reduced motion the Pacman only holds its frame, without the
+// blinking and the flash, and chain multipliers don't float up.
+func (c *Controller) drawCatchEffects(r Renderer) {
+	now := time.Now()
+	kept := c.catchEffects[:0]
+	for _, e := range c.catchEffects {
+		age := now.Sub(e.at)
+		if age >= max(catchFreezeDuration+catchDeathDuration, catchFlashDuration, chainPopupDuration) {
+			continue
+		}
+		kept = append(kept, e)
+
+		p := e.pacman
+		if c.ReducedMotion {
+			p.AnimFrame = 0
+		}
+		switch {
+		case age < catchFreezeDuration:
+			r.DrawEntity(p)
+		case age < catchFreezeDuration+catchDeathDuration && !c.ReducedMotion:
+			if (age-catchFreezeDuration)/catchBlinkInterval%2 == 0 {
+				r.DrawEntity(p)
+			}
+		}
+		if age < catchFlashDuration && !c.ReducedMotion {
+			grown := catchFlashGrowth * float64(age) / float64(catchFlashDuration)
+			r.DrawRing(p.PosX, p.PosY, p.Radius+grown, ColorWhite)
+		}
+		if e.chain > 1 && age < chainPopupDuration {
+			rise := 0.0
+			if !c.ReducedMotion {
+				rise = chainPopupRise * float64(age) / float64(chainPopupDuration)
+			}
+			r.DrawText(fmt.Sprintf("x%d!", e.chain), p.PosX, p.PosY-p.Radius-20-rise, ColorYellow, true)
+		}
+	}
+	c.catchEffects = kept
+}
+
+// Click ripples: a small ring spreading from every click, yellow where it hit
+// a Pacman and red where it missed, showing exactly where it registered.
+const (
+	rippleDuration    = 300 * time.Millisecond
+	rippleStartRadius = 3.0
+	rippleEndRadius   = 15.0
+)
+
+// clickRipple is a click still being shown.
+type clickRipple struct {
+	x, y float64
+	hit  bool
+	at   time.Time
+}
+
+// addClickRipple shows a click at x, y. Call it before the game handles the
+// click, while the Pacman it hits is still running.
+func (c *Controller) addClickRipple(x, y float64) {
+	_, hit := c.GameLogic.HoveredPacman(x, y)
+	c.ripples = append(c.ripples, clickRipple{x: x, y: y, hit: hit, at: time.Now()})
+}
+
+// drawClickRipples draws the ripples still spreading and drops the finished
+// ones. With reduced motion the ring stays put instead of spreading.
+func (c *Controller) drawClickRipples(r Renderer) {
+	now := time.Now()
+	kept := c.ripples[:0]
+	for _, e := range c.ripples {
+		age := now.Sub(e.at)
+		if age >= rippleDuration {
+			continue
+		}
+		kept = append(kept, e)
+
+		radius := rippleStartRadius
+		if !c.ReducedMotion {
+			radius += (rippleEndRadius - rippleStartRadius) * float64(age) / float64(rippleDuration)
+		}
+		clr := ColorRed
+		if e.hit {
+			clr = ColorYellow
+		}
+		r.DrawRing(e.x, e.y, radius, clr)
+	}
+	c.ripples = kept
+}
+
+// Wall impacts: a Pacman bouncing off a wall squashes against it for a moment
+// while a few sparks fly off where it hit.
+const (
+	squashDuration = 120 * time.Millisecond
+	squashAmount   = 0.35 // How much thinner it gets along the wall's normal, at first
+	sparkDuration  = 200 * time.Millisecond
+	sparkLength    = 6.0
+	sparkTravel    = 10.0 // How far the sparks fly off
+	sparkSpread    = math.Pi / 4
+)
+
+// wallImpact is a wall bounce still being shown.
+type wallImpact struct {
+	game.BounceEvent
+	at time.Time
+}
+
+// addWallImpact is the game's bounce hook, see NewController.
+func (c *Controller) addWallImpact(e game.BounceEvent) {
+	c.impacts = append(c.impacts, wallImpact{BounceEvent: e, at: time.Now()})
+}
+
+// squash squashes the i-th Pacman against the wall it just bounced off, if
+// it did. Skipped with reduced motion.
+func (c *Controller) squash(i int, p *game.PacmanDrawData) {
+	if c.ReducedMotion {
+		return
+	}
+	now := time.Now()
+	for _, e := range c.impacts {
+		age := now.Sub(e.at)
+		if e.Index != i || age >= squashDuration {
+			continue
+		}
+		amount := squashAmount * (1 - float64(age)/float64(squashDuration))
+		along, across := 1-amount, 1+amount/2
+		p.ScaleX, p.ScaleY = across, along
+		if e.NormalX != 0 {
+			p.ScaleX, p.ScaleY = along, across
+		}
+		// Keep it touching the wall while it's thinner
+		p.PosX -= e.NormalX * p.Radius * amount
+		p.PosY -= e.NormalY * p.Radius * amount
+	}
+}
+
+// drawWallImpacts draws the sparks of the wall bounces still showing and drops
+// the finished ones. With reduced motion there are no sparks.
+func (c *Controller) drawWallImpacts(r Renderer) {
+	now := time.Now()
+	kept := c.impacts[:0]
+	for _, e := range c.impacts {
+		age := now.Sub(e.at)
+		if age >= max(squashDuration, sparkDuration) {
+			continue
+		}
+		kept = append(kept, e)
+		if age >= sparkDuration || c.ReducedMotion {
+			continue
+		}
+
+		t := float64(age) / float64(sparkDuration)
+		a := uint8(255 * (1 - t))
+		clr := color.RGBA{R: a, G: a, B: a, A: a} // White, premultiplied
+		normal := math.Atan2(e.NormalY, e.NormalX)
+		for _, angle := range []float64{normal - sparkSpread, normal, normal + sparkSpread} {
+			dx, dy := math.Cos(angle), math.Sin(angle)
+			near, far := sparkTravel*t, sparkTravel*t+sparkLength*(1-t)
+			r.DrawLine(e.X+dx*near, e.Y+dy*near, e.X+dx*far, e.Y+dy*far, clr)
+		}
+	}
+	c.impacts = kept
+}
@@ -0,0 +1,28 @@
+package frontend
+
+import (
+	"errors"
+
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/config"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/fileformat"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/persistence"
+)
+
+// loadErrorMessage tells the player why a save or level didn't load, in
+// words a toast can show.
+func loadErrorMessage(err error) string {
+	switch {
+	case errors.Is(err, persistence.ErrSaveNotFound):
+		return "No save for this level yet"
+	case errors.Is(err, persistence.ErrCorruptSave):
+		return "This save is corrupted"
+	case errors.Is(err, persistence.ErrEncrypted), errors.Is(err, persistence.ErrWrongKey), errors.Is(err, persistence.ErrNotEncrypted):
+		return "This save can't be decrypted"
+	case errors.Is(err, fileformat.ErrUnsupportedVersion):
+		return "Made with a newer version of the game"
+	case errors.Is(err, config.ErrInvalidLevel):
+		return "This level file is broken"
+	default:
+		return "Could not load"
+	}
+}
@@ -0,0 +1,106 @@
+package frontend
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/game"
+)
+
+// Logical screen size every frontend maps onto its own output (window, terminal, ...).
+const (
+	ScreenWidth  = 640
+	ScreenHeight = 480
+)
+
+// Key is a frontend-agnostic game action bound to a key or button.
+type Key int
+
+const (
+	KeyConfirm Key = iota // Enter: start, confirm name, continue
+	KeyQuit
+	KeySave
+	KeyLoad
+	KeyLevel0
+	KeyLevel1
+	KeyLevel2
+	KeyBack     // Escape: leave a menu
+	KeySettings // T: open the Twitch chat settings
+	KeySwitch   // Space: the single switch of one-switch mode
+	KeyPause    // P or Escape: pause or resume the level
+	KeyStep     // Period: advance one tick while paused in practice mode
+	KeyRewind   // R (held): rewind in practice mode
+	KeyStats    // H: open the click heatmap stats screen
+	KeyCampaign // C: start or resume the campaign
+	KeyMagnet   // M: use the level's magnet power-up
+	KeyDisplay  // D: open the display settings
+	KeyAudio    // A: open the audio settings
+	KeyUp       // Arrow keys or a gamepad's d-pad: move around menus
+	KeyDown
+	KeyLeft
+	KeyRight
+	KeyNextTrack // ]: skip to the next music track
+	KeyPrevTrack // [: go back to the music track before
+)
+
+// Input is a snapshot of the player's input for a single tick.
+type Input struct {
+	Clicked          bool    // Primary button was just pressed
+	ClickX, ClickY   float64 // Click position in logical screen coordinates
+	HasCursor        bool    // The frontend reported where the pointer is this tick
+	CursorX, CursorY float64 // Pointer position in logical screen coordinates
+	Keys             []Key   // Actions whose key was just pressed
+	Held             []Key   // Actions whose key is held down, for the ones that act while held
+	Chars            []rune  // Typed characters (name entry)
+	Composing        string  // Text an input method is still composing, shown after the typed text until it's committed to Chars
+	Backspace        bool    // Backspace pressed or repeating
+}
+
+// Pressed reports whether the given action was triggered this tick.
+func (in Input) Pressed(k Key) bool {
+	for _, key := range in.Keys {
+		if key == k {
+			return true
+		}
+	}
+	return false
+}
+
+// Holding reports whether the given action's key is held down this tick.
+func (in Input) Holding(k Key) bool {
+	for _, key := range in.Held {
+		if key == k {
+			return true
+		}
+	}
+	return false
+}
+
+// Renderer draws game elements onto a frontend's output using logical screen coordinates.
+type Renderer interface {
+	Fill(clr color.Color)
+	DrawEntity(p game.PacmanDrawData)
+	DrawText(str string, x, y float64, clr color.Color, center bool)
+	DrawRect(x, y, width, height float64, clr color.Color) // Filled
+	DrawRing(x, y, radius float64, clr color.Color)        // Outline only
+	DrawLine(x1, y1, x2, y2 float64, clr color.Color)
+}
+
+// ImageRenderer is a Renderer that can also draw images, scaled to fill the
+// given rectangle, e.g. the thumbnails of saves. Renderers that can't, like
+// the terminal's, go without them.
+type ImageRenderer interface {
+	DrawImage(img image.Image, x, y, width, height float64)
+}
+
+// InputSource collects the player's input once per tick.
+type InputSource interface {
+	PollInput() Input
+}
+
+// Scene is what a frontend's loop drives every tick: a Controller playing a
+// game or a Spectator watching one.
+type Scene interface {
+	Update(in Input) error
+	Draw(r Renderer)
+}
@@ -0,0 +1,94 @@
+package frontend
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+	"io/fs"
+	"log"
+	"time"
+
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/ghost"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/paths"
+)
+
+// Clicks of the ghost run stay marked this long after they happened.
+const ghostClickShown = 0.5
+
+// Ghost overlay colors, translucent where the frontend supports it.
+var (
+	colorGhost      = color.RGBA{R: 150, G: 150, B: 255, A: 110}
+	colorGhostClick = color.RGBA{R: 255, G: 255, B: 255, A: 140}
+)
+
+// startGhost starts recording a run of a standard level and, with
+// ShowGhost, loads the best recorded run of it to race against.
+func (c *Controller) startGhost(level int) {
+	c.ghostRecorder = ghost.NewRecorder(level)
+	c.ghostBest = nil
+
+	best, err := ghost.Load(paths.GhostPath(c.GameLogic.DataDir(), level))
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			log.Printf("Ghost disabled: %v", err)
+		}
+		return
+	}
+	c.ghostBest = best
+}
+
+// stopGhost stops recording and hides the ghost, for runs that aren't
+// comparable with the level's recorded ones (loaded saves, custom levels).
+func (c *Controller) stopGhost() {
+	c.ghostRecorder, c.ghostBest = nil, nil
+}
+
+// recordGhost samples the run being played.
+func (c *Controller) recordGhost(in Input) {
+	if c.ghostRecorder == nil {
+		return
+	}
+	t := time.Since(c.runStart).Seconds()
+	if in.Clicked {
+		c.ghostRecorder.Click(t, in.ClickX, in.ClickY)
+	}
+	c.ghostRecorder.Sample(t, c.GameLogic.GetPacmanData())
+}
+
+// finishGhost keeps the run as the level's ghost if it beat the best one.
+// Practice runs can be paused and rewound, so they're never kept.
+func (c *Controller) finishGhost(bounces int) {
+	rec := c.ghostRecorder
+	c.ghostRecorder = nil
+	if rec == nil || c.GameLogic.Practice() {
+		return
+	}
+	run := rec.Finish(time.Since(c.runStart).Seconds(), bounces)
+	path := paths.GhostPath(c.GameLogic.DataDir(), run.Level)
+	if best, err := ghost.Load(path); err == nil && best.Bounces <= bounces {
+		return
+	}
+	if err := ghost.Save(run, path); err != nil {
+		log.Printf("Could not save ghost: %v", err)
+		return
+	}
+	log.Printf("New best run of level %d (%d bounces) saved as its ghost.", run.Level, bounces)
+}
+
+// drawGhost draws the best run at the same point in time as the current one.
+func (c *Controller) drawGhost(r Renderer) {
+	if !c.ShowGhost || c.ghostBest == nil {
+		return
+	}
+	t := time.Since(c.runStart).Seconds()
+	if pacmans, ok := c.ghostBest.At(t); ok {
+		for _, p := range pacmans {
+			r.DrawRing(p.X, p.Y, p.R, colorGhost)
+		}
+	}
+	for _, click := range c.ghostBest.ClicksBetween(t-ghostClickShown, t) {
+		r.DrawLine(click.X-6, click.Y-6, click.X+6, click.Y+6, colorGhostClick)
+		r.DrawLine(click.X-6, click.Y+6, click.X+6, click.Y-6, colorGhostClick)
+	}
+	r.DrawText(fmt.Sprintf("Ghost: %d", c.ghostBest.Bounces), ScreenWidth-150, 60, colorGhost, false)
+}
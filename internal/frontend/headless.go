@@ -0,0 +1,79 @@
+package frontend
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/config"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/game"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/levelgen"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/model"
+)
+
+// HeadlessOptions are the runs RunHeadless plays.
+type HeadlessOptions struct {
+	LevelFile  string // Level played, the first standard level if empty
+	Quickplay  bool   // Play generated levels of Difficulty instead, another one each run
+	Difficulty int
+	Seed       uint64        // Seed of the first run, the next ones count up from it
+	Runs       int           // At least one is played
+	Reaction   time.Duration // Time the bot takes to click the next Pacman
+	Limit      time.Duration // Simulated time a run is given up after
+}
+
+// RunHeadless plays runs on g without drawing anything, as fast as they
+// compute, with a bot clicking the Pacmans one by one, see
+// game.ReactionPlayer. Every run's bounces are written to w as it ends,
+// and a summary of them all after the last. Runs given up on count as
+// failed. Options such as the mode are set on g beforehand.
+func RunHeadless(w io.Writer, g *game.Game, opts HeadlessOptions) error {
+	sim := game.NewSimulator(g)
+	runs := make([]model.Run, 0, max(opts.Runs, 1))
+	for i := range cap(runs) {
+		seed := opts.Seed + uint64(i)
+		level, source, err := headlessLevel(opts, seed)
+		if err != nil {
+			return err
+		}
+		level.Seed = seed
+		pacmans := len(level.Pacmans)
+		sim.Load(level, source)
+		result := sim.Run(game.ReactionPlayer(opts.Reaction), opts.Limit)
+
+		outcome := "cleared"
+		switch {
+		case result.TimedOut:
+			outcome = "gave up"
+		case result.Failed:
+			outcome = "failed"
+		}
+		fmt.Fprintf(w, "run %d (seed %d): %d bounces, caught %d of %d in %s, %s\n",
+			i+1, seed, result.Bounces, result.Stats.Catches, pacmans, formatSeconds(result.Elapsed.Seconds()), outcome)
+		runs = append(runs, model.Run{Level: level.Level, Bounces: result.Bounces, Failed: result.Failed || result.TimedOut, Duration: result.Elapsed})
+	}
+
+	s := model.Summarize(runs)
+	if s.Cleared == 0 {
+		fmt.Fprintf(w, "%d runs, none cleared\n", s.Games)
+		return nil
+	}
+	fmt.Fprintf(w, "%d runs, %d cleared: best %d, median %g, average %.1f bounces\n", s.Games, s.Cleared, s.Best, s.Median, s.Average)
+	return nil
+}
+
+// headlessLevel reads the level a headless run plays, fresh as every run
+// changes it, and names where it came from.
+func headlessLevel(opts HeadlessOptions, seed uint64) (*game.Game, string, error) {
+	if opts.Quickplay {
+		cfg := levelgen.Config{Seed: seed, Difficulty: opts.Difficulty}
+		level, err := levelgen.Generate(cfg, ScreenWidth, ScreenHeight)
+		return level, "quickplay: " + cfg.String(), err
+	}
+	path := opts.LevelFile
+	if path == "" {
+		path = standardLevelPath(0)
+	}
+	level, err := config.LoadLevelConfig(path)
+	return level, path, err
+}
@@ -0,0 +1,121 @@
+package frontend
+
+import (
+	"fmt"
+	"image/color"
+	"log"
+
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/game"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/heatmap"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/paths"
+)
+
+// heatmapCell is the size of the squares clicks are counted in.
+const heatmapCell = 20
+
+// Heatmap colors: cells blend from the background to colorHeatmapHot with
+// their share of the clicks. Cells are opaque so terminals can show them.
+var (
+	colorHeatmapHot   = color.RGBA{R: 255, G: 60, B: 0, A: 255}
+	colorHeatmapCatch = color.RGBA{R: 80, G: 255, B: 80, A: 255}
+)
+
+// heatmapPage is the stats screen showing where the player clicks on a level.
+type heatmapPage struct {
+	level int
+	m     *heatmap.Map
+	err   error
+}
+
+// recordClick remembers a click of the run being played for the level's heatmap.
+func (c *Controller) recordClick(in Input) {
+	if in.Clicked && c.standardRun {
+		c.runClicks = append(c.runClicks, heatmap.Point{X: in.ClickX, Y: in.ClickY})
+	}
+}
+
+// finishHeatmap adds the clicks and catches of the run that just ended to
+// the level's heatmap. Only runs of the standard levels from their start
+// count, as other layouts would blur the map.
+func (c *Controller) finishHeatmap(level int, stats game.RunStats) {
+	clicks := c.runClicks
+	c.runClicks = nil
+	if !c.standardRun {
+		return
+	}
+	var catches []heatmap.Point
+	for _, p := range stats.Pacmans {
+		if p.Caught && p.CaughtAt > 0 {
+			catches = append(catches, heatmap.Point{X: p.X, Y: p.Y})
+		}
+	}
+
+	path := paths.HeatmapPath(c.GameLogic.DataDir(), level)
+	m, err := heatmap.Load(path, level)
+	if err != nil {
+		log.Printf("Could not update heatmap: %v", err)
+		return
+	}
+	m.Add(clicks, catches)
+	if err := heatmap.Save(m, path); err != nil {
+		log.Printf("Could not save heatmap: %v", err)
+	}
+}
+
+// openHeatmap opens the stats screen on a level's heatmap.
+func (c *Controller) openHeatmap(level int) {
+	m, err := heatmap.Load(paths.HeatmapPath(c.GameLogic.DataDir(), level), level)
+	c.heatmap = &heatmapPage{level: level, m: m, err: err}
+}
+
+// updateHeatmap handles input while the stats screen is open.
+func (c *Controller) updateHeatmap(in Input) {
+	switch {
+	case in.Pressed(KeyBack) || in.Pressed(KeyStats) || c.switched(in):
+		c.heatmap = nil
+	case in.Pressed(KeyLevel0):
+		c.openHeatmap(0)
+	case in.Pressed(KeyLevel1):
+		c.openHeatmap(1)
+	case in.Pressed(KeyLevel2):
+		c.openHeatmap(2)
+	}
+}
+
+// drawHeatmap renders the stats screen: click density as colored cells and
+// each catch as a ring.
+func (c *Controller) drawHeatmap(r Renderer) {
+	page := c.heatmap
+	if page.err != nil {
+		r.DrawText(fmt.Sprintf("Heatmap of level %d unavailable", page.level), ScreenWidth/2, ScreenHeight/2-20, ColorRed, true)
+		r.DrawText(page.err.Error(), ScreenWidth/2, ScreenHeight/2+10, ColorGray, true)
+		r.DrawText("ESC=Back", 10, ScreenHeight-20, ColorGray, false)
+		return
+	}
+
+	counts, highest := page.m.Grid(heatmapCell, ScreenWidth, ScreenHeight)
+	for row, cols := range counts {
+		for col, n := range cols {
+			if n > 0 {
+				clr := blend(ColorDarkBlue, colorHeatmapHot, float64(n)/float64(highest))
+				r.DrawRect(float64(col*heatmapCell), float64(row*heatmapCell), heatmapCell, heatmapCell, clr)
+			}
+		}
+	}
+	for _, p := range page.m.Catches {
+		r.DrawRing(p.X, p.Y, 3, colorHeatmapCatch)
+	}
+
+	r.DrawText(fmt.Sprintf("Click heatmap - Level %d", page.level), ScreenWidth/2, 20, ColorYellow, true)
+	if len(page.m.Clicks) == 0 {
+		r.DrawText("No clicks recorded yet!", ScreenWidth/2, ScreenHeight/2, ColorGray, true)
+	}
+	r.DrawText(fmt.Sprintf("%d clicks, %d catches (rings)", len(page.m.Clicks), len(page.m.Catches)), ScreenWidth/2, 40, ColorWhite, true)
+	r.DrawText("F1/F2/F3=Level ESC=Back", 10, ScreenHeight-20, ColorGray, false)
+}
+
+// blend mixes two colors, t=0 being from and t=1 being to.
+func blend(from, to color.RGBA, t float64) color.RGBA {
+	mix := func(a, b uint8) uint8 { return uint8(float64(a) + t*(float64(b)-float64(a))) }
+	return color.RGBA{mix(from.R, to.R), mix(from.G, to.G), mix(from.B, to.B), 255}
+}
@@ -0,0 +1,24 @@
+package frontend
+
+import "image/color"
+
+// colorHover outlines the Pacman under the cursor, faint enough not to hide it.
+var colorHover = color.RGBA{R: 255, G: 255, B: 200, A: 120}
+
+// updateHover remembers where the pointer is, for drawHover.
+func (c *Controller) updateHover(in Input) {
+	if in.HasCursor {
+		c.hasCursor, c.cursorX, c.cursorY = true, in.CursorX, in.CursorY
+	}
+}
+
+// drawHover outlines the Pacman a click would catch, as aiming feedback.
+// One-switch mode doesn't aim, so it has no hover.
+func (c *Controller) drawHover(r Renderer) {
+	if !c.hasCursor || c.oneSwitch {
+		return
+	}
+	if p, ok := c.GameLogic.HoveredPacman(c.cursorX, c.cursorY); ok {
+		r.DrawRing(p.PosX, p.PosY, p.Radius+2, colorHover)
+	}
+}
@@ -0,0 +1,82 @@
+package frontend
+
+import (
+	"image/color"
+	"time"
+
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/game"
+)
+
+// How long a sound indicator stays on screen, and its size.
+const (
+	indicatorDuration = 400 * time.Millisecond
+	edgeGlowWidth     = 6.0
+	edgeGlowLength    = 120.0
+	catchRingRadius   = 30.0
+)
+
+// Edge glow colors: yellow warns of an imminent wall hit, white shows a bounce.
+var (
+	colorWarningGlow = color.RGBA{R: 255, G: 255, B: 0, A: 180}
+	colorBounceGlow  = color.RGBA{R: 255, G: 255, B: 255, A: 140}
+)
+
+// soundIndicator is an on-screen stand-in for a sound event.
+type soundIndicator struct {
+	event game.SoundEvent
+	shown time.Time
+}
+
+// ShowSoundIndicators mirrors every sound event on screen for deaf and
+// hard-of-hearing players: the wall a Pacman bounces off or is about to hit
+// glows, and a ring marks each catch.
+func (c *Controller) ShowSoundIndicators() {
+	c.GameLogic.OnSound(func(e game.SoundEvent) {
+		c.indicators = append(c.indicators, soundIndicator{event: e, shown: time.Now()})
+	})
+}
+
+// drawSoundIndicators draws the indicators still showing and drops the
+// expired ones.
+func (c *Controller) drawSoundIndicators(r Renderer) {
+	now := time.Now()
+	kept := c.indicators[:0]
+	for _, ind := range c.indicators {
+		age := now.Sub(ind.shown)
+		if age >= indicatorDuration {
+			continue
+		}
+		kept = append(kept, ind)
+
+		e := ind.event
+		switch e.Name {
+		case game.SoundCatch:
+			radius := catchRingRadius
+			if !c.ReducedMotion {
+				// The ring widens while it's shown
+				radius *= 1 + float64(age)/float64(indicatorDuration)
+			}
+			r.DrawRing(e.X, e.Y, radius, ColorRed)
+		case game.SoundCueWall, game.SoundCueWallTop, game.SoundCueWallBottom:
+			drawEdgeGlow(r, e.X, e.Y, colorWarningGlow)
+		case game.SoundBounce:
+			drawEdgeGlow(r, e.X, e.Y, colorBounceGlow)
+		}
+	}
+	c.indicators = kept
+}
+
+// drawEdgeGlow lights up the part of the nearest screen edge next to (x, y).
+func drawEdgeGlow(r Renderer, x, y float64, clr color.Color) {
+	left, right, top, bottom := x, ScreenWidth-x, y, ScreenHeight-y
+	switch min(left, right, top, bottom) {
+	case left:
+		r.DrawRect(0, y-edgeGlowLength/2, edgeGlowWidth, edgeGlowLength, clr)
+	case right:
+		r.DrawRect(ScreenWidth-edgeGlowWidth, y-edgeGlowLength/2, edgeGlowWidth, edgeGlowLength, clr)
+	case top:
+		r.DrawRect(x-edgeGlowLength/2, 0, edgeGlowLength, edgeGlowWidth, clr)
+	default:
+		r.DrawRect(x-edgeGlowLength/2, ScreenHeight-edgeGlowWidth, edgeGlowLength, edgeGlowWidth, clr)
+	}
+}
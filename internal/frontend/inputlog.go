@@ -0,0 +1,106 @@
+package frontend
+
+import (
+	"log"
+
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/inputlog"
+)
+
+// keyNames are the names keys are written to input logs with.
+var keyNames = map[Key]string{
+	KeyConfirm:   "confirm",
+	KeyQuit:      "quit",
+	KeySave:      "save",
+	KeyLoad:      "load",
+	KeyLevel0:    "level0",
+	KeyLevel1:    "level1",
+	KeyLevel2:    "level2",
+	KeyBack:      "back",
+	KeySettings:  "settings",
+	KeySwitch:    "switch",
+	KeyPause:     "pause",
+	KeyStep:      "step",
+	KeyRewind:    "rewind",
+	KeyStats:     "stats",
+	KeyCampaign:  "campaign",
+	KeyMagnet:    "magnet",
+	KeyDisplay:   "display",
+	KeyAudio:     "audio",
+	KeyUp:        "up",
+	KeyDown:      "down",
+	KeyLeft:      "left",
+	KeyRight:     "right",
+	KeyNextTrack: "next_track",
+	KeyPrevTrack: "prev_track",
+}
+
+func (k Key) String() string {
+	if name, ok := keyNames[k]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// recordInput writes this tick's input to the input log, if one is being recorded.
+func (c *Controller) recordInput(in Input) {
+	if c.InputLog == nil {
+		return
+	}
+	c.InputLog.Tick()
+	e := inputlog.Entry{Text: string(in.Chars), Backspace: in.Backspace}
+	if in.Clicked {
+		e.Click = &inputlog.Click{X: in.ClickX, Y: in.ClickY}
+	}
+	// Frontends may report the pointer every tick, only its moves are logged
+	if cursor := (inputlog.Click{X: in.CursorX, Y: in.CursorY}); in.HasCursor && cursor != c.loggedCursor {
+		e.Cursor = &cursor
+		c.loggedCursor = cursor
+	}
+	for _, k := range in.Keys {
+		e.Keys = append(e.Keys, k.String())
+	}
+	for _, k := range in.Held {
+		e.Held = append(e.Held, k.String())
+	}
+	c.InputLog.Input(e)
+}
+
+// recordLevel notes the level just started in the input log.
+func (c *Controller) recordLevel(source string) {
+	if c.InputLog == nil {
+		return
+	}
+	_, _, level := c.GameLogic.GetGameState()
+	entry := inputlog.Level{Number: level, Source: source, Seed: c.GameLogic.RunSeed(), Hash: c.GameLogic.LayoutHash()}
+	c.InputLog.Level(entry)
+	if err := c.InputLog.Err(); err != nil {
+		log.Printf("Input log stopped: %v", err)
+	}
+}
+
+// InputFromLog turns a logged input entry back into the Input of its tick,
+// e.g. to replay a session from a bug report.
+func InputFromLog(e inputlog.Entry) Input {
+	in := Input{Chars: []rune(e.Text), Backspace: e.Backspace}
+	if e.Click != nil {
+		in.Clicked, in.ClickX, in.ClickY = true, e.Click.X, e.Click.Y
+	}
+	if e.Cursor != nil {
+		in.HasCursor, in.CursorX, in.CursorY = true, e.Cursor.X, e.Cursor.Y
+	}
+	in.Keys = keysFromNames(e.Keys)
+	in.Held = keysFromNames(e.Held)
+	return in
+}
+
+func keysFromNames(names []string) []Key {
+	var keys []Key
+	for _, name := range names {
+		for k, n := range keyNames {
+			if n == name {
+				keys = append(keys, k)
+			}
+		}
+	}
+	return keys
+}
@@ -0,0 +1,49 @@
+package frontend
+
+import (
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/game"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/ui"
+)
+
+// keyboardY is where the name entry's on-screen keyboard starts, under the
+// name being typed.
+const keyboardY = nameEntryY + 40
+
+// nameKeyboard is the on-screen keyboard of the name entry, for players
+// with a gamepad or a touch screen and no physical keyboard.
+type nameKeyboard struct {
+	keys *ui.Keyboard
+	menu *ui.Menu
+}
+
+// keyboard returns the name entry's on-screen keyboard, made afresh for
+// each run, see levelFinished.
+func (c *Controller) keyboard() *nameKeyboard {
+	if c.nameKeyboard == nil {
+		keys := &ui.Keyboard{
+			OnType:  func(r rune) { c.apply(game.TypeName([]rune{r})) },
+			OnErase: func() { c.apply(game.Action{Kind: game.ActionEraseName}) },
+			OnDone:  c.confirmName,
+		}
+		c.nameKeyboard = &nameKeyboard{keys: keys, menu: newMenu(keyboardY, keys)}
+	}
+	return c.nameKeyboard
+}
+
+// updateNameEntry handles input while a high score's name is entered. Until
+// the on-screen keyboard is used, ENTER confirms the name; after, its OK key
+// does. One-switch mode has no use for the keyboard.
+func (c *Controller) updateNameEntry(in Input) {
+	c.apply(c.nameActions(in)...)
+	if c.oneSwitch {
+		if in.Pressed(KeyConfirm) || in.Pressed(KeySwitch) {
+			c.confirmName()
+		}
+		return
+	}
+	k := c.keyboard()
+	k.menu.Update(c.menuInput(in))
+	if !k.keys.Active && in.Pressed(KeyConfirm) {
+		c.confirmName()
+	}
+}
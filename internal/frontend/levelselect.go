@@ -0,0 +1,70 @@
+package frontend
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/ui"
+)
+
+// levelSelectRows is how many levels the level select shows at once.
+const levelSelectRows = 10
+
+// levelSelectPage lists the standard levels, with the personal best of each.
+type levelSelectPage struct {
+	menu *ui.Menu
+}
+
+// openLevelSelect shows the level select page.
+func (c *Controller) openLevelSelect() {
+	var items []string
+	for level := 0; ; level++ {
+		if _, err := os.Stat(standardLevelPath(level)); err != nil {
+			break
+		}
+		items = append(items, c.levelSelectItem(level))
+	}
+	levels := &ui.List{Items: items, Rows: levelSelectRows, OnSelect: func(level int) {
+		c.levelSelect = nil
+		if err := c.LoadLevel(level); err != nil {
+			log.Printf("Failed to load level %d: %v", level, err)
+		}
+	}}
+	back := &ui.Button{Label: "Back", OnPress: func() { c.levelSelect = nil }}
+	c.levelSelect = &levelSelectPage{menu: newMenu(110, levels, back)}
+}
+
+// levelSelectItem describes a level and the player's personal best on it,
+// with what its last improvement gained.
+func (c *Controller) levelSelectItem(level int) string {
+	if c.Records != nil {
+		if pb, ok := c.Records.Levels[level]; ok {
+			text := fmt.Sprintf("Level %d - best: %d bounces, %s", level, pb.Bounces, formatRunDuration(pb.Duration))
+			if pb.Improvement > 0 {
+				text += fmt.Sprintf(" (-%d)", pb.Improvement)
+			}
+			return text
+		}
+	}
+	return fmt.Sprintf("Level %d", level)
+}
+
+// updateLevelSelect handles input while the level select is open.
+func (c *Controller) updateLevelSelect(in Input) {
+	if in.Pressed(KeyBack) {
+		c.levelSelect = nil
+		return
+	}
+	c.levelSelect.menu.Update(c.menuInput(in))
+}
+
+// drawLevelSelect renders the level select.
+func (c *Controller) drawLevelSelect(r Renderer) {
+	r.DrawText("Level Select", ScreenWidth/2, 50, ColorYellow, true)
+	if c.Records != nil {
+		r.DrawText("Personal bests of "+c.Records.Profile, ScreenWidth/2, 75, ColorGray, true)
+	}
+	c.levelSelect.menu.Draw(r)
+	r.DrawText("UP/DOWN=Choose ENTER=Play ESC=Back", 10, ScreenHeight-20, ColorGray, false)
+}
@@ -0,0 +1,39 @@
+package frontend
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Sizes of the loading screen's progress bar.
+const (
+	loadingBarWidth  = 300
+	loadingBarHeight = 12
+)
+
+// updateLoading handles input while the game is in StateLoading, which takes
+// all input but quitting.
+func (c *Controller) updateLoading(in Input) error {
+	if in.Pressed(KeyQuit) || in.Pressed(KeyBack) {
+		return ErrQuit
+	}
+	return nil
+}
+
+// drawLoading renders StateLoading: a progress bar, the item loaded last
+// and the ones that failed.
+func (c *Controller) drawLoading(r Renderer) {
+	p := c.GameLogic.LoadProgress()
+	r.DrawText("Catch The Pac-Man", ScreenWidth/2, 150, ColorYellow, true)
+	x, y := float64(ScreenWidth-loadingBarWidth)/2, 220.0
+	r.DrawRect(x, y, loadingBarWidth, loadingBarHeight, ColorGray)
+	if p.Total > 0 {
+		r.DrawRect(x, y, loadingBarWidth*float64(p.Done)/float64(p.Total), loadingBarHeight, ColorWhite)
+		r.DrawText(fmt.Sprintf("Loading %s (%d/%d)", p.Item, p.Done, p.Total), ScreenWidth/2, y+25, ColorGray, true)
+	} else {
+		r.DrawText("Loading...", ScreenWidth/2, y+25, ColorGray, true)
+	}
+	if len(p.Failed) > 0 {
+		r.DrawText("Could not load: "+strings.Join(p.Failed, ", "), ScreenWidth/2, y+60, ColorRed, true)
+	}
+}
@@ -0,0 +1,29 @@
+package frontend
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/game"
+)
+
+var colorMagnet = color.RGBA{R: 255, G: 80, B: 200, A: 255}
+
+// updateCursor tells the game where the cursor is, for the magnet to pull
+// towards.
+func (c *Controller) updateCursor(in Input) {
+	if in.HasCursor {
+		c.GameLogic.SetCursor(in.CursorX, in.CursorY)
+	}
+}
+
+// drawMagnet shows the reach of an active magnet around the cursor, or
+// whether the level's magnet is still there to use.
+func (c *Controller) drawMagnet(r Renderer) {
+	if x, y, left, active := c.GameLogic.Magnet(); active {
+		r.DrawRing(x, y, game.MagnetRange, colorMagnet)
+		r.DrawText(fmt.Sprintf("Magnet: %s", formatSeconds(left)), ScreenWidth-150, ScreenHeight-20, colorMagnet, false)
+	} else if c.GameLogic.Magnets() > 0 {
+		r.DrawText("M=Magnet", ScreenWidth-150, ScreenHeight-20, ColorGray, false)
+	}
+}
@@ -0,0 +1,124 @@
+package frontend
+
+import (
+	"image/color"
+	"log"
+
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/levelgen"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/ui"
+)
+
+// Menus are this wide, centered on the screen.
+const menuWidth = 360
+
+// defaultMenuDifficulty is the difficulty the main menu's quick play starts at.
+const defaultMenuDifficulty = 3
+
+// menuStyle is the colors of every menu.
+var menuStyle = ui.Style{
+	Text:      ColorWhite,
+	Focused:   ColorYellow,
+	Dim:       ColorGray,
+	Highlight: color.RGBA{R: 30, G: 30, B: 70, A: 255},
+}
+
+// newMenu stacks widgets in a menu centered on the screen, starting at y.
+func newMenu(y float64, widgets ...ui.Widget) *ui.Menu {
+	return &ui.Menu{Widgets: widgets, X: ScreenWidth / 2, Y: y, Width: menuWidth, Style: menuStyle}
+}
+
+// menuInput translates this tick's input into menu navigation. A single
+// switch can only press whatever is focused.
+func (c *Controller) menuInput(in Input) ui.Input {
+	m := ui.Input{
+		Up:         in.Pressed(KeyUp),
+		Down:       in.Pressed(KeyDown),
+		Left:       in.Pressed(KeyLeft),
+		Right:      in.Pressed(KeyRight),
+		Activate:   in.Pressed(KeyConfirm),
+		HasPointer: in.HasCursor,
+		PointerX:   in.CursorX,
+		PointerY:   in.CursorY,
+		Clicked:    in.Clicked,
+		Chars:      in.Chars,
+		Composing:  in.Composing,
+		Backspace:  in.Backspace,
+	}
+	if in.Clicked {
+		m.HasPointer, m.PointerX, m.PointerY = true, in.ClickX, in.ClickY
+	}
+	if c.oneSwitch {
+		m.Activate = c.switched(in)
+		m.Clicked = false
+	}
+	return m
+}
+
+// startMenu returns the main menu of the start screen, its widgets rebuilt
+// from the current settings.
+func (c *Controller) startMenu() *ui.Menu {
+	if c.mainMenu == nil {
+		c.mainMenu = newMenu(100)
+	}
+	c.mainMenu.Widgets = c.mainMenuWidgets()
+	return c.mainMenu
+}
+
+// mainMenuWidgets lists what can be done from the start screen.
+func (c *Controller) mainMenuWidgets() []ui.Widget {
+	widgets := []ui.Widget{
+		&ui.Button{Label: "Play", OnPress: func() {
+			if err := c.LoadLevel(0); err != nil {
+				log.Printf("Failed to load level 0 on start: %v", err)
+			}
+		}},
+		&ui.Button{Label: "Level select", OnPress: c.openLevelSelect},
+		&ui.Button{Label: "Campaign", OnPress: func() {
+			if err := c.StartCampaign(); err != nil {
+				log.Printf("Failed to start campaign: %v", err)
+			}
+		}},
+		&ui.Button{Label: "Quick play", OnPress: c.quickplayFromMenu},
+		&ui.Slider{Label: "Difficulty", Value: c.menuDifficulty, Min: levelgen.MinDifficulty, Max: levelgen.MaxDifficulty,
+			OnChange: func(v int) { c.menuDifficulty = v }},
+		&ui.Button{Label: "Load a save", OnPress: c.openSaveBrowser},
+		&ui.Toggle{Label: "Race ghost", On: c.ShowGhost, OnChange: func(on bool) { c.ShowGhost = on }},
+		&ui.Toggle{Label: "Motion trails", On: c.MotionTrails, OnChange: func(on bool) { c.MotionTrails = on }},
+		&ui.Button{Label: "Click heatmap", OnPress: func() { c.openHeatmap(0) }},
+		&ui.Button{Label: "Manage Hall of Fame", OnPress: func() { c.openScoreManager(0) }},
+	}
+	if c.Profile != nil {
+		widgets = append(widgets, &ui.Toggle{Label: "Resume on launch", On: c.Profile.QuickResume, OnChange: func(on bool) {
+			if err := c.Profile.SetQuickResume(on); err != nil {
+				log.Printf("Could not save the profile: %v", err)
+			}
+		}})
+	}
+	if c.Records != nil {
+		widgets = append(widgets, &ui.Button{Label: "Your progress", OnPress: c.openProgress})
+	}
+	if c.Display != nil {
+		widgets = append(widgets, &ui.Button{Label: "Display settings", OnPress: c.openDisplaySettings})
+	}
+	if c.Mixer != nil {
+		widgets = append(widgets, &ui.Button{Label: "Audio settings", OnPress: c.openAudioSettings})
+	}
+	if c.twitchSettingsPath != "" {
+		label := "Twitch chat: off"
+		if c.twitch != nil {
+			label = "Twitch chat: #" + c.twitch.Channel()
+		}
+		widgets = append(widgets, &ui.Button{Label: label, OnPress: c.openTwitchSettings})
+	}
+	return append(widgets, &ui.Button{Label: "Quit", OnPress: func() { c.quitRequested = true }})
+}
+
+// quickplayFromMenu starts a generated level of the difficulty picked in the main menu.
+func (c *Controller) quickplayFromMenu() {
+	cfg := levelgen.Config{Seed: levelgen.NewSeed(), Difficulty: c.menuDifficulty}
+	if err := c.Quickplay(cfg); err != nil {
+		log.Printf("Failed to start quick play: %v", err)
+		return
+	}
+	log.Printf("Quick play seed: %d", cfg.Seed)
+}
@@ -0,0 +1,46 @@
+package frontend
+
+import "github.com/Y1m4r/Catch-The-PacMan-Game/internal/game"
+
+// Music is a frontend's music, a playlist played while a level is being
+// played and a theme on the menus, crossfading from one to the other.
+// Frontends without music leave Controller.Music nil.
+type Music interface {
+	PlayMusic()     // Keeps the playlist going, called every tick of play
+	PlayMenuMusic() // Keeps the menu music going, called every tick of the menus
+	PauseMusic()    // Fades the music out, as the game over jingle plays
+	NextTrack()
+	PreviousTrack()
+	CurrentTrack() string // Title of the track playing or paused, "" for none
+}
+
+// updateMusic plays the playlist while a level is played, the menu music
+// on the start screen, and fades out past the end of a game. The track keys
+// skip around the playlist, and every new track's title shows as a toast.
+func (c *Controller) updateMusic(state game.GameState, in Input) {
+	if c.Music == nil {
+		return
+	}
+	switch state {
+	case game.StatePlaying:
+	case game.StateStarting:
+		c.Music.PlayMenuMusic()
+		return
+	default:
+		c.Music.PauseMusic()
+		return
+	}
+	switch {
+	case in.Pressed(KeyNextTrack):
+		c.Music.NextTrack()
+	case in.Pressed(KeyPrevTrack):
+		c.Music.PreviousTrack()
+	}
+	c.Music.PlayMusic()
+	if title := c.Music.CurrentTrack(); title != c.musicTrack {
+		c.musicTrack = title
+		if title != "" {
+			c.showToast("Now playing: " + title)
+		}
+	}
+}
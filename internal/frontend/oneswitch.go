@@ -0,0 +1,33 @@
+package frontend
+
+import "time"
+
+// highlightMargin is the gap between a highlighted Pacman and its ring.
+const highlightMargin = 6.0
+
+// EnableOneSwitch makes the game playable with a single switch: a highlight
+// cycles through the running Pacmans every scan interval, and SPACE, ENTER
+// or a click anywhere catches the highlighted one. The same press confirms
+// on every other screen.
+func (c *Controller) EnableOneSwitch(scan time.Duration) {
+	c.oneSwitch = true
+	c.GameLogic.SetSwitchScan(scan)
+}
+
+// switched reports whether the player confirmed: ENTER or a click, plus the
+// switch key in one-switch mode.
+func (c *Controller) switched(in Input) bool {
+	return in.Pressed(KeyConfirm) || in.Clicked || (c.oneSwitch && in.Pressed(KeySwitch))
+}
+
+// drawHighlight rings the Pacman the one-switch highlight is on.
+func (c *Controller) drawHighlight(r Renderer) {
+	if !c.oneSwitch {
+		return
+	}
+	if p, ok := c.GameLogic.Highlighted(); ok {
+		r.DrawRing(p.PosX, p.PosY, p.Radius+highlightMargin, ColorYellow)
+		r.DrawRing(p.PosX, p.PosY, p.Radius+2*highlightMargin, ColorWhite)
+	}
+	r.DrawText("SPACE/ENTER/Click = Catch highlighted", 10, ScreenHeight-40, ColorGray, false)
+}
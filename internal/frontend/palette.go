@@ -0,0 +1,159 @@
+package frontend
+
+import (
+	"fmt"
+	"image/color"
+	"time"
+)
+
+// Palette settings, see SetPalette.
+const (
+	PaletteClock = "clock" // Follows the time of day
+	PaletteCycle = "cycle" // Goes through a whole day every paletteCycleLength
+)
+
+// paletteCycleLength is how long a day lasts with PaletteCycle.
+const paletteCycleLength = 10 * time.Minute
+
+// hudAccentY is where the level theme's accent underlines the HUD.
+const hudAccentY = 36
+
+// Palette is the colors of the background and the HUD. Its Night palette is
+// the one the screens are drawn with, the others take its place on the fly.
+type Palette struct {
+	Background color.RGBA // Replaces ColorDarkBlue
+	Text       color.RGBA // Replaces ColorWhite
+	Dim        color.RGBA // Replaces ColorGray
+}
+
+// The palettes of the times of day. Text stays light, since Ebiten's debug
+// font is always drawn white.
+var palettes = map[string]Palette{
+	"night": {Background: ColorDarkBlue, Text: ColorWhite, Dim: color.RGBA{150, 150, 150, 255}},
+	"dawn":  {Background: color.RGBA{45, 20, 50, 255}, Text: color.RGBA{255, 235, 240, 255}, Dim: color.RGBA{180, 150, 170, 255}},
+	"day":   {Background: color.RGBA{25, 50, 95, 255}, Text: ColorWhite, Dim: color.RGBA{170, 190, 215, 255}},
+	"dusk":  {Background: color.RGBA{60, 25, 20, 255}, Text: color.RGBA{255, 240, 225, 255}, Dim: color.RGBA{190, 160, 140, 255}},
+}
+
+// PaletteNames lists every palette setting: the fixed palettes, then the cycling ones.
+var PaletteNames = []string{"night", "dawn", "day", "dusk", PaletteClock, PaletteCycle}
+
+// dayPalettes are the palettes around the day, each peaking at its hour and
+// blending into the next one.
+var dayPalettes = []struct {
+	hour    float64
+	palette string
+}{
+	{0, "night"}, {6, "dawn"}, {12, "day"}, {18, "dusk"}, {24, "night"},
+}
+
+// SetPalette picks the palette, one of PaletteNames: a fixed one, or one
+// that shifts with the time of day. The screens are drawn in the night
+// palette until then.
+func (c *Controller) SetPalette(name string) error {
+	if _, fixed := palettes[name]; !fixed && name != PaletteClock && name != PaletteCycle {
+		return fmt.Errorf("unknown palette %q, must be one of %v", name, PaletteNames)
+	}
+	c.palette = name
+	c.paletteStart = time.Now()
+	return nil
+}
+
+// currentPalette returns the palette to draw with now.
+func (c *Controller) currentPalette() Palette {
+	switch c.palette {
+	case "":
+		return palettes["night"]
+	case PaletteClock:
+		now := time.Now()
+		return PaletteAt(float64(now.Hour()) + float64(now.Minute())/60 + float64(now.Second())/3600)
+	case PaletteCycle:
+		elapsed := time.Since(c.paletteStart) % paletteCycleLength
+		return PaletteAt(24 * float64(elapsed) / float64(paletteCycleLength))
+	}
+	return palettes[c.palette]
+}
+
+// themedRenderer returns r drawing with the current palette, and the loaded
+// level's background if its theme has one.
+func (c *Controller) themedRenderer(r Renderer) Renderer {
+	theme := c.GameLogic.LevelTheme()
+	if c.palette == "" && theme.Background.A == 0 {
+		return r
+	}
+	palette := c.currentPalette()
+	if theme.Background.A != 0 {
+		palette.Background = theme.Background
+	}
+	return paletteRenderer{r, palette}
+}
+
+// hudAccent returns the accent color of the HUD, the level theme's if it has one.
+func (c *Controller) hudAccent() color.Color {
+	if accent := c.GameLogic.LevelTheme().Accent; accent.A != 0 {
+		return accent
+	}
+	return ColorYellow
+}
+
+// drawHUDAccent underlines the HUD in the level theme's accent color, if it
+// has one. Besides the "Click PacMan!" prompt, it's the only place to show it,
+// since Ebiten's debug font is always drawn white.
+func (c *Controller) drawHUDAccent(r Renderer) {
+	if accent := c.GameLogic.LevelTheme().Accent; accent.A != 0 {
+		r.DrawRect(0, hudAccentY, ScreenWidth, 2, accent)
+	}
+}
+
+// PaletteAt returns the palette at the given hour of the day (0 to 24),
+// blended between the two times of day it's in between.
+func PaletteAt(hour float64) Palette {
+	for i := 1; i < len(dayPalettes); i++ {
+		from, to := dayPalettes[i-1], dayPalettes[i]
+		if hour <= to.hour {
+			t := (hour - from.hour) / (to.hour - from.hour)
+			a, b := palettes[from.palette], palettes[to.palette]
+			return Palette{blend(a.Background, b.Background, t), blend(a.Text, b.Text, t), blend(a.Dim, b.Dim, t)}
+		}
+	}
+	return palettes["night"]
+}
+
+// paletteRenderer draws through a Renderer with the palette's colors in
+// place of the night palette's.
+type paletteRenderer struct {
+	Renderer
+	palette Palette
+}
+
+func (r paletteRenderer) recolor(clr color.Color) color.Color {
+	switch clr {
+	case ColorDarkBlue:
+		return r.palette.Background
+	case ColorWhite:
+		return r.palette.Text
+	case ColorGray:
+		return r.palette.Dim
+	}
+	return clr
+}
+
+func (r paletteRenderer) Fill(clr color.Color) {
+	r.Renderer.Fill(r.recolor(clr))
+}
+
+func (r paletteRenderer) DrawText(str string, x, y float64, clr color.Color, center bool) {
+	r.Renderer.DrawText(str, x, y, r.recolor(clr), center)
+}
+
+func (r paletteRenderer) DrawRect(x, y, width, height float64, clr color.Color) {
+	r.Renderer.DrawRect(x, y, width, height, r.recolor(clr))
+}
+
+func (r paletteRenderer) DrawRing(x, y, radius float64, clr color.Color) {
+	r.Renderer.DrawRing(x, y, radius, r.recolor(clr))
+}
+
+func (r paletteRenderer) DrawLine(x1, y1, x2, y2 float64, clr color.Color) {
+	r.Renderer.DrawLine(x1, y1, x2, y2, r.recolor(clr))
+}
@@ -0,0 +1,30 @@
+package frontend
+
+import (
+	"image/color"
+
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/game"
+)
+
+// colorPauseDim darkens the frozen level behind the pause overlay.
+var colorPauseDim = color.RGBA{A: 150}
+
+// updatePaused handles input while the level is paused: P, Escape, Enter
+// or a click resumes it, and it can still be saved.
+func (c *Controller) updatePaused(in Input) {
+	if in.Pressed(KeyPause) || c.switched(in) {
+		c.apply(game.Action{Kind: game.ActionPause})
+		return
+	}
+	if in.Pressed(KeySave) {
+		c.apply(game.Action{Kind: game.ActionSave})
+	}
+}
+
+// drawPaused renders the pause overlay over the frozen level.
+func (c *Controller) drawPaused(r Renderer) {
+	r.DrawRect(0, 0, ScreenWidth, ScreenHeight, colorPauseDim)
+	r.DrawRect(ScreenWidth/2-140, ScreenHeight/2-40, 280, 80, menuStyle.Highlight)
+	r.DrawText("PAUSED", ScreenWidth/2, ScreenHeight/2-25, ColorYellow, true)
+	r.DrawText("P or ESC=Resume S=Save Q=Quit", ScreenWidth/2, ScreenHeight/2+5, ColorWhite, true)
+}
@@ -0,0 +1,132 @@
+package frontend
+
+import (
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/game"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/model"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/paths"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/persistence"
+)
+
+// periods are the windows of time the Hall of Fame keeps boards for besides
+// all time, its tabs after the first. A window's board starts empty when it
+// rolls over, and the boards of past windows stay behind as archive files.
+var periods = []struct {
+	name string
+	key  func(t time.Time) string // Names the window t falls in
+}{
+	{"This week", func(t time.Time) string {
+		year, week := t.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", year, week)
+	}},
+	{"This month", func(t time.Time) string { return t.Format("2006-01") }},
+}
+
+// periodBoards are a level's boards of the current windows of periods.
+type periodBoards struct {
+	level  int
+	keys   []string
+	scores [][]model.Score
+}
+
+// periodKeys names the windows of periods that t falls in.
+func periodKeys(t time.Time) []string {
+	keys := make([]string, len(periods))
+	for i, p := range periods {
+		keys[i] = p.key(t)
+	}
+	return keys
+}
+
+// periodScores returns the level's boards of the current windows, read from
+// their files unless they already were, or a window rolled over since.
+func (c *Controller) periodScores(level int) *periodBoards {
+	keys := periodKeys(time.Now())
+	if b := c.periodBoards; b != nil && b.level == level && slices.Equal(b.keys, keys) {
+		return b
+	}
+	b := &periodBoards{level: level, keys: keys}
+	for _, key := range keys {
+		scores, err := persistence.LoadHighScores(paths.PeriodHighScorePath(c.GameLogic.DataDir(), level, key))
+		if err != nil {
+			log.Printf("Could not read the %s board of level %d, starting it afresh: %v", key, level, err)
+		}
+		b.scores = append(b.scores, scores)
+	}
+	c.periodBoards = b
+	return b
+}
+
+// offerPeriodBoards asks for the player's name after a run that missed the
+// Hall of Fame but makes one of the level's current period boards, which
+// follow the same rules.
+func (c *Controller) offerPeriodBoards(state game.GameState, level int) {
+	if state != game.StateGameOver || !c.levelBoards() {
+		return
+	}
+	score, rules := c.GameLogic.Score(), c.GameLogic.HallOfFameRules()
+	for _, scores := range c.periodScores(level).scores {
+		if _, ok := rules.Add(scores, score, time.Now()); ok {
+			if c.GameLogic.EnterName() {
+				log.Println("Run made a period board, asking for a name.")
+			}
+			return
+		}
+	}
+}
+
+// addPeriodScore adds a named score to the level's current period boards it
+// makes, and saves them.
+func (c *Controller) addPeriodScore(level int, score model.Score) {
+	if !c.levelBoards() || c.GameLogic.Practice() {
+		return
+	}
+	b, rules := c.periodScores(level), c.GameLogic.HallOfFameRules()
+	for i, key := range b.keys {
+		scores, added := rules.Add(b.scores[i], score, time.Now())
+		if !added {
+			continue
+		}
+		b.scores[i] = scores
+		if err := persistence.SaveHighScores(scores, paths.PeriodHighScorePath(c.GameLogic.DataDir(), level, key)); err != nil {
+			log.Printf("Could not save the %s board of level %d: %v", key, level, err)
+		}
+	}
+}
+
+// hallOfFameScores returns the scores of the Hall of Fame tab shown, and
+// the tab's name.
+func (c *Controller) hallOfFameScores(level int) ([]model.Score, string) {
+	if c.hallTab == 0 || !c.levelBoards() {
+		_, scores, _ := c.GameLogic.GetHighScoreData()
+		return scores, "All time"
+	}
+	return c.periodScores(level).scores[c.hallTab-1], periods[c.hallTab-1].name
+}
+
+// hallOfFameRows is how many Hall of Fame entries fit on screen at once.
+// Larger Hall of Fames, see model.HallOfFameRules, scroll.
+const hallOfFameRows = model.MaxHighScores
+
+// navigateHallOfFame goes through the Hall of Fame's tabs with LEFT and
+// RIGHT, and scrolls the one shown with UP and DOWN.
+func (c *Controller) navigateHallOfFame(in Input, level int) {
+	tabs := len(periods) + 1
+	switch {
+	case in.Pressed(KeyRight):
+		c.hallTab = (c.hallTab + 1) % tabs
+		c.hallTop = 0
+	case in.Pressed(KeyLeft):
+		c.hallTab = (c.hallTab + tabs - 1) % tabs
+		c.hallTop = 0
+	case in.Pressed(KeyUp):
+		c.hallTop = max(c.hallTop-1, 0)
+	case in.Pressed(KeyDown):
+		scores, _ := c.hallOfFameScores(level)
+		c.hallTop = min(c.hallTop+1, max(len(scores)-hallOfFameRows, 0))
+	}
+}
@@ -0,0 +1,43 @@
+package frontend
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/game"
+)
+
+// powerUpStyles are the colors and letters power-ups are drawn with, by kind.
+var powerUpStyles = [...]struct {
+	clr    color.Color
+	letter string
+}{
+	game.PowerUpSlowMotion: {color.RGBA{R: 120, G: 200, B: 255, A: 255}, "S"},
+	game.PowerUpFreeze:     {color.RGBA{R: 200, G: 240, B: 255, A: 255}, "F"},
+	game.PowerUpBigHitbox:  {color.RGBA{R: 120, G: 255, B: 120, A: 255}, "H"},
+}
+
+// powerUpBlink is how many seconds before a power-up goes away it starts
+// blinking.
+const powerUpBlink = 1.5
+
+// drawPowerUps shows the power-ups waiting to be clicked, blinking before
+// they go away.
+func (c *Controller) drawPowerUps(r Renderer) {
+	for _, p := range c.GameLogic.PowerUps() {
+		if p.Left < powerUpBlink && !c.ReducedMotion && int(p.Left*6)%2 == 1 {
+			continue
+		}
+		style := powerUpStyles[p.Kind]
+		r.DrawRing(p.PosX, p.PosY, p.Radius, style.clr)
+		r.DrawText(style.letter, p.PosX, p.PosY-8, style.clr, true)
+	}
+}
+
+// drawPowerUpEffects lists the effects of the power-ups collected that are
+// still running, with the time they have left.
+func (c *Controller) drawPowerUpEffects(r Renderer) {
+	for i, e := range c.GameLogic.PowerUpEffects() {
+		r.DrawText(fmt.Sprintf("%s: %s", e.Kind, formatSeconds(e.Left)), 10, 100+float64(i)*20, powerUpStyles[e.Kind].clr, false)
+	}
+}
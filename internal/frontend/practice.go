@@ -0,0 +1,43 @@
+package frontend
+
+import (
+	"image/color"
+
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/game"
+)
+
+// Velocity vectors show where a Pacman will be this many seconds from now.
+const velocityLookahead = 0.25
+
+// Practice overlay colors.
+var (
+	colorHitbox   = color.RGBA{R: 255, G: 80, B: 80, A: 255}
+	colorVelocity = color.RGBA{R: 80, G: 200, B: 255, A: 255}
+)
+
+// rewind steps the run back one tick while R is held in practice mode.
+// Returns false if it didn't, so the game should move on as usual.
+func (c *Controller) rewind(in Input) bool {
+	return in.Holding(KeyRewind) && c.GameLogic.Apply(game.Action{Kind: game.ActionRewind})
+}
+
+// drawPractice draws every running Pacman's hitbox and velocity vector in
+// practice mode, plus the practice controls.
+func (c *Controller) drawPractice(r Renderer) {
+	if !c.GameLogic.Practice() {
+		return
+	}
+	for _, p := range c.GameLogic.GetPacmanData() {
+		if p.IsStopped {
+			continue
+		}
+		r.DrawRing(p.PosX, p.PosY, p.Radius, colorHitbox)
+		r.DrawLine(p.PosX, p.PosY, p.PosX+p.VelX*velocityLookahead, p.PosY+p.VelY*velocityLookahead, colorVelocity)
+	}
+
+	status := "PRACTICE  P=Pause  Hold R=Rewind"
+	if c.GameLogic.Paused() {
+		status = "PAUSED  P=Resume .=Step  Hold R=Rewind"
+	}
+	r.DrawText(status, ScreenWidth/2, 40, ColorYellow, true)
+}
@@ -0,0 +1,159 @@
+package frontend
+
+import (
+	"fmt"
+	"image/color"
+	"maps"
+	"math"
+	"slices"
+
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/model"
+)
+
+// progressRuns is how many of a level's last cleared runs the progress
+// screen's line chart shows.
+const progressRuns = 30
+
+// colorBar is the bars and lines of the progress screen's charts.
+var colorBar = color.RGBA{R: 80, G: 160, B: 255, A: 255}
+
+// Chart areas of the progress screen, as left, top, width, height.
+var (
+	progressBars  = [4]float64{60, 100, 520, 95}
+	progressLines = [4]float64{60, 270, 520, 140}
+)
+
+// progressPage is the "Your Progress" screen: the profile's run history
+// summed up, with the best run of every level as bars and the last runs of
+// one of them as a line.
+type progressPage struct {
+	overall   model.Summary
+	summaries map[int]model.Summary
+	levels    []int // Levels played, in order
+	selected  int   // Index in levels of the one whose runs are shown
+}
+
+// openProgress shows the progress screen, on the level played last.
+func (c *Controller) openProgress() {
+	runs := c.Records.Runs
+	page := &progressPage{overall: model.SummarizeAll(runs), summaries: model.SummarizeLevels(runs)}
+	page.levels = slices.Sorted(maps.Keys(page.summaries))
+	if len(runs) > 0 {
+		page.selected = slices.Index(page.levels, runs[len(runs)-1].Level)
+	}
+	c.progress = page
+}
+
+// updateProgress handles input while the progress screen is open.
+func (c *Controller) updateProgress(in Input) {
+	page := c.progress
+	n := len(page.levels)
+	switch {
+	case in.Pressed(KeyBack) || c.switched(in):
+		c.progress = nil
+	case in.Pressed(KeyLeft) && n > 0:
+		page.selected = (page.selected + n - 1) % n
+	case in.Pressed(KeyRight) && n > 0:
+		page.selected = (page.selected + 1) % n
+	}
+}
+
+// drawProgress renders the progress screen.
+func (c *Controller) drawProgress(r Renderer) {
+	page := c.progress
+	r.DrawText("Your Progress - "+c.Records.Profile, ScreenWidth/2, 20, ColorYellow, true)
+	r.DrawText("LEFT/RIGHT=Level ESC=Back", 10, ScreenHeight-20, ColorGray, false)
+	if page.overall.Games == 0 {
+		r.DrawText("No runs played yet!", ScreenWidth/2, ScreenHeight/2, ColorGray, true)
+		return
+	}
+	r.DrawText(fmt.Sprintf("%d games, %d cleared, %s", page.overall.Games, page.overall.Cleared, formatTrend(page.overall.Trend)), ScreenWidth/2, 45, ColorWhite, true)
+
+	c.drawBestBars(r)
+
+	level := page.levels[page.selected]
+	s := page.summaries[level]
+	y := progressLines[1] - 45
+	if s.Cleared == 0 {
+		r.DrawText(fmt.Sprintf("Level %d: %d games, none cleared", level, s.Games), ScreenWidth/2, y, ColorWhite, true)
+		return
+	}
+	r.DrawText(fmt.Sprintf("Level %d: best %d, median %g, average %.1f", level, s.Best, s.Median, s.Average), ScreenWidth/2, y, ColorWhite, true)
+	r.DrawText(fmt.Sprintf("%d games, %s", s.Games, formatTrend(s.Trend)), ScreenWidth/2, y+20, ColorGray, true)
+	c.drawRunLine(r, level)
+}
+
+// drawBestBars charts the best run of every level played as bars, the
+// selected level's highlighted. Levels never cleared get no bar.
+func (c *Controller) drawBestBars(r Renderer) {
+	page := c.progress
+	x0, y0, w, h := progressBars[0], progressBars[1], progressBars[2], progressBars[3]
+	highest := 1
+	for _, s := range page.summaries {
+		highest = max(highest, s.Best)
+	}
+	slot := w / float64(len(page.levels))
+	barWidth := min(slot*0.6, 40)
+	r.DrawLine(x0, y0+h, x0+w, y0+h, ColorGray)
+	for i, level := range page.levels {
+		s := page.summaries[level]
+		x := x0 + slot*float64(i) + (slot-barWidth)/2
+		clr := colorBar
+		if i == page.selected {
+			clr = ColorYellow
+		}
+		r.DrawText(fmt.Sprint(level), x+barWidth/2, y0+h+5, clr, true)
+		if s.Cleared == 0 {
+			continue
+		}
+		barHeight := max(h*float64(s.Best)/float64(highest), 2)
+		r.DrawRect(x, y0+h-barHeight, barWidth, barHeight, clr)
+		r.DrawText(fmt.Sprint(s.Best), x+barWidth/2, y0+h-barHeight-18, ColorWhite, true)
+	}
+	r.DrawText("Best bounces by level", x0, y0-35, ColorGray, false)
+}
+
+// drawRunLine charts the bounces of a level's last cleared runs as a line,
+// oldest on the left.
+func (c *Controller) drawRunLine(r Renderer, level int) {
+	var bounces []int
+	for _, run := range c.Records.Runs {
+		if run.Level == level && !run.Failed {
+			bounces = append(bounces, run.Bounces)
+		}
+	}
+	bounces = bounces[max(len(bounces)-progressRuns, 0):]
+
+	x0, y0, w, h := progressLines[0], progressLines[1], progressLines[2], progressLines[3]
+	r.DrawLine(x0, y0, x0, y0+h, ColorGray)
+	r.DrawLine(x0, y0+h, x0+w, y0+h, ColorGray)
+	highest := max(slices.Max(bounces), 1)
+	r.DrawText(fmt.Sprint(highest), x0-8, y0-6, ColorGray, true)
+	r.DrawText("0", x0-8, y0+h-6, ColorGray, true)
+
+	step := w / float64(max(len(bounces)-1, 1))
+	point := func(i int) (float64, float64) {
+		return x0 + step*float64(i), y0 + h - h*float64(bounces[i])/float64(highest)
+	}
+	for i := range bounces {
+		x, y := point(i)
+		if i > 0 {
+			px, py := point(i - 1)
+			r.DrawLine(px, py, x, y, colorBar)
+		}
+		r.DrawRect(x-2, y-2, 4, 4, ColorWhite)
+	}
+	r.DrawText(fmt.Sprintf("Last cleared runs (%d)", len(bounces)), x0, y0+h+5, ColorGray, false)
+}
+
+// formatTrend describes a summary's trend, in bounces per run.
+func formatTrend(trend float64) string {
+	switch {
+	case math.Abs(trend) < 0.05:
+		return "holding steady"
+	case trend < 0:
+		return fmt.Sprintf("improving by %.1f bounces a run", -trend)
+	default:
+		return fmt.Sprintf("slipping by %.1f bounces a run", trend)
+	}
+}
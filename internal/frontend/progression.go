@@ -0,0 +1,62 @@
+package frontend
+
+import (
+	"fmt"
+	"os"
+)
+
+// standardLevelPath returns the file of a standard level.
+func standardLevelPath(level int) string {
+	return fmt.Sprintf("assets/levels/level_%d.txt", level)
+}
+
+// nextLevel returns the standard level after the one that just ended, if
+// the run succeeded and there is one. Custom and generated levels have no
+// next level.
+func (c *Controller) nextLevel(level int) (int, bool) {
+	if c.replay != nil || level < 0 || c.GameLogic.Failed() {
+		return 0, false
+	}
+	if _, err := os.Stat(standardLevelPath(level + 1)); err != nil {
+		return 0, false
+	}
+	return level + 1, true
+}
+
+// updateGameOver handles input on the game over screen: ENTER (or the
+// switch) goes on to the next level when there is one, ESC or a click
+// retries. Without a next level, any of them retries.
+func (c *Controller) updateGameOver(in Input, level int) {
+	next, ok := c.nextLevel(level)
+	if !ok {
+		if c.switched(in) || in.Pressed(KeyBack) {
+			c.restart(level)
+		}
+		return
+	}
+	switch {
+	case in.Pressed(KeyConfirm) || (c.oneSwitch && in.Pressed(KeySwitch)):
+		c.LoadLevel(next)
+	case in.Clicked || in.Pressed(KeyBack):
+		c.restart(level)
+	}
+}
+
+// drawGameOverChoices tells the player how to go on from the game over screen.
+func (c *Controller) drawGameOverChoices(r Renderer, level int) {
+	if next, ok := c.nextLevel(level); ok {
+		r.DrawText(fmt.Sprintf("ENTER=Next Level (%d)  ESC or Click=Retry", next), ScreenWidth/2, ScreenHeight/2+10, ColorWhite, true)
+		return
+	}
+	r.DrawText("Press ENTER or Click to Restart", ScreenWidth/2, ScreenHeight/2+10, ColorWhite, true)
+}
+
+// continueAfterHallOfFame goes on to the next level after the one the
+// Hall of Fame is shown for, or back to level 0 after the last one.
+func (c *Controller) continueAfterHallOfFame(level int) {
+	if next, ok := c.nextLevel(level); ok {
+		c.LoadLevel(next)
+		return
+	}
+	c.LoadLevel(0)
+}
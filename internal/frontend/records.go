@@ -0,0 +1,99 @@
+package frontend
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/game"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/model"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/persistence"
+)
+
+// newPersonalBest is the personal best the run that just ended set.
+type newPersonalBest struct {
+	persistence.PersonalBest
+	previous    persistence.PersonalBest
+	hadPrevious bool
+}
+
+// startRun starts timing a run of a standard level, whose result counts
+// towards the ghost and the profile's personal bests.
+func (c *Controller) startRun(level int) {
+	if c.GameLogic.Mode() != game.ModeClassic {
+		c.stopRun() // Arcade and time attack runs only count for their own Hall of Fame
+		return
+	}
+	c.runStart = time.Now()
+	c.standardRun = true
+	c.newPB, c.results, c.runClicks = nil, nil, nil
+	c.startGhost(level)
+	c.startSession(level)
+}
+
+// stopRun marks the run being played as one that isn't comparable with the
+// level's others (loaded saves, custom and generated levels).
+func (c *Controller) stopRun() {
+	c.standardRun = false
+	c.newPB, c.results, c.runClicks, c.session = nil, nil, nil, nil
+	c.leaveCampaign()
+	c.stopGhost()
+}
+
+// finishRun keeps the run that just ended as the level's ghost and the
+// profile's personal best if it beat them, and adds it to the profile's run
+// history. Practice runs never count, and failed runs only in the history.
+func (c *Controller) finishRun(level, bounces int) {
+	standard := c.standardRun
+	c.standardRun = false
+	failed := c.GameLogic.Failed()
+	if failed {
+		c.ghostRecorder = nil
+	} else {
+		c.finishGhost(bounces)
+	}
+	if c.Records == nil || !standard || c.GameLogic.Practice() {
+		return
+	}
+	duration := time.Since(c.runStart)
+	if err := c.Records.AddRun(model.Run{Level: level, Bounces: bounces, Failed: failed, Duration: duration, Date: time.Now()}); err != nil {
+		log.Printf("Could not save run history: %v", err)
+	}
+	if failed {
+		return
+	}
+	run := persistence.PersonalBest{Bounces: bounces, Duration: duration, Date: time.Now()}
+	improved, previous, hadPrevious, err := c.Records.Record(level, run)
+	if err != nil {
+		log.Printf("Could not save personal best: %v", err)
+	}
+	if improved {
+		c.newPB = &newPersonalBest{c.Records.Levels[level], previous, hadPrevious}
+		log.Printf("New personal best on level %d: %d bounces in %s.", level, bounces, formatRunDuration(run.Duration))
+	}
+}
+
+// drawNewPersonalBest shows the personal best the run just set, if any.
+func (c *Controller) drawNewPersonalBest(r Renderer, y float64) {
+	if c.newPB == nil {
+		return
+	}
+	text := "NEW PB!"
+	if c.newPB.hadPrevious {
+		text += " " + formatPBDelta(c.newPB.PersonalBest, c.newPB.previous)
+	}
+	r.DrawText(text, ScreenWidth/2, y, ColorYellow, true)
+}
+
+// formatPBDelta describes how much pb beat previous by.
+func formatPBDelta(pb, previous persistence.PersonalBest) string {
+	if pb.Bounces < previous.Bounces {
+		return fmt.Sprintf("(-%d bounces)", previous.Bounces-pb.Bounces)
+	}
+	return fmt.Sprintf("(-%s)", formatRunDuration(previous.Duration-pb.Duration))
+}
+
+// formatRunDuration formats a run's duration to a tenth of a second.
+func formatRunDuration(d time.Duration) string {
+	return formatSeconds(d.Seconds())
+}
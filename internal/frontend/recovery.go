@@ -0,0 +1,65 @@
+package frontend
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/game"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/persistence"
+)
+
+// maxShownProblems is how many problems of a damaged save fit on screen.
+const maxShownProblems = 8
+
+// recoveryPrompt asks whether to load what's left of a damaged save.
+type recoveryPrompt struct {
+	damage *persistence.DamagedSaveError
+}
+
+// updateRecoveryPrompt handles input while the prompt is open.
+func (c *Controller) updateRecoveryPrompt(in Input) {
+	if in.Pressed(KeyBack) {
+		log.Printf("Recovery of %s cancelled", c.recovery.damage.Path)
+		c.recovery = nil
+		return
+	}
+	if !in.Pressed(KeyConfirm) {
+		return
+	}
+
+	path := c.recovery.damage.Path
+	c.recovery = nil
+	recoverFunc := func(path string) (*game.Game, error) {
+		recovered, _, err := persistence.RecoverGame(path)
+		return recovered, err
+	}
+	err := c.requestSavedGame(path, recoverFunc, func(err error) {
+		if err != nil {
+			log.Printf("Recovery failed: %v", err)
+			return
+		}
+		log.Println("Recovered game loaded. Save again (S) to replace the damaged file.")
+		c.saveLoaded(path)
+	})
+	if err != nil {
+		log.Printf("Recovery failed: %v", err)
+	}
+}
+
+// drawRecoveryPrompt renders the prompt.
+func (c *Controller) drawRecoveryPrompt(r Renderer) {
+	damage := c.recovery.damage
+	r.DrawText("Save file is damaged", ScreenWidth/2, 60, ColorRed, true)
+	r.DrawText(damage.Path, ScreenWidth/2, 90, ColorGray, true)
+
+	for i, problem := range damage.Problems {
+		if i == maxShownProblems {
+			r.DrawText(fmt.Sprintf("... and %d more", len(damage.Problems)-i), 40, 130+float64(i)*25, ColorGray, false)
+			break
+		}
+		r.DrawText(problem, 40, 130+float64(i)*25, ColorWhite, false)
+	}
+
+	r.DrawText("Recover what's left of it?", ScreenWidth/2, ScreenHeight-80, ColorYellow, true)
+	r.DrawText("ENTER=Recover ESC=Cancel", 10, ScreenHeight-20, ColorGray, false)
+}
@@ -0,0 +1,161 @@
+package frontend
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/game"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/model"
+)
+
+// maxShownPacmans is how many rows of the per-Pacman breakdown fit on screen.
+const maxShownPacmans = 8
+
+// updateResults handles input while the results of the last run are shown.
+// Continue goes on to name entry after a high score, or restarts the level.
+// It goes back to the game over screen instead when there's a next level
+// to choose, and for practice runs, which can still be rewound there.
+func (c *Controller) updateResults(in Input) {
+	if !c.switched(in) {
+		return
+	}
+	c.results = nil
+	state, _, level := c.GameLogic.GetGameState()
+	if c.campaign != nil && state == game.StateGameOver {
+		c.continueCampaign()
+		return
+	}
+	if _, hasNext := c.nextLevel(level); state == game.StateGameOver && !c.GameLogic.Practice() && !hasNext {
+		c.restart(level)
+	}
+}
+
+// drawResults renders the results screen.
+func (c *Controller) drawResults(r Renderer) {
+	s := c.results
+	if c.GameLogic.Failed() {
+		r.DrawText("Run failed - out of bounces", ScreenWidth/2, 50, ColorRed, true)
+	} else {
+		r.DrawText("Results", ScreenWidth/2, 50, ColorYellow, true)
+	}
+	c.drawNewPersonalBest(r, 80)
+
+	lines := []string{
+		fmt.Sprintf("Bounces: %d", s.Bounces),
+		fmt.Sprintf("Catches: %d", s.Catches),
+		fmt.Sprintf("Misses: %d", s.Misses),
+		fmt.Sprintf("Accuracy: %.0f%%", 100*s.Accuracy()),
+		fmt.Sprintf("Time: %s", formatSeconds(s.Elapsed)),
+		fmt.Sprintf("Best combo: %d", s.BestCombo),
+	}
+	if s.BestChain > 1 {
+		lines = append(lines, fmt.Sprintf("Best chain: x%d (%d bounces off)", s.BestChain, c.GameLogic.Score().ChainBonus))
+	}
+	if score := c.GameLogic.Score(); c.GameLogic.AccuracyScoring() {
+		lines = append(lines, fmt.Sprintf("Score: %d (+%d for misses)", score.Score, score.Score-score.Bounces()))
+	} else if c.GameLogic.Arcade() {
+		lines = append(lines, fmt.Sprintf("Points: %d (catches - bounces)", game.ArcadePoints(score)))
+	} else if c.GameLogic.Mode() == game.ModeTimeAttack && !c.GameLogic.Failed() {
+		lines = append(lines, fmt.Sprintf("Points: %d (%d a second left - bounces)", game.TimeAttackPoints(score), game.TimeAttackBonus))
+	}
+	for i, line := range lines {
+		r.DrawText(line, ScreenWidth/4, 120+float64(i)*30, ColorWhite, false)
+	}
+
+	r.DrawText("Pac-Man  Bounces  Caught", ScreenWidth/2+40, 120, ColorGray, false)
+	for i, p := range s.Pacmans {
+		y := 150 + float64(i)*25
+		if i == maxShownPacmans {
+			r.DrawText(fmt.Sprintf("... and %d more", len(s.Pacmans)-i), ScreenWidth/2+40, y, ColorGray, false)
+			break
+		}
+		caught := "-"
+		if p.Caught && p.CaughtAt > 0 {
+			caught = formatSeconds(p.CaughtAt)
+		} else if p.Caught {
+			caught = "yes"
+		}
+		r.DrawText(fmt.Sprintf("#%-7d %-8d %s", p.ID, p.Bounces, caught), ScreenWidth/2+40, y, ColorWhite, false)
+	}
+
+	if state, _, _ := c.GameLogic.GetGameState(); state == game.StateEnteringHighScore {
+		r.DrawText("Press ENTER or Click to enter your name", ScreenWidth/2, ScreenHeight-50, ColorWhite, true)
+	} else {
+		r.DrawText("Press ENTER or Click to Continue", ScreenWidth/2, ScreenHeight-50, ColorWhite, true)
+	}
+}
+
+// drawMisses shows the run's score with its miss penalty, with accuracy scoring.
+func (c *Controller) drawMisses(r Renderer, x, y float64) {
+	if !c.GameLogic.AccuracyScoring() {
+		return
+	}
+	score := c.GameLogic.Score()
+	r.DrawText(fmt.Sprintf("Misses: %d", score.Misses), x, y, ColorWhite, false)
+	r.DrawText(fmt.Sprintf("Score: %d", score.Score), x, y+20, ColorYellow, false)
+}
+
+// drawChain shows the multiplier of the chain under way, if it's past a
+// single catch.
+func (c *Controller) drawChain(r Renderer) {
+	if multiplier, left := c.GameLogic.Chain(); multiplier > 1 {
+		r.DrawText(fmt.Sprintf("Chain x%d (%s)", multiplier, formatSeconds(left)), ScreenWidth/2, 60, ColorYellow, true)
+	}
+}
+
+// drawLives shows the lives the run has left, if the level has any.
+func (c *Controller) drawLives(r Renderer, x, y float64) {
+	left, limited := c.GameLogic.LivesLeft()
+	if !limited {
+		return
+	}
+	clr := ColorWhite
+	if left <= 1 {
+		clr = ColorRed
+	}
+	r.DrawText(fmt.Sprintf("Lives: %d", left), x, y, clr, false)
+}
+
+// drawArcade shows the time left and the points of an arcade run.
+func (c *Controller) drawArcade(r Renderer) {
+	if !c.GameLogic.Arcade() {
+		return
+	}
+	r.DrawText(fmt.Sprintf("Time left: %.0fs", math.Ceil(c.GameLogic.ArcadeTimeLeft())), ScreenWidth/2, 40, ColorWhite, true)
+	r.DrawText(fmt.Sprintf("Points: %d", game.ArcadePoints(c.GameLogic.Score())), ScreenWidth-150, 60, ColorYellow, false)
+}
+
+// timeAttackWarning is how many seconds before a time attack run runs out
+// its clock turns red.
+const timeAttackWarning = 10
+
+// drawTimeAttack shows the countdown of a time attack run.
+func (c *Controller) drawTimeAttack(r Renderer) {
+	if c.GameLogic.Mode() != game.ModeTimeAttack {
+		return
+	}
+	left := c.GameLogic.TimeAttackTimeLeft()
+	clr := ColorWhite
+	if left < timeAttackWarning {
+		clr = ColorRed
+	}
+	r.DrawText(fmt.Sprintf("Time left: %d:%02d", int(math.Ceil(left))/60, int(math.Ceil(left))%60), ScreenWidth/2, 40, clr, true)
+}
+
+// scoreText describes a Hall of Fame score in the unit of the game mode:
+// bounces, or points in arcade and time attack modes.
+func (c *Controller) scoreText(score model.Score) string {
+	switch c.GameLogic.Mode() {
+	case game.ModeArcade:
+		return fmt.Sprintf("%d Points", game.ArcadePoints(score))
+	case game.ModeTimeAttack:
+		return fmt.Sprintf("%d Points", game.TimeAttackPoints(score))
+	default:
+		return fmt.Sprintf("%d Bounces", score.Score)
+	}
+}
+
+// formatSeconds formats simulated seconds to a tenth.
+func formatSeconds(s float64) string {
+	return fmt.Sprintf("%.1fs", s)
+}
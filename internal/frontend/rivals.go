@@ -0,0 +1,46 @@
+package frontend
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/game"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/model"
+)
+
+// colorRivalAhead is the rival pace while the run is doing better than the rival.
+var colorRivalAhead = color.RGBA{R: 80, G: 255, B: 80, A: 255}
+
+// rival returns the best score on the loaded level's Hall of Fame by a name
+// the profile marked as a rival.
+func (c *Controller) rival() (model.Score, bool) {
+	if c.Profile == nil || len(c.Profile.Rivals) == 0 {
+		return model.Score{}, false
+	}
+	_, scores, _ := c.GameLogic.GetHighScoreData()
+	for _, score := range scores { // Best first
+		if c.Profile.IsRival(score.Name) {
+			return score, true
+		}
+	}
+	return model.Score{}, false
+}
+
+// drawRival shows how the run being played compares with the best rival on
+// the level's Hall of Fame: the bounces it has over the rival's score,
+// negative while it's still beating it.
+func (c *Controller) drawRival(r Renderer, bounces int) {
+	if c.GameLogic.Mode() != game.ModeClassic || c.GameLogic.Practice() {
+		return // Points and practice runs don't compare with bounce scores
+	}
+	rival, ok := c.rival()
+	if !ok {
+		return
+	}
+	pace := bounces - rival.Score
+	clr := colorRivalAhead
+	if pace >= 0 {
+		clr = ColorRed
+	}
+	r.DrawText(fmt.Sprintf("Rival %s: %+d bounces", rival.Name, pace), 10, 40, clr, false)
+}
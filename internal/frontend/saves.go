@@ -0,0 +1,197 @@
+package frontend
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/game"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/paths"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/persistence"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/ui"
+)
+
+// saveBrowserRows is how many saves the save browser shows at once.
+const saveBrowserRows = 10
+
+// Where the save browser shows the selected save, right of the list.
+const (
+	slotPreviewX      = 420
+	slotPreviewY      = 110
+	slotPreviewWidth  = ScreenWidth / 4
+	slotPreviewHeight = ScreenHeight / 4
+)
+
+// saveBrowserPage lists the saved games, one per standard level.
+type saveBrowserPage struct {
+	menu  *ui.Menu
+	list  *ui.List
+	slots []persistence.SaveSlot
+}
+
+// requestSave saves the level being played in the background, with the
+// metadata the save browser shows of it and, if asked and the frontend can
+// take one, a thumbnail of the frame shown. It reports whether a level is
+// being played.
+func (c *Controller) requestSave(thumbnail bool) bool {
+	if state, _, level := c.GameLogic.GetGameState(); (state != game.StatePlaying && state != game.StatePaused) || level < 0 {
+		return false
+	}
+	slot := persistence.SaveSlot{Elapsed: time.Duration(c.GameLogic.Stats().Elapsed * float64(time.Second)), Saved: time.Now()}
+	if thumbnail && c.Thumbnail != nil {
+		slot.Thumbnail = c.Thumbnail()
+	}
+	err := c.GameLogic.RequestSaveGame(func(path string, level, totalBounces int, seed uint64, pacmans []game.PacmanSaveData) error {
+		if err := persistence.SaveGame(path, level, totalBounces, seed, pacmans); err != nil {
+			return err
+		}
+		slot.Path, slot.Level, slot.Bounces = path, level, totalBounces
+		if err := persistence.SaveSlotMeta(slot); err != nil {
+			log.Printf("Could not save the metadata of %s: %v", path, err) // The save itself is fine
+		}
+		return nil
+	})
+	return err == nil
+}
+
+// openSaveBrowser shows the save browser.
+func (c *Controller) openSaveBrowser() {
+	page := &saveBrowserPage{}
+	slots, err := persistence.ListSaveSlots(paths.SavesDir(c.GameLogic.DataDir()))
+	if err != nil {
+		log.Printf("Could not list saves: %v", err)
+	}
+	var items []string
+	for _, slot := range slots {
+		if _, err := os.Stat(standardLevelPath(slot.Level)); err != nil {
+			continue // Saved from a level that's gone
+		}
+		page.slots = append(page.slots, slot)
+		items = append(items, fmt.Sprintf("Level %d - %d bounces - %s", slot.Level, slot.Bounces, slot.Saved.Format("2006-01-02 15:04")))
+	}
+
+	page.list = &ui.List{Items: items, Rows: saveBrowserRows, OnSelect: func(i int) {
+		c.saveBrowser = nil
+		c.loadSave(page.slots[i].Path)
+	}}
+	back := &ui.Button{Label: "Back", OnPress: func() { c.saveBrowser = nil }}
+	if len(items) == 0 {
+		page.menu = newMenu(160, back)
+	} else {
+		page.menu = newMenu(110, page.list, back)
+		page.menu.X = slotPreviewX / 2 // Leaves room for the preview
+	}
+	c.saveBrowser = page
+}
+
+// Resume loads the save of a standard level saved last, autosaves and saves
+// on exit included, skipping the start screen. It reports false if there's
+// none to resume.
+func (c *Controller) Resume() bool {
+	slot, ok, err := persistence.LatestSaveSlot(paths.SavesDir(c.GameLogic.DataDir()))
+	if err != nil {
+		log.Printf("Could not list saves: %v", err)
+	}
+	if !ok {
+		return false
+	}
+	if _, err := os.Stat(standardLevelPath(slot.Level)); err != nil {
+		return false // Saved from a level that's gone
+	}
+	log.Printf("Resuming level %d, saved %s.", slot.Level, slot.Saved.Format("2006-01-02 15:04"))
+	c.loadSave(slot.Path)
+	return true
+}
+
+// loadSave starts loading a saved game in the background, offering to
+// recover what's left of it if it turns out damaged.
+func (c *Controller) loadSave(savePath string) {
+	// Pass the actual LoadGame function from persistence
+	err := c.requestSavedGame(savePath, persistence.LoadGame, func(err error) {
+		var damage *persistence.DamagedSaveError
+		if errors.As(err, &damage) {
+			c.recovery = &recoveryPrompt{damage: damage}
+			return
+		}
+		if err != nil {
+			log.Printf("Load failed: %v", err)
+			c.showToast(loadErrorMessage(err))
+			return
+		}
+		log.Println("Game Loaded.")
+		c.saveLoaded(savePath)
+	})
+	if err != nil {
+		log.Printf("Load failed: %v", err)
+		c.showToast(loadErrorMessage(err))
+	}
+}
+
+// saveLoaded goes on with the run loaded from savePath.
+func (c *Controller) saveLoaded(savePath string) {
+	c.recordLevel(savePath)
+	c.stopRun()
+	_, _, loadedLevel := c.GameLogic.GetGameState()
+	c.fetchScores(loadedLevel)
+	c.watchScores(loadedLevel)
+}
+
+// updateSaveBrowser handles input while the save browser is open.
+func (c *Controller) updateSaveBrowser(in Input) {
+	if in.Pressed(KeyBack) {
+		c.saveBrowser = nil
+		return
+	}
+	c.saveBrowser.menu.Update(c.menuInput(in))
+}
+
+// drawSaveBrowser renders the save browser.
+func (c *Controller) drawSaveBrowser(r Renderer) {
+	page := c.saveBrowser
+	r.DrawText("Load a Save", ScreenWidth/2, 50, ColorYellow, true)
+	if len(page.slots) == 0 {
+		r.DrawText("No saved games yet, press S while playing to save", ScreenWidth/2, 110, ColorGray, true)
+	} else {
+		drawSlotPreview(r, page.slots[page.list.Selected])
+	}
+	page.menu.Draw(r)
+	r.DrawText("UP/DOWN=Choose ENTER=Load ESC=Back", 10, ScreenHeight-20, ColorGray, false)
+}
+
+// drawSlotPreview shows a save's thumbnail, if the save and the renderer
+// have one, and how far the run got.
+func drawSlotPreview(r Renderer, slot persistence.SaveSlot) {
+	x, y, w, h := float64(slotPreviewX), float64(slotPreviewY), float64(slotPreviewWidth), float64(slotPreviewHeight)
+	ir, ok := r.(ImageRenderer)
+	if ok && slot.Thumbnail != nil {
+		ir.DrawImage(slot.Thumbnail, x, y, w, h)
+	} else {
+		r.DrawRect(x, y, w, h, menuStyle.Highlight)
+		r.DrawText("No preview", x+w/2, y+h/2-8, ColorGray, true)
+	}
+	r.DrawText(fmt.Sprintf("Level %d", slot.Level), x, y+h+10, ColorWhite, false)
+	r.DrawText(fmt.Sprintf("%d bounces", slot.Bounces), x, y+h+30, ColorWhite, false)
+	if slot.Elapsed > 0 {
+		r.DrawText("Played "+formatRunDuration(slot.Elapsed), x, y+h+50, ColorGray, false)
+	}
+	r.DrawText(slot.Saved.Format("2006-01-02 15:04"), x, y+h+70, ColorGray, false)
+}
+
+// reportSaves tells the player how the saves written in the background
+// went. Autosaves are only reported when they fail.
+func (c *Controller) reportSaves() {
+	for _, result := range c.GameLogic.PollSaveResults() {
+		auto := len(c.saves) > 0 && c.saves[0]
+		if len(c.saves) > 0 {
+			c.saves = c.saves[1:]
+		}
+		switch {
+		case result.Err != nil:
+			c.showToast("Save failed")
+		case !auto:
+			c.showToast("Game saved (press L to load)")
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package frontend
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/game"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/model"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/persistence"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/ui"
+)
+
+// maxNameLength is the longest name an entry can be renamed to, the same
+// limit as the name entry after a run.
+const maxNameLength = 15
+
+// scoreManagerPage is the screen for cleaning up a level's Hall of Fame:
+// entries are picked from a list, then renamed or deleted.
+type scoreManagerPage struct {
+	level   int
+	scores  []model.Score
+	err     error    // Set if the level's Hall of Fame couldn't be read
+	menu    *ui.Menu // The level's entries, or the entry being edited
+	editing int      // Index of the entry being edited, -1 while choosing one
+}
+
+// openScoreManager shows the Hall of Fame of a level for editing.
+func (c *Controller) openScoreManager(level int) {
+	page := &scoreManagerPage{level: level, editing: -1}
+	page.scores, page.err = persistence.LoadHighScores(c.highScorePath(level))
+	c.scoreManager = page
+	c.showScoreList(-1)
+}
+
+// highScorePath returns the Hall of Fame file of a level, in the mode the
+// game is played in.
+func (c *Controller) highScorePath(level int) string {
+	return game.HighScorePath(c.GameLogic.DataDir(), c.GameLogic.Mode(), level, "")
+}
+
+// showScoreList lists the entries of the page's level. Unless selected is
+// -1, which leaves the level picker focused, that entry is focused.
+func (c *Controller) showScoreList(selected int) {
+	page := c.scoreManager
+	page.editing = -1
+	lastLevel := 0
+	for level := 1; ; level++ {
+		if _, err := os.Stat(standardLevelPath(level)); err != nil {
+			break
+		}
+		lastLevel = level
+	}
+	levels := &ui.Slider{Label: "Level", Value: page.level, Min: 0, Max: lastLevel, OnChange: c.openScoreManager}
+	entries := &ui.List{Rows: hallOfFameRows, Selected: max(selected, 0), OnSelect: c.editScore}
+	for i, score := range page.scores {
+		entries.Items = append(entries.Items, fmt.Sprintf("%d. %s", i+1, c.scoreManagerItem(score)))
+	}
+	back := &ui.Button{Label: "Back", OnPress: func() { c.scoreManager = nil }}
+	if len(entries.Items) == 0 {
+		page.menu = newMenu(90, levels, back)
+		return
+	}
+	page.menu = newMenu(90, levels, entries, back)
+	if selected >= 0 {
+		page.menu.Focus = 1
+	}
+}
+
+// scoreManagerItem describes an entry as the Hall of Fame shows it, and
+// whether it's a rival.
+func (c *Controller) scoreManagerItem(score model.Score) string {
+	text := fmt.Sprintf("%s - %s", score.Name, c.scoreText(score))
+	if c.Profile != nil && c.Profile.IsRival(score.Name) {
+		text += " (rival)"
+	}
+	return text
+}
+
+// editScore offers to rename or delete the i-th entry, and to mark its
+// name as a rival if there's a profile.
+func (c *Controller) editScore(i int) {
+	page := c.scoreManager
+	page.editing = i
+	name := &ui.TextField{Label: "Name:", Text: []rune(page.scores[i].Name), MaxLen: maxNameLength}
+	rename := func() {
+		if len(name.Text) == 0 {
+			c.showToast("Names can't be empty")
+			return
+		}
+		page.scores[i].Name = string(name.Text)
+		c.writeScores(i)
+	}
+	name.OnSubmit = func(string) { rename() }
+	widgets := []ui.Widget{name,
+		&ui.Button{Label: "Rename", OnPress: rename},
+		&ui.Button{Label: "Delete", OnPress: func() {
+			page.scores = append(page.scores[:i], page.scores[i+1:]...)
+			c.writeScores(min(i, len(page.scores)-1))
+		}},
+	}
+	if c.Profile != nil {
+		entry := page.scores[i].Name
+		widgets = append(widgets, &ui.Toggle{Label: "Rival", On: c.Profile.IsRival(entry), OnChange: func(on bool) {
+			if err := c.Profile.SetRival(entry, on); err != nil {
+				log.Printf("Could not save rivals: %v", err)
+				c.showToast("Could not save rivals")
+			}
+		}})
+	}
+	widgets = append(widgets, &ui.Button{Label: "Cancel", OnPress: func() { c.showScoreList(i) }})
+	page.menu = newMenu(130, widgets...)
+}
+
+// writeScores saves the page's entries back to the level's Hall of Fame and
+// goes back to the list with the given entry selected. The loaded level's
+// scores are updated too.
+func (c *Controller) writeScores(selected int) {
+	page := c.scoreManager
+	if err := persistence.SaveHighScores(page.scores, c.highScorePath(page.level)); err != nil {
+		log.Printf("Could not save Hall of Fame of level %d: %v", page.level, err)
+		c.showToast("Could not save the Hall of Fame")
+		c.openScoreManager(page.level) // Back to what's on disk
+		return
+	}
+	c.GameLogic.SetHighScores(page.level, append([]model.Score(nil), page.scores...))
+	c.showScoreList(selected)
+}
+
+// updateScoreManager handles input while the Hall of Fame manager is open.
+// ESC leaves an entry being edited, then the page.
+func (c *Controller) updateScoreManager(in Input) {
+	page := c.scoreManager
+	switch {
+	case in.Pressed(KeyBack) && page.editing >= 0:
+		c.showScoreList(page.editing)
+	case in.Pressed(KeyBack):
+		c.scoreManager = nil
+	default:
+		page.menu.Update(c.menuInput(in))
+	}
+}
+
+// drawScoreManager renders the Hall of Fame manager.
+func (c *Controller) drawScoreManager(r Renderer) {
+	page := c.scoreManager
+	title := "Manage Hall of Fame"
+	if mode := c.GameLogic.Mode(); mode != game.ModeClassic {
+		title = "Manage " + mode.String() + " Hall of Fame"
+	}
+	r.DrawText(title, ScreenWidth/2, 40, ColorYellow, true)
+	switch {
+	case page.err != nil:
+		r.DrawText(fmt.Sprintf("Hall of Fame of level %d unavailable", page.level), ScreenWidth/2, ScreenHeight/2+40, ColorRed, true)
+		r.DrawText(page.err.Error(), ScreenWidth/2, ScreenHeight/2+70, ColorGray, true)
+	case page.editing >= 0:
+		r.DrawText(fmt.Sprintf("Level %d, entry %d: %s", page.level, page.editing+1, c.scoreManagerItem(page.scores[page.editing])), ScreenWidth/2, 90, ColorWhite, true)
+	case len(page.scores) == 0:
+		r.DrawText("No scores yet!", ScreenWidth/2, ScreenHeight/2, ColorGray, true)
+	}
+	page.menu.Draw(r)
+	if page.editing >= 0 {
+		r.DrawText("Type to rename ENTER=Choose ESC=Back", 10, ScreenHeight-20, ColorGray, false)
+	} else {
+		r.DrawText("LEFT/RIGHT=Level UP/DOWN=Choose ENTER=Edit ESC=Back", 10, ScreenHeight-20, ColorGray, false)
+	}
+}
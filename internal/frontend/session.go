@@ -0,0 +1,83 @@
+package frontend
+
+import (
+	"log"
+	"sync"
+
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/game"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/leaderboard"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/scoreapi"
+)
+
+// scoreSession is the score server's session of the run being played, see
+// startSession. The server answers in the background, hence the lock.
+type scoreSession struct {
+	layout string // Layout hash of the level when the run started
+
+	mu    sync.Mutex
+	token string          // Empty until the server answers, or if it couldn't
+	run   leaderboard.Run // Filled in once the run is over, see finishSession
+}
+
+// startSession asks the score server, if there is one, for the token the
+// score of the run starting on level is submitted with.
+func (c *Controller) startSession(level int) {
+	c.session = nil
+	if c.Leaderboard == nil || c.GameLogic.Mode() != game.ModeClassic {
+		return
+	}
+	session := &scoreSession{layout: c.GameLogic.LayoutHash()}
+	c.session = session
+	ctx := c.ctx
+	c.background.Add(1)
+	go func() {
+		defer c.background.Done()
+		token, err := c.Leaderboard.StartSession(ctx, level)
+		if err != nil {
+			if ctx.Err() == nil {
+				log.Printf("Could not start a session on the leaderboard server, the score may be turned away: %v", err)
+			}
+			return
+		}
+		session.mu.Lock()
+		defer session.mu.Unlock()
+		session.token = token
+	}()
+}
+
+// finishSession keeps what the score server is told about the run that
+// just ended. Call it before the run's clicks are handed to the heatmap.
+func (c *Controller) finishSession(stats game.RunStats) {
+	if c.session == nil {
+		return
+	}
+	session := c.session
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	if session.token == "" {
+		return // Submitted without a session
+	}
+	clicks := make([][2]float64, len(c.runClicks))
+	for i, p := range c.runClicks {
+		clicks[i] = [2]float64{p.X, p.Y}
+	}
+	session.run = leaderboard.Run{
+		Session: session.token,
+		Hash:    scoreapi.HashRun(session.token, session.layout, clicks),
+		Elapsed: stats.Elapsed,
+		Catches: stats.Catches,
+	}
+}
+
+// takeSessionRun returns the run of the session that just ended for its
+// score submission, and ends the session: a token is only good once.
+func (c *Controller) takeSessionRun() leaderboard.Run {
+	session := c.session
+	c.session = nil
+	if session == nil {
+		return leaderboard.Run{}
+	}
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	return session.run
+}
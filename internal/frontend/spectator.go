@@ -0,0 +1,70 @@
+package frontend
+
+import (
+	"fmt"
+
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/game"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/spectate"
+)
+
+// Spectator draws a run streamed from another game instead of playing one.
+// It has the same Update/Draw shape as Controller so frontends can run either.
+type Spectator struct {
+	Stream        *spectate.Client
+	ReducedMotion bool // Same as Controller.ReducedMotion
+}
+
+// NewSpectator creates a spectator for a connected stream.
+func NewSpectator(stream *spectate.Client) *Spectator {
+	return &Spectator{Stream: stream}
+}
+
+// Update handles the only input a spectator has: quitting. It also stops once the stream ends.
+func (s *Spectator) Update(in Input) error {
+	if in.Pressed(KeyQuit) {
+		return ErrQuit
+	}
+	if _, _, err := s.Stream.Latest(); err != nil {
+		return fmt.Errorf("spectator stream ended: %w", err)
+	}
+	return nil
+}
+
+// Draw renders the newest snapshot of the watched run.
+func (s *Spectator) Draw(r Renderer) {
+	r.Fill(ColorDarkBlue)
+
+	snapshot, ok, _ := s.Stream.Latest()
+	if !ok {
+		r.DrawText("Waiting for the run to start...", ScreenWidth/2, ScreenHeight/2, ColorGray, true)
+		r.DrawText("Q=Quit", 10, ScreenHeight-20, ColorGray, false)
+		return
+	}
+
+	for _, e := range snapshot.Pacmans {
+		p := game.PacmanDrawData{
+			PosX:      float64(e[0]),
+			PosY:      float64(e[1]),
+			Radius:    float64(e[2]),
+			AnimFrame: e[3],
+		}
+		if s.ReducedMotion {
+			p.AnimFrame = 0
+		}
+		r.DrawEntity(p)
+	}
+
+	r.DrawText(fmt.Sprintf("Level: %d", snapshot.Level), 10, 20, ColorWhite, false)
+	r.DrawText(fmt.Sprintf("Bounces: %d", snapshot.Bounces), ScreenWidth-150, 20, ColorWhite, false)
+	r.DrawText("Spectating", ScreenWidth/2, 20, ColorYellow, true)
+	r.DrawText("Q=Quit", 10, ScreenHeight-20, ColorGray, false)
+
+	switch game.GameState(snapshot.State) {
+	case game.StateGameOver:
+		r.DrawText("GAME OVER!", ScreenWidth/2, ScreenHeight/2-30, ColorRed, true)
+	case game.StateEnteringHighScore:
+		r.DrawText("New High Score!", ScreenWidth/2, ScreenHeight/2-30, ColorYellow, true)
+	case game.StateHallOfFame:
+		r.DrawText(fmt.Sprintf("Hall of Fame - Level %d", snapshot.Level), ScreenWidth/2, ScreenHeight/2-30, ColorYellow, true)
+	}
+}
@@ -0,0 +1,30 @@
+package frontend
+
+import "time"
+
+// toastDuration is how long a toast stays up.
+const toastDuration = 3 * time.Second
+
+// toast is a short message shown over the game for a moment, see showToast.
+type toast struct {
+	text string
+	at   time.Time
+}
+
+// showToast shows text at the bottom of the screen for toastDuration,
+// replacing the toast already up.
+func (c *Controller) showToast(text string) {
+	c.toast = &toast{text: text, at: time.Now()}
+}
+
+// drawToast draws the toast that's up, if any, above the key hints.
+func (c *Controller) drawToast(r Renderer) {
+	if c.toast == nil {
+		return
+	}
+	if time.Since(c.toast.at) >= toastDuration {
+		c.toast = nil
+		return
+	}
+	r.DrawText(c.toast.text, ScreenWidth/2, ScreenHeight-45, ColorYellow, true)
+}
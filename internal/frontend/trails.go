@@ -0,0 +1,70 @@
+package frontend
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/game"
+)
+
+// Motion trails behind fast Pacmans, see MotionTrails.
+const (
+	trailMinSpeed    = 90.0 // Pixels per second a Pacman needs for a trail
+	trailLength      = 6    // Positions kept per Pacman
+	trailSampleTicks = 3    // Ticks between two kept positions
+	trailMaxJump     = 40.0 // Farther moves between samples (respawns, rewinds) start a new trail
+	trailAlpha       = 160  // Opacity of the segment nearest the Pacman
+)
+
+// trailPoint is a past position of a Pacman.
+type trailPoint struct {
+	x, y float64
+}
+
+// recordTrails keeps the recent positions of the fast Pacmans, indexed like
+// game.Game.GetPacmanData.
+func (c *Controller) recordTrails() {
+	if !c.MotionTrails || c.ReducedMotion {
+		c.trails = nil
+		return
+	}
+	c.trailTicks++
+	if c.trailTicks%trailSampleTicks != 0 {
+		return
+	}
+
+	pacmans := c.GameLogic.GetPacmanData()
+	if len(c.trails) != len(pacmans) {
+		c.trails = make([][]trailPoint, len(pacmans))
+	}
+	for i, p := range pacmans {
+		t := c.trails[i]
+		if p.IsStopped || math.Hypot(p.VelX, p.VelY) < trailMinSpeed {
+			c.trails[i] = t[:0]
+			continue
+		}
+		if n := len(t); n > 0 && math.Hypot(p.PosX-t[n-1].x, p.PosY-t[n-1].y) > trailMaxJump {
+			t = t[:0]
+		}
+		if len(t) == trailLength {
+			t = append(t[:0], t[1:]...)
+		}
+		c.trails[i] = append(t, trailPoint{p.PosX, p.PosY})
+	}
+}
+
+// drawTrails draws the trails behind pacmans, fading out towards their
+// oldest position. Draw them before the Pacmans so they stay underneath.
+func (c *Controller) drawTrails(r Renderer, pacmans []game.PacmanDrawData) {
+	for i, t := range c.trails {
+		if i >= len(pacmans) || len(t) == 0 || pacmans[i].IsStopped {
+			continue
+		}
+		points := append(t, trailPoint{pacmans[i].PosX, pacmans[i].PosY})
+		for j := 1; j < len(points); j++ {
+			a := uint8(trailAlpha * j / (len(points) - 1))
+			clr := color.RGBA{R: a, G: a, B: 0, A: a} // Yellow, premultiplied
+			r.DrawLine(points[j-1].x, points[j-1].y, points[j].x, points[j].y, clr)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package frontend
+
+import (
+	"log"
+	"time"
+
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/game"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/twitch"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/ui"
+)
+
+// Effects of the chat commands.
+const (
+	chatSpawnDiameter = 30
+	chatSpawnWaitMs   = 80
+	chatSlowFactor    = 0.5
+	chatSlowDuration  = 5 * time.Second
+)
+
+// twitchSettingsPage is the screen for editing the chat interaction settings.
+type twitchSettingsPage struct {
+	menu    *ui.Menu
+	channel *ui.TextField
+	token   *ui.TextField
+}
+
+// EnableTwitch turns on the Twitch chat interaction mode, with its settings
+// kept at path. Chat is only joined if the saved settings enable it.
+func (c *Controller) EnableTwitch(path string) {
+	c.twitchSettingsPath = path
+	settings, err := twitch.LoadSettings(path)
+	if err != nil {
+		log.Printf("Twitch chat disabled: %v", err)
+		return
+	}
+	c.applyTwitchSettings(settings)
+}
+
+// StopTwitch leaves chat, if joined.
+func (c *Controller) StopTwitch() {
+	if c.twitch != nil {
+		c.twitch.Close()
+		c.twitch = nil
+	}
+}
+
+// applyTwitchSettings (re)joins chat according to settings.
+func (c *Controller) applyTwitchSettings(settings twitch.Settings) {
+	c.StopTwitch()
+	if !settings.Enabled {
+		return
+	}
+	client, err := twitch.Connect(settings)
+	if err != nil {
+		log.Printf("Could not join Twitch chat: %v", err)
+		return
+	}
+	c.twitch = client
+}
+
+// openTwitchSettings shows the settings page filled with the saved settings.
+func (c *Controller) openTwitchSettings() {
+	settings, err := twitch.LoadSettings(c.twitchSettingsPath)
+	if err != nil {
+		log.Printf("Could not read Twitch settings, starting from scratch: %v", err)
+	}
+	page := &twitchSettingsPage{
+		channel: &ui.TextField{Label: "Channel:", Text: []rune(settings.Channel), MaxLen: 64},
+		// Don't show the token on stream
+		token: &ui.TextField{Label: "OAuth token (optional):", Text: []rune(settings.Token), MaxLen: 64, Masked: true},
+	}
+	page.channel.OnSubmit = func(string) { page.menu.Focus = 1 }
+	page.token.OnSubmit = func(string) { c.saveTwitchSettings() }
+	page.menu = newMenu(170, page.channel, page.token,
+		&ui.Button{Label: "Save", OnPress: c.saveTwitchSettings},
+		&ui.Button{Label: "Cancel", OnPress: func() { c.twitchSettings = nil }})
+	c.twitchSettings = page
+}
+
+// saveTwitchSettings saves the settings entered on the page, (re)joins chat
+// accordingly and closes the page.
+func (c *Controller) saveTwitchSettings() {
+	page := c.twitchSettings
+	settings := twitch.Settings{
+		Channel: string(page.channel.Text),
+		Token:   string(page.token.Text),
+	}
+	settings.Enabled = settings.Channel != ""
+	if err := settings.Save(c.twitchSettingsPath); err != nil {
+		log.Printf("Could not save Twitch settings: %v", err)
+	}
+	c.applyTwitchSettings(settings)
+	c.twitchSettings = nil
+}
+
+// updateTwitchSettings handles input while the settings page is open. Letters
+// are always text here, so the letter shortcuts (Q, S, L) are ignored.
+func (c *Controller) updateTwitchSettings(in Input) {
+	if in.Pressed(KeyBack) {
+		c.twitchSettings = nil
+		return
+	}
+	c.twitchSettings.menu.Update(c.menuInput(in))
+}
+
+// drawTwitchSettings renders the settings page.
+func (c *Controller) drawTwitchSettings(r Renderer) {
+	r.DrawText("Twitch Chat Settings", ScreenWidth/2, 60, ColorYellow, true)
+	r.DrawText("Viewers can type !spawn and !slow during your run", ScreenWidth/2, 100, ColorGray, true)
+	c.twitchSettings.menu.Draw(r)
+	r.DrawText("Leave the channel empty to turn chat interaction off", ScreenWidth/2, 360, ColorGray, true)
+	r.DrawText("ENTER=Next/Save ESC=Cancel", 10, ScreenHeight-20, ColorGray, false)
+}
+
+// applyChatCommands runs the chat commands accepted since the last tick.
+func (c *Controller) applyChatCommands() {
+	if c.twitch == nil {
+		return
+	}
+	for _, cmd := range c.twitch.Poll() {
+		switch cmd.Name {
+		case twitch.CommandSpawn:
+			if c.GameLogic.SpawnRandomPacman(chatSpawnDiameter/2.0, chatSpawnWaitMs) {
+				log.Printf("Chat: %s spawned a Pacman", cmd.User)
+			}
+		case twitch.CommandSlow:
+			c.GameLogic.ApplySlowMotion(chatSlowFactor, chatSlowDuration)
+			log.Printf("Chat: %s triggered slow motion", cmd.User)
+			c.autosaveDue()
+		}
+	}
+}
+
+// WantsText reports whether typed characters are currently used as text
+// (name entry, settings), so frontends know to collect them.
+func (c *Controller) WantsText() bool {
+	if c.twitchSettings != nil || c.campaignEnd != nil && c.campaignEnd.entering {
+		return true
+	}
+	if c.scoreManager != nil && c.scoreManager.editing >= 0 {
+		return true
+	}
+	state, _, _ := c.GameLogic.GetGameState()
+	return state == game.StateEnteringHighScore
+}
+
+// TextPosition returns where the text being typed is shown, so frontends
+// can put an input method's candidate window right under it.
+func (c *Controller) TextPosition() (x, y float64) {
+	if c.twitchSettings != nil {
+		return c.twitchSettings.menu.TextPosition()
+	}
+	if c.scoreManager != nil {
+		return c.scoreManager.menu.TextPosition()
+	}
+	return ScreenWidth / 2, nameEntryY + ui.RowHeight
+}
@@ -0,0 +1,88 @@
+package frontend
+
+import (
+	"fmt"
+	"log"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// stallThreshold is how long a tick may run before the watchdog reports the
+// game as stalled. A tick normally takes a fraction of a frame.
+const stallThreshold = 2 * time.Second
+
+// watchdog notices ticks that don't finish, e.g. a deadlock on the game's
+// mutex or a collision loop running away, and logs where every goroutine is
+// stuck, see runWatchdog.
+type watchdog struct {
+	mu       sync.Mutex
+	started  time.Time // When the tick under way started, zero between ticks
+	reported bool      // The tick under way was logged as stalled
+}
+
+// begin marks the start of a tick.
+func (w *watchdog) begin() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.started = time.Now()
+	w.reported = false
+}
+
+// end marks the end of a tick. It returns how long the tick took if the
+// watchdog reported it as stalled.
+func (w *watchdog) end() (time.Duration, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	took := time.Since(w.started)
+	w.started = time.Time{}
+	return took, w.reported
+}
+
+// check logs a goroutine dump, once per tick, if the tick under way has run
+// for longer than stallThreshold.
+func (w *watchdog) check(now time.Time) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.started.IsZero() || w.reported || now.Sub(w.started) < stallThreshold {
+		return
+	}
+	w.reported = true
+	log.Printf("Watchdog: tick has been running for %v, the game may be frozen. Goroutines:\n%s", now.Sub(w.started).Round(time.Millisecond), goroutineDump())
+}
+
+// runWatchdog checks for a stalled tick until the controller is closed.
+func (c *Controller) runWatchdog() {
+	defer c.background.Done()
+	ticker := time.NewTicker(stallThreshold / 4)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.ctx.Done():
+			return
+		case now := <-ticker.C:
+			c.watchdog.check(now)
+		}
+	}
+}
+
+// recoverFromStall gets the game going again after a tick the watchdog
+// reported: the time it took is skipped rather than played as one long
+// step, and the player is told the freeze was noticed.
+func (c *Controller) recoverFromStall(took time.Duration) {
+	log.Printf("Watchdog: stalled tick finished after %v.", took.Round(time.Millisecond))
+	c.GameLogic.SkipElapsed()
+	c.showToast(fmt.Sprintf("The game froze for %.1fs (details in the log)", took.Seconds()))
+}
+
+// goroutineDump returns the stacks of all goroutines.
+func goroutineDump() []byte {
+	buf := make([]byte, 64<<10)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			return buf[:n]
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+}
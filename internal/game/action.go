@@ -0,0 +1,93 @@
+package game
+
+import "github.com/Y1m4r/Catch-The-PacMan-Game/internal/model"
+
+// ActionKind is what a player does to the game, see Action.
+type ActionKind int
+
+const (
+	ActionCatchAt          ActionKind = iota // Click at X, Y
+	ActionCatchHighlighted                   // Catch the one-switch highlight
+	ActionUseMagnet
+	ActionPause  // Pause or resume, see TogglePause
+	ActionStep   // One step of a paused practice run
+	ActionRewind // One tick back, in practice mode; sent every tick it's held
+	ActionSave   // Write a save file with the writer set with SetSaveWriter
+	ActionTypeName
+	ActionEraseName
+	ActionConfirmName // Keep the score with the saver set with SetHighScoreSaver
+)
+
+// Action is one thing done to the game, whoever does it: the keyboard and
+// mouse of a frontend, a gamepad, a replay or an AI driver all feed the
+// game the same actions through Apply.
+type Action struct {
+	Kind ActionKind
+	X, Y float64 // Of ActionCatchAt
+	Text []rune  // Of ActionTypeName
+}
+
+// CatchAt is a click at x, y.
+func CatchAt(x, y float64) Action {
+	return Action{Kind: ActionCatchAt, X: x, Y: y}
+}
+
+// TypeName is text typed into the name of a new high score.
+func TypeName(text []rune) Action {
+	return Action{Kind: ActionTypeName, Text: text}
+}
+
+// Apply does an action to the game. It reports whether the action did
+// anything: false for a magnet with none left, a rewind with nothing to go
+// back to or a save outside a level. Actions that don't fit the current
+// state are ignored.
+func (g *Game) Apply(a Action) bool {
+	switch a.Kind {
+	case ActionCatchAt:
+		g.HandleClick(a.X, a.Y)
+	case ActionCatchHighlighted:
+		g.CatchHighlighted()
+	case ActionUseMagnet:
+		return g.UseMagnet()
+	case ActionPause:
+		g.TogglePause()
+	case ActionStep:
+		g.StepFrame()
+	case ActionRewind:
+		return g.Rewind()
+	case ActionSave:
+		g.mu.RLock()
+		write := g.saveWriter
+		g.mu.RUnlock()
+		return write != nil && g.RequestSaveGame(write) == nil
+	case ActionTypeName:
+		g.HandleTextInput(a.Text)
+	case ActionEraseName:
+		g.HandleBackspace()
+	case ActionConfirmName:
+		g.mu.RLock()
+		save := g.saveHighScores
+		g.mu.RUnlock()
+		if save == nil {
+			save = func([]model.Score, string) error { return nil }
+		}
+		g.HandleEnter(save)
+	}
+	return true
+}
+
+// SetSaveWriter makes ActionSave write save files with fn, see
+// RequestSaveGame. Without one, ActionSave does nothing.
+func (g *Game) SetSaveWriter(fn func(path string, level, totalBounces int, seed uint64, pacmans []PacmanSaveData) error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.saveWriter = fn
+}
+
+// SetHighScoreSaver makes ActionConfirmName write the Hall of Fame with
+// fn, see HandleEnter. Without one, it's only kept in memory.
+func (g *Game) SetHighScoreSaver(fn func(scores []model.Score, path string) error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.saveHighScores = fn
+}
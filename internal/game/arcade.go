@@ -0,0 +1,116 @@
+package game
+
+import (
+	"time"
+
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/model"
+)
+
+// Arcade mode, see SetArcade.
+const (
+	ArcadeDuration     = 60 * time.Second
+	ArcadeRespawnDelay = 1500 * time.Millisecond // From a catch to the Pacman coming back
+	ArcadeSpeedup      = 1.1                     // Speed factor of every respawn
+)
+
+// ArcadeScorer scores arcade runs: catches minus bounces, the more the
+// better. Score holds it negated so that lower stays better, ArcadePoints
+// turns it back.
+type ArcadeScorer struct{}
+
+func (ArcadeScorer) Score(run RunStats) model.Score {
+	return model.Score{Score: run.Bounces - run.Catches}
+}
+func (ArcadeScorer) Failed(RunStats) bool { return false }
+
+// ArcadePoints returns the points of a score set in arcade mode.
+func ArcadePoints(s model.Score) int {
+	return -s.Score
+}
+
+// SetArcade turns on arcade mode: every caught Pacman comes back at a random
+// edge ArcadeRespawnDelay later, a bit faster, and the run ends after
+// ArcadeDuration, scored with ArcadeScorer. Arcade scores have their own
+// Hall of Fame per level. Takes effect from the next level loaded.
+// Turning it off goes back to ModeClassic.
+func (g *Game) SetArcade(enabled bool) {
+	if enabled {
+		g.SetMode(ModeArcade)
+	} else if g.Mode() == ModeArcade {
+		g.SetMode(ModeClassic)
+	}
+}
+
+// Arcade reports whether arcade mode is on.
+func (g *Game) Arcade() bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.mode == ModeArcade
+}
+
+// ArcadeTimeLeft returns how long the arcade run has left, in simulated seconds.
+func (g *Game) ArcadeTimeLeft() float64 {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return max(0, ArcadeDuration.Seconds()-g.simTime)
+}
+
+// startArcade prepares the arcade run of a freshly loaded level: Pacmans
+// already caught, as in a save, come back like caught ones. Respawns draw
+// from the run's randomness, see seedRun. Assumes the write lock is held.
+func (g *Game) startArcade() {
+	if g.mode != ModeArcade {
+		return
+	}
+	for _, p := range g.Pacmans {
+		p.mu.Lock()
+		if p.IsStopped {
+			p.respawnAt = ArcadeRespawnDelay.Seconds()
+		}
+		p.mu.Unlock()
+	}
+}
+
+// scheduleRespawn brings a Pacman that was just caught back after
+// ArcadeRespawnDelay, in arcade mode. Assumes the write lock is held.
+func (g *Game) scheduleRespawn(p *Pacman) {
+	if g.mode != ModeArcade {
+		return
+	}
+	p.mu.Lock()
+	p.respawnAt = g.simTime + ArcadeRespawnDelay.Seconds()
+	p.mu.Unlock()
+}
+
+// respawnPacmans brings back the Pacmans whose respawn is due, each at a
+// random edge heading inwards. Assumes the write lock is held.
+func (g *Game) respawnPacmans() {
+	for _, p := range g.Pacmans {
+		p.mu.Lock()
+		if p.respawnAt > 0 && g.simTime >= p.respawnAt {
+			edge, along := g.rng.IntN(4), g.rng.Float64()
+			p.respawn(edge, along, g.ScreenWidth, g.ScreenHeight)
+		}
+		p.mu.Unlock()
+	}
+}
+
+// respawn puts a caught Pacman back in play on one of the screen's edges
+// (left, right, top, bottom), along a fraction of it, ArcadeSpeedup times
+// faster. Assumes the Pacman's lock is held.
+func (p *Pacman) respawn(edge int, along, screenWidth, screenHeight float64) {
+	x := p.Radius + along*(screenWidth-2*p.Radius)
+	y := p.Radius + along*(screenHeight-2*p.Radius)
+	switch edge {
+	case 0:
+		p.PosX, p.PosY, p.DirX, p.DirY = p.Radius, y, 1, 0
+	case 1:
+		p.PosX, p.PosY, p.DirX, p.DirY = screenWidth-p.Radius, y, -1, 0
+	case 2:
+		p.PosX, p.PosY, p.DirX, p.DirY = x, p.Radius, 0, 1
+	default:
+		p.PosX, p.PosY, p.DirX, p.DirY = x, screenHeight-p.Radius, 0, -1
+	}
+	p.Speed *= ArcadeSpeedup
+	p.IsStopped, p.wallWarned, p.stunned, p.respawnAt = false, false, 0, 0
+}
@@ -0,0 +1,65 @@
+package game
+
+import (
+	"time"
+
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/model"
+)
+
+// Chains of quick catches, see model.HallOfFameRules.Chains.
+const (
+	ChainWindow        = 1500 * time.Millisecond // Longest gap between two catches of a chain
+	ChainMaxMultiplier = 5
+)
+
+// ChainScorer takes the bounces earned by chains of quick catches off the
+// run's bounces, down to none, before scoring like the Scorer it wraps.
+// The catch at a chain's multiplier m earns m-1 bounces.
+type ChainScorer struct {
+	Scorer
+}
+
+func (s ChainScorer) Score(run RunStats) model.Score {
+	bonus := min(run.ChainBonus, max(run.Bounces, 0))
+	run.Bounces -= bonus
+	score := s.Scorer.Score(run)
+	score.Chain, score.ChainBonus = run.BestChain, bonus
+	return score
+}
+
+// Chain returns the multiplier of the chain under way and how many
+// simulated seconds are left to carry it on, with a multiplier of 0 if
+// there's none.
+func (g *Game) Chain() (multiplier int, left float64) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	left = g.counters.lastCatchAt + ChainWindow.Seconds() - g.simTime
+	if g.counters.chain == 0 || left <= 0 {
+		return 0, 0
+	}
+	return min(g.counters.chain, ChainMaxMultiplier), left
+}
+
+// countChain carries on the chain under way with a catch, or starts one,
+// and returns the catch's multiplier, 0 without chains. Levels score
+// chains if their Hall of Fame says so, so every score on a board is
+// scored alike: every catch within ChainWindow of the one before raises
+// the chain's multiplier, up to ChainMaxMultiplier, and earns bounces off
+// the score, see ChainScorer. A miss breaks the chain. Assumes the write
+// lock is held.
+func (g *Game) countChain() int {
+	if !g.HallOfFame.Chains {
+		return 0
+	}
+	c := &g.counters
+	if c.chain > 0 && g.simTime-c.lastCatchAt <= ChainWindow.Seconds() {
+		c.chain++
+	} else {
+		c.chain = 1
+	}
+	c.lastCatchAt = g.simTime
+	multiplier := min(c.chain, ChainMaxMultiplier)
+	c.chainBonus += multiplier - 1
+	c.bestChain = max(c.bestChain, multiplier)
+	return multiplier
+}
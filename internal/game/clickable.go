@@ -0,0 +1,64 @@
+package game
+
+import "slices"
+
+// Clickable is anything in the play area a click can land on: the Pacmans,
+// and whatever a frontend adds with AddClickable.
+type Clickable interface {
+	// Contains reports whether a click at x, y lands on it.
+	Contains(x, y float64) bool
+	// Click handles a click that landed on it. It's called from HandleClick
+	// with the game locked, so it must not call back into the game.
+	Click()
+}
+
+// AddClickable puts c on top of everything a click can land on, above the
+// Pacmans and the clickables added before it.
+func (g *Game) AddClickable(c Clickable) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.clickables = append(g.clickables, c)
+}
+
+// RemoveClickable takes back a clickable added with AddClickable.
+func (g *Game) RemoveClickable(c Clickable) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.clickables = slices.DeleteFunc(g.clickables, func(other Clickable) bool { return other == c })
+}
+
+// clickTargets lists what a click can land on, topmost first: the added
+// clickables, the last added first, the power-ups, the newest first, then
+// the Pacmans, the last drawn first. Assumes the read lock is held.
+func (g *Game) clickTargets() []Clickable {
+	targets := make([]Clickable, 0, len(g.clickables)+len(g.powerUps.items)+len(g.Pacmans))
+	for _, c := range slices.Backward(g.clickables) {
+		targets = append(targets, c)
+	}
+	for i := range slices.Backward(g.powerUps.items) {
+		targets = append(targets, powerUpTarget{g, i})
+	}
+	for _, p := range slices.Backward(g.Pacmans) {
+		targets = append(targets, pacmanTarget{g, p})
+	}
+	return targets
+}
+
+// pacmanTarget is a Pacman as a Clickable: clicking it stuns or catches it.
+type pacmanTarget struct {
+	g *Game
+	p *Pacman
+}
+
+func (t pacmanTarget) Contains(x, y float64) bool {
+	return t.p.hit(x, y, t.g.hitboxScale()) // Running Pacmans only
+}
+
+func (t pacmanTarget) Click() {
+	if t.g.twoStageCatch && t.p.Stun(StunDuration.Seconds()) {
+		posX, posY, _, _, _ := t.p.GetData()
+		t.g.soundEvent(SoundStun, posX, posY)
+		return
+	}
+	t.g.catch(t.p)
+}
@@ -0,0 +1,32 @@
+package game
+
+import "time"
+
+// Clock is where the game reads the time from, see SetClock.
+type Clock interface {
+	Now() time.Time
+}
+
+// wallClock is the Clock a game runs on unless told otherwise.
+type wallClock struct{}
+
+func (wallClock) Now() time.Time { return time.Now() }
+
+// SetClock makes the game read the time from clock rather than the wall
+// clock, so tests and tools can step it tick by tick: every Update moves
+// the Pacmans by the time clock advanced since the last one.
+func (g *Game) SetClock(clock Clock) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.clock = clock
+	g.lastUpdateTime = clock.Now()
+}
+
+// SkipElapsed drops the time since the last Update, so the next one moves
+// the Pacmans by a single tick. The frontend calls it after the game loop
+// stalled, which would otherwise make every Pacman jump.
+func (g *Game) SkipElapsed() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.lastUpdateTime = g.clock.Now()
+}
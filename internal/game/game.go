@@ -1,13 +1,18 @@
 package game
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"image/color"
 	"log"
+	"math/rand/v2"
+	"slices"
 	"sync"
 	"time"
 
-	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/audio" // Adjust import path
 	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/model" //
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/paths"
 )
 
 // GameState represents the possible states of the game screen.
@@ -19,8 +24,58 @@ const (
 	StateGameOver
 	StateEnteringHighScore // Waiting for player name input
 	StateHallOfFame        // Displaying high scores
+	StateLoading           // Showing a progress bar while assets or levels load, see StartLoading
+	StatePaused            // A level is being played but frozen, see TogglePause
 )
 
+// SoundPlayer plays a preloaded sound effect by name.
+// It is satisfied by *audio.AudioManager, keeping this package free of audio and
+// rendering dependencies.
+type SoundPlayer interface {
+	PlaySound(name string) Playback
+}
+
+// SpatialSoundPlayer is a SoundPlayer that can also place a sound in the
+// stereo field, from -1 (left) to 1 (right). Audio cues use it when the
+// frontend's player supports it.
+type SpatialSoundPlayer interface {
+	SoundPlayer
+	PlaySoundAt(name string, pan float64) Playback
+}
+
+// Playback is a sound a SoundPlayer started, to control it while it plays,
+// e.g. to cut it off when the level ends. A sound that couldn't be played
+// is a Playback that has already ended.
+type Playback interface {
+	Stop()                    // Ends it for good
+	Pause()                   // Holds it where it is
+	Resume()                  // Goes on from where it was paused
+	SetVolume(volume float64) // 1 is as recorded, 0 is silent
+	IsPlaying() bool          // Until it ended or was stopped, paused or not
+}
+
+// Sound names. The side walls share one cue placed left or right, the top
+// and bottom walls each have their own, see SetAudioCues.
+const (
+	SoundCatch         = "pacman_death"
+	SoundBounce        = "pacman_bounce" // No sound file yet, only reported to the sound hooks
+	SoundStun          = "pacman_stun"   // No sound file yet, only reported to the sound hooks
+	SoundCueWall       = "cue_wall"
+	SoundCueWallTop    = "cue_wall_top"
+	SoundCueWallBottom = "cue_wall_bottom"
+	SoundGameOver      = "level_up" // Jingle cutting off the level's sounds when it ends
+)
+
+// SoundEvent is a sound the game played, or would play, and where it happened,
+// so frontends can show it to players who can't hear it.
+type SoundEvent struct {
+	Name string
+	X, Y float64
+}
+
+// A Pacman's wall cue plays this many seconds before it hits the wall.
+const wallCueLead = 0.35
+
 // Game represents the overall game state and logic controller.
 type Game struct {
 	Pacmans      []*Pacman
@@ -29,20 +84,89 @@ type Game struct {
 	ScreenWidth  float64
 	ScreenHeight float64
 	CurrentState GameState
+	MaxBounces   int                   // Bounce budget of the level, 0 for none; going over it fails the run
+	TimeLimit    time.Duration         // Countdown of the level in ModeTimeAttack, 0 for TimeAttackDuration
+	Lives        int                   // Clicks catching nothing the level allows, 0 for no limit; running out fails the run
+	Seed         uint64                // Of the run's randomness, see seedRun; 0 in loaded data for the level's own
+	Theme        LevelTheme            // Colors of the level, see LevelTheme
+	HallOfFame   model.HallOfFameRules // Which runs make the level's Hall of Fame
+	Board        string                // Hall of Fame of the level instead of its number's, see HighScorePath; empty for standard levels
 
 	HighScores      []model.Score // Loaded high scores for the current level
 	highScorePath   string        // Path to save/load high scores for this level
 	saveGamePath    string        // Path to save the current game state
 	levelConfigPath string        // Path of the loaded level
+	dataDir         string        // Directory holding saves and high scores (see package paths)
 
 	lastUpdateTime time.Time
 	deltaTime      float64 // Time since last frame in seconds
 
+	// Slow-motion power-up: movement runs at timeScale until slowMotionUntil
+	timeScale       float64
+	slowMotionUntil time.Time
+	slowPowerUp     bool      // The slow motion comes from a power-up collected, see PowerUpEffects
+	pausedAt        time.Time // When StatePaused was entered, to push slowMotionUntil back by the pause
+
 	// Player name input buffer (for high score entry)
 	playerNameInput []rune
-	isNewHighScore  bool // Flag if the current score qualifies for high scores
+	isNewHighScore  bool        // Flag if the current score qualifies for high scores
+	failed          bool        // The run went over MaxBounces, out of time or out of lives, see Failed
+	entry           model.Score // ID and time of the finished run's Hall of Fame entry, see score
 
-	audioManager *audio.AudioManager // Reference to the audio manager
+	audioManager SoundPlayer // Plays sound effects; provided by the frontend
+	sounds       []Playback  // Sounds of the level that may still be playing, see stopSounds
+
+	spriteScale float64 // Accessibility option enlarging every Pacman, see SetSpriteScale
+	audioCues   bool    // Accessibility option warning of wall hits by ear, see SetAudioCues
+
+	modeScorer    Scorer     // Scoring rules of the game mode, see SetScorer
+	twoStageCatch bool       // First click stuns, second one catches, see SetTwoStageCatch
+	livesOverride int        // Lives of every level instead of their own, see SetLives
+	mode          Mode       // See SetMode
+	rng           *rand.Rand // The run's randomness, drawing from rngSrc, see seedRun
+	rngSrc        rand.PCG
+	handicap      model.Handicap // Handicap of the player's profile, see SetHandicap
+
+	// Magnet power-up, see UseMagnet
+	cursorX, cursorY        float64
+	magnets                 int     // Left for the level
+	magnetFrom, magnetUntil float64 // Simulated seconds the last magnet pulls between
+
+	// Power-ups, see SetPowerUps
+	powerUps   powerUpState
+	noPowerUps bool
+
+	hooks          hooks                                    // See OnSound, OnCatch, OnBounce and OnStateChange
+	loadHighScores func(path string) ([]model.Score, error) // Optional, see SetHighScoreLoader
+	clickables     []Clickable                              // Above the Pacmans, see AddClickable
+
+	// Used by Apply, see SetSaveWriter and SetHighScoreSaver
+	saveWriter     func(path string, level, totalBounces int, seed uint64, pacmans []PacmanSaveData) error
+	saveHighScores func(scores []model.Score, path string) error
+
+	clock Clock // Where the time is read from, see SetClock
+
+	// Practice mode, see SetPractice
+	practice     bool
+	paused       bool
+	pendingSteps int        // Frame steps requested while paused
+	history      []snapshot // Recent ticks to rewind to, oldest first, see Rewind
+	simTime      float64    // Simulated seconds since the level started
+
+	counters runCounters // Statistics of the run, see Stats
+
+	// One-switch mode, see SetSwitchScan
+	switchScan    time.Duration
+	highlighted   int // Index of the highlighted Pacman, -1 for none
+	highlightedAt time.Time
+
+	// Loading screen, see StartLoading
+	loaders      int       // Loadings under way, StateLoading lasts until they're all done
+	afterLoading GameState // State to go on to once loaded
+	loadProgress LoadProgress
+	levelLoad    *levelLoad // Level being read, see RequestLoadLevel
+
+	saves saver // Save files being written, see RequestSaveGame
 
 	// Mutex to protect shared game state (Pacmans slice, TotalBounces, CurrentState, HighScores)
 	mu sync.RWMutex // Allows multiple readers (Draw) or one writer (Update, HandleClick)
@@ -53,12 +177,29 @@ func (g *Game) IsNewHighScorePending() (any, any) {
 	panic("unimplemented")
 }
 
+// ResetToStart unloads the level and goes back to the start screen.
 func (g *Game) ResetToStart() {
-	panic("unimplemented")
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.Level = -1
+	g.Pacmans = []*Pacman{}
+	g.TotalBounces = 0
+	g.MaxBounces = 0
+	g.TimeLimit = 0
+	g.Lives = 0
+	g.Theme = LevelTheme{}
+	g.HallOfFame = model.HallOfFameRules{}
+	g.Board = ""
+	g.failed = false
+	g.entry = model.Score{}
+	g.enterState(StateStarting)
+	g.stopSounds()
+	g.clearHistory()
+	g.resetHighlight()
 }
 
 // NewGame initializes a new game state, but doesn't load a level yet.
-func NewGame(screenWidth, screenHeight float64, audioMgr *audio.AudioManager) *Game {
+func NewGame(screenWidth, screenHeight float64, audioMgr SoundPlayer) *Game {
 	g := &Game{
 		Level:        -1, // No level loaded initially
 		ScreenWidth:  screenWidth,
@@ -67,42 +208,224 @@ func NewGame(screenWidth, screenHeight float64, audioMgr *audio.AudioManager) *G
 		Pacmans:      []*Pacman{},
 		HighScores:   []model.Score{},
 		audioManager: audioMgr,
+		highlighted:  -1,
+		dataDir:      paths.LegacyDir,
+		spriteScale:  1,
+		modeScorer:   BounceScorer{},
+		clock:        wallClock{},
 	}
+	g.rng = rand.New(&g.rngSrc)
 	return g
 }
 
-// RequestLoadLevel triggers the loading of a level configuration.
-// It acquires the write lock to modify game state safely.
-func (g *Game) RequestLoadLevel(level int, configPath string, loadFunc func(string) (*Game, error)) error {
+// SetDataDir sets the directory saves and high scores are kept in.
+// It defaults to the assets directory, like older versions of the game.
+func (g *Game) SetDataDir(dir string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.dataDir = dir
+}
+
+// SetSpriteScale enlarges every Pacman, and with it its hitbox, by scale,
+// for players with motor or vision impairments. It applies from the next
+// level load, and the scores set are marked as assisted.
+func (g *Game) SetSpriteScale(scale float64) error {
+	if !slices.Contains(model.SpriteScales, scale) {
+		return fmt.Errorf("sprite scale must be one of %v, got %g", model.SpriteScales, scale)
+	}
 	g.mu.Lock()
 	defer g.mu.Unlock()
+	g.spriteScale = scale
+	return nil
+}
+
+// SetAudioCues turns on positional sound cues played shortly before a
+// Pacman hits a wall, so low-vision players can follow the action by ear.
+// They need a SpatialSoundPlayer.
+func (g *Game) SetAudioCues(enabled bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.audioCues = enabled
+}
 
-	log.Printf("Requesting load level %d from %s", level, configPath)
-	loadedGameData, err := loadFunc(configPath)
-	if err != nil {
-		log.Printf("Error loading level config %s: %v", configPath, err)
-		return fmt.Errorf("failed to load level config '%s': %w", configPath, err)
+// playSound plays a sound of the level, kept to be cut off when it ends.
+// Assumes the write lock is held.
+func (g *Game) playSound(name string) {
+	if g.audioManager != nil {
+		g.trackSound(g.audioManager.PlaySound(name))
 	}
+}
+
+// trackSound keeps a sound of the level, dropping the ones that ended.
+// Assumes the write lock is held.
+func (g *Game) trackSound(p Playback) {
+	g.sounds = slices.DeleteFunc(g.sounds, func(p Playback) bool { return !p.IsPlaying() })
+	g.sounds = append(g.sounds, p)
+}
+
+// stopSounds cuts off the sounds of the level still playing.
+// Assumes the write lock is held.
+func (g *Game) stopSounds() {
+	for _, p := range g.sounds {
+		p.Stop()
+	}
+	g.sounds = nil
+}
+
+// playGameOver ends the level's sounds with the game over jingle.
+// Assumes the write lock is held.
+func (g *Game) playGameOver() {
+	g.stopSounds()
+	if g.audioManager != nil {
+		g.audioManager.PlaySound(SoundGameOver)
+	}
+}
+
+// SpriteScale returns the Pacman size multiplier set with SetSpriteScale.
+func (g *Game) SpriteScale() float64 {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.spriteScale
+}
+
+// DataDir returns the directory saves and high scores are kept in.
+func (g *Game) DataDir() string {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.dataDir
+}
+
+// SaveGamePath returns the save file of a level.
+func (g *Game) SaveGamePath(level int) string {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return paths.SaveGamePath(g.dataDir, level)
+}
+
+// levelLoad is a level or a save being read in the background, see
+// RequestLoadLevel and RequestLoadSavedGame.
+type levelLoad struct {
+	configPath string
+	savePath   string // Save being read instead of a level, if any
+	done       chan levelLoadResult
+}
+
+// levelLoadResult is a level read by a levelLoad, with its high scores.
+type levelLoadResult struct {
+	data      *Game
+	err       error
+	scores    []model.Score
+	scoresErr error
+}
+
+// RequestLoadLevel starts loading a level configuration on a background
+// goroutine, so slow disks don't stall the frames. The game shows
+// StateLoading meanwhile, until PollLevelLoad swaps the level in. Only one
+// level loads at a time.
+func (g *Game) RequestLoadLevel(configPath string, loadFunc func(string) (*Game, error)) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if err := g.checkNoLoad(configPath); err != nil {
+		return err
+	}
+
+	log.Printf("Requesting load level from %s", configPath)
+	load := &levelLoad{configPath: configPath, done: make(chan levelLoadResult, 1)}
+	g.levelLoad = load
+	g.startLoading()
+	g.loadProgress.Done, g.loadProgress.Total, g.loadProgress.Item = 0, 1, configPath
+	dataDir, mode, loadHighScores := g.dataDir, g.mode, g.loadHighScores
+	go func() {
+		var r levelLoadResult
+		if r.data, r.err = loadFunc(configPath); r.err == nil && loadHighScores != nil {
+			r.scores, r.scoresErr = loadHighScores(HighScorePath(dataDir, mode, r.data.Level, r.data.Board))
+		}
+		load.done <- r
+	}()
+	return nil
+}
+
+// checkNoLoad refuses to start loading path while another level or save
+// is still loading. Assumes the lock is held.
+func (g *Game) checkNoLoad(path string) error {
+	if g.levelLoad == nil {
+		return nil
+	}
+	loading := g.levelLoad.configPath
+	if g.levelLoad.savePath != "" {
+		loading = g.levelLoad.savePath
+	}
+	return fmt.Errorf("can't load '%s' while '%s' is still loading", path, loading)
+}
+
+// PollLevelLoad swaps in the level or save RequestLoadLevel or
+// RequestLoadSavedGame started loading once it's read, leaving
+// StateLoading. It reports whether a load finished, and why it failed if
+// it did, in which case the game goes back to where it was. Call it every
+// tick.
+func (g *Game) PollLevelLoad() (finished bool, err error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.levelLoad == nil {
+		return false, nil
+	}
+	var r levelLoadResult
+	select {
+	case r = <-g.levelLoad.done:
+	default:
+		return false, nil
+	}
+	load := g.levelLoad
+	g.levelLoad = nil
+	defer g.finishLoading()
+	if load.savePath != "" {
+		if r.err != nil {
+			log.Printf("Error loading saved game %s: %v", load.savePath, r.err)
+			return true, fmt.Errorf("failed to load saved game '%s': %w", load.savePath, r.err)
+		}
+		g.swapInSave(r, load.savePath)
+		return true, nil
+	}
+	if r.err != nil {
+		log.Printf("Error loading level config %s: %v", load.configPath, r.err)
+		return true, fmt.Errorf("failed to load level config '%s': %w", load.configPath, r.err)
+	}
+	g.swapInLevel(r, load.configPath)
+	return true, nil
+}
+
+// swapInLevel replaces the level with one a levelLoad read.
+// Assumes the write lock is held.
+func (g *Game) swapInLevel(r levelLoadResult, configPath string) {
+	loadedGameData := r.data
 
 	// Transfer loaded data to the current game instance
 	g.Level = loadedGameData.Level
 	g.Pacmans = loadedGameData.Pacmans
 	g.TotalBounces = loadedGameData.TotalBounces // Usually 0 for new level, but loader might set it
-	g.CurrentState = StatePlaying
+	g.MaxBounces = loadedGameData.MaxBounces
+	g.TimeLimit = loadedGameData.TimeLimit
+	g.Lives = loadedGameData.Lives
+	g.Theme = loadedGameData.Theme
+	g.HallOfFame = loadedGameData.HallOfFame
+	g.Board = loadedGameData.Board
+	g.failed = false
+	g.entry = model.Score{}
+	g.scalePacmans()
+	g.enterState(StatePlaying)
 	g.levelConfigPath = configPath
-	g.highScorePath = fmt.Sprintf("assets/highscores/highscores_%d.gob", g.Level)
-	g.saveGamePath = fmt.Sprintf("assets/saves/savegame_%d.txt", g.Level) // Or a generic quicksave path
+	g.highScorePath = g.levelHighScorePath()
+	g.saveGamePath = paths.SaveGamePath(g.dataDir, g.Level) // Or a generic quicksave path
 	g.playerNameInput = []rune{}
 	g.isNewHighScore = false
 
-	// Call the injected loader function (which now returns []model.Score)
-	if loadHighScoresFunc != nil {
-		loadedScores, err := loadHighScoresFunc(g.highScorePath)
-		if err != nil {
-			log.Printf("Could not load high scores for level %d (%s): %v. Starting fresh.", g.Level, g.highScorePath, err)
+	// The injected loader function (which now returns []model.Score) ran with the level
+	if g.loadHighScores != nil {
+		if r.scoresErr != nil {
+			log.Printf("Could not load high scores for level %d (%s): %v. Starting fresh.", g.Level, g.highScorePath, r.scoresErr)
 			g.HighScores = []model.Score{} // <--- USE model.Score
 		} else {
-			g.HighScores = loadedScores // <--- Assign loaded []model.Score
+			g.HighScores = r.scores // <--- Assign loaded []model.Score
 			log.Printf("Loaded %d high scores for level %d", len(g.HighScores), g.Level)
 		}
 	} else {
@@ -110,79 +433,126 @@ func (g *Game) RequestLoadLevel(level int, configPath string, loadFunc func(stri
 		g.HighScores = []model.Score{} // <--- USE model.Score
 	}
 
-	g.lastUpdateTime = time.Now()
+	g.resetHighlight()
+	g.clearHistory()
+	g.paused, g.pendingSteps = false, 0
+	g.counters = runCounters{}
+	g.seedRun(loadedGameData.Seed)
+	g.startArcade()
+	g.resetMagnets()
+	g.resetPowerUps()
 	log.Printf("Level %d loaded successfully. Starting game.", g.Level)
+	g.stopSounds()
 	if g.audioManager != nil {
 		// g.audioManager.PlaySound("level_start")
 	}
-
-	return nil
 }
 
-// RequestLoadSavedGame triggers loading from a save file.
+// RequestLoadSavedGame starts loading a save file on a background
+// goroutine, like RequestLoadLevel, once the saves still being written are
+// done. PollLevelLoad swaps it in.
 func (g *Game) RequestLoadSavedGame(savePath string, loadFunc func(string) (*Game, error)) error {
 	g.mu.Lock()
 	defer g.mu.Unlock()
+	if err := g.checkNoLoad(savePath); err != nil {
+		return err
+	}
 
 	log.Printf("Requesting load saved game from %s", savePath)
-	loadedGameData, err := loadFunc(savePath)
-	if err != nil {
-		log.Printf("Error loading saved game %s: %v", savePath, err)
-		return fmt.Errorf("failed to load saved game '%s': %w", savePath, err)
-	}
+	load := &levelLoad{savePath: savePath, done: make(chan levelLoadResult, 1)}
+	g.levelLoad = load
+	g.startLoading()
+	g.loadProgress.Done, g.loadProgress.Total, g.loadProgress.Item = 0, 1, savePath
+	dataDir, mode, loadHighScores := g.dataDir, g.mode, g.loadHighScores
+	level, board := g.Level, g.Board
+	go func() {
+		g.WaitForSaves() // Or a save still being written could be read half done
+		var r levelLoadResult
+		if r.data, r.err = loadFunc(savePath); r.err == nil && loadHighScores != nil {
+			if r.data.Level != level {
+				board = "" // See swapInSave
+			}
+			r.scores, r.scoresErr = loadHighScores(HighScorePath(dataDir, mode, r.data.Level, board))
+		}
+		load.done <- r
+	}()
+	return nil
+}
 
-	// Transfer loaded data
+// swapInSave resumes the run a levelLoad read from savePath.
+// Assumes the write lock is held.
+func (g *Game) swapInSave(r levelLoadResult, savePath string) {
+	loadedGameData := r.data
+
+	// Transfer loaded data. Saves don't store the bounce budget, the time
+	// limit, the lives, the theme or the Hall of Fame, but they're
+	// only loaded into the level they were saved from, which still has them.
+	// Lives lost aren't saved either, the run's statistics start over.
+	if loadedGameData.Level != g.Level {
+		g.MaxBounces = 0
+		g.TimeLimit = 0
+		g.Lives = 0
+		g.Theme = LevelTheme{}
+		g.HallOfFame = model.HallOfFameRules{}
+		g.Board = ""
+	}
+	g.failed = false
+	g.entry = model.Score{}
 	g.Level = loadedGameData.Level
 	g.Pacmans = loadedGameData.Pacmans
 	g.TotalBounces = loadedGameData.TotalBounces
-	g.CurrentState = StatePlaying
+	g.enterState(StatePlaying)
+	g.stopSounds()
 	// Determine paths based on loaded level
 	g.levelConfigPath = fmt.Sprintf("assets/levels/level_%d.txt", g.Level) // Assume standard naming
-	g.highScorePath = fmt.Sprintf("assets/highscores/highscores_%d.gob", g.Level)
+	g.highScorePath = g.levelHighScorePath()
 	g.saveGamePath = savePath // Keep the path we loaded from
 	g.playerNameInput = []rune{}
 	g.isNewHighScore = false
 
-	// Call the injected loader function (which now returns []model.Score)
-	if loadHighScoresFunc != nil {
-		loadedScores, err := loadHighScoresFunc(g.highScorePath)
-		if err != nil {
-			log.Printf("Could not load high scores for loaded level %d (%s): %v. Starting fresh.", g.Level, g.highScorePath, err)
+	// The injected loader function (which now returns []model.Score) ran with the save
+	if g.loadHighScores != nil {
+		if r.scoresErr != nil {
+			log.Printf("Could not load high scores for loaded level %d (%s): %v. Starting fresh.", g.Level, g.highScorePath, r.scoresErr)
 			g.HighScores = []model.Score{} // <--- USE model.Score
 		} else {
-			g.HighScores = loadedScores // <--- Assign loaded []model.Score
+			g.HighScores = r.scores // <--- Assign loaded []model.Score
 		}
 	} else {
 		log.Printf("Warning: High score loading function not set.")
 		g.HighScores = []model.Score{} // <--- USE model.Score
 	}
 
-	g.lastUpdateTime = time.Now()
+	g.resetHighlight()
+	g.clearHistory()
+	g.counters = runCounters{}
+	g.seedRun(loadedGameData.Seed)
+	g.startArcade()
+	g.resetMagnets()
+	g.resetPowerUps()
 	log.Printf("Saved game loaded successfully. Resuming level %d.", g.Level)
-	return nil
 }
 
-// RequestSaveGame triggers saving the current game state.
-func (g *Game) RequestSaveGame(saveFunc func(*Game, string) error) error {
-	g.mu.RLock() // Use Read Lock initially to check state
-	if g.CurrentState != StatePlaying || g.Level < 0 {
+// RequestSaveGame takes a snapshot of the current game state and hands it
+// to writeFunc on a background goroutine, so the game goes on while the
+// file is written. Saves are written one at a time, in order; see
+// PollSaveResults for how they went.
+func (g *Game) RequestSaveGame(writeFunc func(path string, level, totalBounces int, seed uint64, pacmans []PacmanSaveData) error) error {
+	g.mu.RLock() // Read lock is enough to take the snapshot
+	if (g.CurrentState != StatePlaying && g.CurrentState != StatePaused) || g.Level < 0 {
 		g.mu.RUnlock()
 		log.Println("Cannot save game: Not currently playing a level.")
 		return fmt.Errorf("cannot save game: not playing")
 	}
-	currentSavePath := g.saveGamePath // Get path while read-locked
-	g.mu.RUnlock()                    // Release read lock before calling save function
+	currentSavePath := g.saveGamePath
+	level, totalBounces, pacmans := g.dataForSave()
+	seed := g.Seed
+	g.mu.RUnlock()
 
 	log.Printf("Requesting save game to %s", currentSavePath)
-	// The saveFunc will need to acquire necessary locks (Read lock on Game, locks on Pacmans)
-	// Pass 'g' itself so saveFunc can access data via public methods or direct fields (if within same package)
-	err := saveFunc(g, currentSavePath)
-	if err != nil {
-		log.Printf("Error saving game state to %s: %v", currentSavePath, err)
-		return fmt.Errorf("failed to save game: %w", err)
-	}
-
-	log.Printf("Game state saved successfully to %s", currentSavePath)
+	g.saves.add(func() SaveResult {
+		return SaveResult{Path: currentSavePath, Err: writeFunc(currentSavePath, level, totalBounces, seed, pacmans)}
+	})
 	return nil
 }
 
@@ -192,9 +562,15 @@ func (g *Game) Update() {
 	g.mu.Lock() // Lock for writing state
 	defer g.mu.Unlock()
 
-	now := time.Now()
+	now := g.clock.Now()
 	g.deltaTime = now.Sub(g.lastUpdateTime).Seconds()
 	g.lastUpdateTime = now
+	if now.Before(g.slowMotionUntil) {
+		g.deltaTime *= g.timeScale
+	}
+	if g.handicap.Slowed() {
+		g.deltaTime *= g.handicap.Slowdown
+	}
 
 	// Only update game elements if playing
 	if g.CurrentState != StatePlaying {
@@ -206,82 +582,136 @@ func (g *Game) Update() {
 		return // Should not happen if state transitions are correct
 	}
 
+	if g.paused && !g.pausedStep() {
+		return
+	}
+	if g.practice {
+		g.recordHistory()
+	}
+	g.simTime += g.deltaTime
+
 	allStopped := true
 	bouncesThisFrame := 0
+	g.respawnPacmans()
+	g.updatePowerUps()
 
 	// --- Pacman Movement & Edge Bouncing ---
-	for _, p := range g.Pacmans {
-		bounces := p.Update(g.deltaTime, g.ScreenWidth, g.ScreenHeight) // Update handles its own lock
+	dt := g.deltaTime
+	if g.powerUpActive(PowerUpFreeze) {
+		dt = 0
+	}
+	for i, p := range g.Pacmans {
+		bounces := p.Update(dt, g.ScreenWidth, g.ScreenHeight) // Update handles its own lock
 		bouncesThisFrame += bounces
-		_, _, _, _, stopped := p.GetData() // Safely get stopped status
+		posX, posY, _, _, stopped := p.GetData() // Safely get stopped status
 		if !stopped {
 			allStopped = false
 		}
-	}
-
-	// --- Pacman-to-Pacman Collision ---
-	numPacmans := len(g.Pacmans)
-	for i := 0; i < numPacmans; i++ {
-		p1 := g.Pacmans[i]
-		p1PosX, p1PosY, p1Radius, p1Stopped := p1.GetStateForCollisionCheck()
-		if p1Stopped {
-			continue
+		if bounces > 0 {
+			g.soundEvent(SoundBounce, posX, posY)
+			if len(g.hooks.bounce) > 0 {
+				x, y, normalX, normalY := p.WallContact()
+				for _, fn := range g.hooks.bounce {
+					fn(BounceEvent{Index: i, X: x, Y: y, NormalX: normalX, NormalY: normalY})
+				}
+			}
 		}
+	}
 
-		for j := i + 1; j < numPacmans; j++ {
-			p2 := g.Pacmans[j]
-			p2PosX, p2PosY, p2Radius, p2Stopped := p2.GetStateForCollisionCheck()
-			if p2Stopped {
-				continue
-			}
+	g.pullPacmans()
+	g.playWallCues()
+	g.updateHighlight(now)
 
-			// Check collision using the retrieved safe data
-			dx := p1PosX - p2PosX
-			dy := p1PosY - p2PosY
-			distSq := dx*dx + dy*dy
-			radiiSum := p1Radius + p2Radius
-
-			if distSq > 0 && distSq < radiiSum*radiiSum { // distSq > 0 avoids collision with self if logic flawed
-				// Collision detected! Bounce both Pacmans.
-				// The Bounce method handles internal state update & bounce count.
-				bounced1 := p1.Bounce()
-				bounced2 := p2.Bounce()
-				if bounced1 {
-					bouncesThisFrame++
-				}
-				if bounced2 {
-					bouncesThisFrame++
-				}
-				if bounced1 || bounced2 {
-					// Play bounce sound maybe? Limit frequency?
-					if g.audioManager != nil {
-						// g.audioManager.PlaySound("pacman_bounce") // Add a bounce sound
-					}
-				}
-			}
+	// --- Pacman-to-Pacman Collision ---
+	for i, p1 := range g.Pacmans {
+		for _, p2 := range g.Pacmans[i+1:] {
+			bouncesThisFrame += collide(p1, p2) // Takes both Pacmans' locks
 		}
 	}
 
 	g.TotalBounces += bouncesThisFrame
 
-	// Check for game over condition
+	if g.scorer().Failed(g.totals()) {
+		g.entry = model.Score{}.Stamp(g.clock.Now())
+		g.setState(StateGameOver)
+		g.failed = true
+		log.Printf("Run failed with %d bounces", g.TotalBounces)
+		return
+	}
+
+	// Check for game over condition, arcade runs go on until time's up
+	if g.mode == ModeArcade {
+		allStopped = g.simTime >= ArcadeDuration.Seconds()
+	}
 	if allStopped {
-		g.CurrentState = StateGameOver
+		g.entry = model.Score{}.Stamp(g.clock.Now())
+		g.setState(StateGameOver)
 		log.Printf("Game Over! Final Bounces: %d", g.TotalBounces)
-		if g.audioManager != nil {
-			// g.audioManager.PlaySound("level_up") // Or a specific game over sound
-		}
 		// Check if score qualifies for Hall of Fame
-		_, g.isNewHighScore = model.AddScore(g.HighScores, model.Score{Score: g.TotalBounces}) // Check without adding yet
+		_, g.isNewHighScore = g.hallOfFameRules().Add(g.HighScores, g.score(), g.clock.Now()) // Check without adding yet
+		g.isNewHighScore = g.isNewHighScore && !g.practice
 		if g.isNewHighScore {
 			log.Println("New High Score achieved!")
-			g.CurrentState = StateEnteringHighScore // Transition to name entry state
-			g.playerNameInput = []rune{}            // Clear input buffer
+			g.setState(StateEnteringHighScore) // Transition to name entry state
 		}
 	}
 }
 
-// HandleClick checks if any Pacman was clicked at (x, y) and stops it.
+// scalePacmans applies the sprite scale to a freshly loaded level, moving
+// Pacmans that would now stick out of the screen back inside.
+// Assumes the write lock is held.
+func (g *Game) scalePacmans() {
+	if g.spriteScale == 1 {
+		return
+	}
+	for _, p := range g.Pacmans {
+		p.mu.Lock()
+		p.Radius *= g.spriteScale
+		p.PosX = max(p.Radius, min(p.PosX, g.ScreenWidth-p.Radius))
+		p.PosY = max(p.Radius, min(p.PosY, g.ScreenHeight-p.Radius))
+		p.mu.Unlock()
+	}
+}
+
+// playWallCues plays the audio cue of every Pacman about to hit a wall,
+// panned to where it is, and reports it to the sound hooks.
+// Assumes the write lock is held.
+func (g *Game) playWallCues() {
+	player, ok := g.audioManager.(SpatialSoundPlayer)
+	audible := g.audioCues && ok
+	if !audible && len(g.hooks.sound) == 0 {
+		return
+	}
+	// The lead is real time, Pacmans cover less ground in it during slow motion
+	lead := wallCueLead
+	if g.clock.Now().Before(g.slowMotionUntil) {
+		lead *= g.timeScale
+	}
+	if g.handicap.Slowed() {
+		lead *= g.handicap.Slowdown
+	}
+	for _, p := range g.Pacmans {
+		posX, posY, wall, warn := p.WallWarning(lead, g.ScreenWidth, g.ScreenHeight)
+		if !warn {
+			continue
+		}
+		sound := SoundCueWall
+		switch wall {
+		case 'T':
+			sound = SoundCueWallTop
+		case 'B':
+			sound = SoundCueWallBottom
+		}
+		if audible {
+			g.trackSound(player.PlaySoundAt(sound, 2*posX/g.ScreenWidth-1))
+		}
+		g.soundEvent(sound, posX, posY)
+	}
+}
+
+// HandleClick passes a click at (x, y) to the topmost Clickable it lands
+// on, see clickTargets: a Pacman clicked is stopped. A click landing on
+// nothing costs a life, see Lives.
 // Acquires necessary locks.
 func (g *Game) HandleClick(x, y float64) {
 	g.mu.Lock() // Need write lock to potentially modify Pacman state
@@ -291,16 +721,97 @@ func (g *Game) HandleClick(x, y float64) {
 		return // Ignore clicks if not playing
 	}
 
+	for _, target := range g.clickTargets() {
+		if target.Contains(x, y) {
+			target.Click()
+			return // Only the topmost target gets the click
+		}
+	}
+	g.loseLife()
+}
+
+// HoveredPacman returns the Pacman a click at x, y would hit, without
+// clicking it, so frontends can show what the player is aiming at.
+func (g *Game) HoveredPacman(x, y float64) (PacmanDrawData, bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if g.CurrentState != StatePlaying {
+		return PacmanDrawData{}, false
+	}
+	for _, p := range slices.Backward(g.Pacmans) { // Topmost first, like HandleClick
+		if p.hit(x, y, g.hitboxScale()) {
+			var data PacmanDrawData
+			data.PosX, data.PosY, data.Radius, data.AnimFrame, data.IsStopped = p.GetData()
+			data.VelX, data.VelY = p.Velocity()
+			data.Stunned = p.Stunned()
+			return data, true
+		}
+	}
+	return PacmanDrawData{}, false
+}
+
+// catch stops a Pacman, with its sound. Assumes the write lock is held.
+func (g *Game) catch(p *Pacman) {
+	wasRunning := p.Stop() // Stop method handles its own mutex and state change
+	if !wasRunning {
+		return
+	}
+	chain := g.countCatch(p)
+	g.scheduleRespawn(p)
+	g.playSound(SoundCatch) // Play sound on successful stop
+	posX, posY, _, _, _ := p.GetData()
+	g.soundEvent(SoundCatch, posX, posY)
+	if len(g.hooks.catch) > 0 {
+		e := CatchEvent{ID: p.ID, Chain: chain}
+		e.Pacman.PosX, e.Pacman.PosY, e.Pacman.Radius, e.Pacman.AnimFrame, e.Pacman.IsStopped = p.GetData()
+		e.Pacman.Tint = g.tint(p)
+		for _, fn := range g.hooks.catch {
+			fn(e)
+		}
+	}
+}
+
+// SpawnPacman adds an extra running Pacman, heading along dirX, dirY, to the
+// level being played. Returns false if no level is being played.
+func (g *Game) SpawnPacman(radius, posX, posY, dirX, dirY float64, waitTimeMs int) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.CurrentState != StatePlaying {
+		return false
+	}
+	g.spawnPacman(radius, posX, posY, dirX, dirY, waitTimeMs)
+	return true
+}
+
+// spawnPacman is SpawnPacman for callers holding the write lock.
+func (g *Game) spawnPacman(radius, posX, posY, dirX, dirY float64, waitTimeMs int) {
+	nextID := 0
 	for _, p := range g.Pacmans {
-		// IsClicked is safe, checks bounds and if already stopped
-		if p.IsClicked(x, y) {
-			wasRunning := p.Stop() // Stop method handles its own mutex and state change
-			if wasRunning && g.audioManager != nil {
-				g.audioManager.PlaySound("pacman_death") // Play sound on successful stop
-			}
-			break // Assume only one Pacman can be clicked at a time
+		if p.ID >= nextID {
+			nextID = p.ID + 1
 		}
 	}
+	g.Pacmans = append(g.Pacmans, NewPacman(nextID, radius, posX, posY, dirX, dirY, waitTimeMs, 0, false))
+}
+
+// ApplySlowMotion runs Pacman movement at factor times normal speed for the
+// given duration. A new slow motion replaces the running one.
+func (g *Game) ApplySlowMotion(factor float64, duration time.Duration) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.timeScale = factor
+	g.slowMotionUntil = g.clock.Now().Add(duration)
+	g.slowPowerUp = false
+}
+
+// SlowMotion reports whether slow motion is on.
+func (g *Game) SlowMotion() bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.clock.Now().Before(g.slowMotionUntil)
 }
 
 // HandleTextInput processes character input during the high score entry state.
@@ -322,7 +833,7 @@ func (g *Game) HandleBackspace() {
 	g.mu.Lock()
 	defer g.mu.Unlock()
 
-	if g.CurrentState != StateEnteringHighScore && len(g.playerNameInput) > 0 {
+	if g.CurrentState == StateEnteringHighScore && len(g.playerNameInput) > 0 {
 		g.playerNameInput = g.playerNameInput[:len(g.playerNameInput)-1]
 	}
 }
@@ -341,10 +852,12 @@ func (g *Game) HandleEnter(saveFunc func([]model.Score, string) error) {
 		playerName = "Anonymous" // Default name
 	}
 
-	log.Printf("Adding high score: %s - %d", playerName, g.TotalBounces)
+	score := g.score()
+	score.Name = playerName
+	log.Printf("Adding high score: %s - %d", playerName, score.Score)
 
 	var added bool
-	g.HighScores, added = model.AddScore(g.HighScores, model.Score{Name: playerName, Score: g.TotalBounces})
+	g.HighScores, added = g.hallOfFameRules().Add(g.HighScores, score, g.clock.Now())
 
 	if added {
 		log.Println("Score added to Hall of Fame. Saving...")
@@ -359,33 +872,67 @@ func (g *Game) HandleEnter(saveFunc func([]model.Score, string) error) {
 		log.Println("Score was not added (likely pushed out by better scores).")
 	}
 
-	g.CurrentState = StateHallOfFame // Transition to showing the hall of fame
-	g.playerNameInput = []rune{}     // Clear input
+	g.setState(StateHallOfFame) // Transition to showing the hall of fame
 }
 
 // --- Data Accessor Methods (Thread-Safe) ---
 
-// GetPacmanData provides data needed for drawing all Pacmans.
-func (g *Game) GetPacmanData() []struct {
+// PacmanDrawData is a snapshot of the state a frontend needs to draw one Pacman.
+type PacmanDrawData struct {
 	PosX, PosY, Radius float64
 	AnimFrame          int
 	IsStopped          bool
-} {
+	Stunned            bool       // Waiting to be grabbed, see SetTwoStageCatch
+	VelX, VelY         float64    // Pixels per second
+	ScaleX, ScaleY     float64    // Draw-only stretch set by effects, 0 for none
+	Tint               color.RGBA // From the level's theme, zero for none
+}
+
+// Scale returns the stretch to draw the Pacman with, 1 on both axes unless
+// an effect set ScaleX and ScaleY.
+func (d PacmanDrawData) Scale() (x, y float64) {
+	if d.ScaleX == 0 || d.ScaleY == 0 {
+		return 1, 1
+	}
+	return d.ScaleX, d.ScaleY
+}
+
+// GetPacmanData provides data needed for drawing all Pacmans.
+func (g *Game) GetPacmanData() []PacmanDrawData {
 	g.mu.RLock() // Read lock is sufficient
 	defer g.mu.RUnlock()
 
-	data := make([]struct {
-		PosX, PosY, Radius float64
-		AnimFrame          int
-		IsStopped          bool
-	}, len(g.Pacmans))
+	data := make([]PacmanDrawData, len(g.Pacmans))
 
 	for i, p := range g.Pacmans {
 		data[i].PosX, data[i].PosY, data[i].Radius, data[i].AnimFrame, data[i].IsStopped = p.GetData()
+		data[i].VelX, data[i].VelY = p.Velocity()
+		data[i].Stunned = p.Stunned()
+		data[i].Tint = g.tint(p)
 	}
 	return data
 }
 
+// LayoutHash returns a short hash of the level number and every Pacman's
+// position, size, direction and speed, identifying a layout in bug reports.
+func (g *Game) LayoutHash() string {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	h := sha256.New()
+	fmt.Fprintf(h, "level %d\n", g.Level)
+	for _, p := range g.Pacmans {
+		diameter, posX, posY, dirX, dirY, _, waitTimeMs, bounces, isStopped := p.GetDataForSave()
+		direction, subDirection := HeadingAxis(dirX, dirY)
+		fmt.Fprintf(h, "%g %g %g %d %d %d %c %t", diameter, posX, posY, waitTimeMs, subDirection, bounces, direction, isStopped)
+		if dirX != 0 && dirY != 0 {
+			fmt.Fprintf(h, " %g", HeadingAngle(dirX, dirY)) // Layouts from before angles hash as they did
+		}
+		fmt.Fprintln(h)
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
 // GetGameState provides the current game state and score.
 func (g *Game) GetGameState() (state GameState, bounces int, level int) {
 	g.mu.RLock()
@@ -403,37 +950,52 @@ func (g *Game) GetHighScoreData() (state GameState, scores []model.Score, curren
 	return g.CurrentState, scoresCopy, string(g.playerNameInput)
 }
 
-// Need to define these somewhere accessible, perhaps passed into NewGame or globally (less ideal)
-var loadHighScoresFunc func(filepath string) ([]model.Score, error) = nil // Placeholder
-//var saveHighScoresFunc func(scores []Score, filepath string) error = nil // Placeholder - passed into HandleEnter
+// SetHighScores replaces the high scores of the given level, e.g. with ones fetched
+// from a score server. Ignored if another level has been loaded in the meantime.
+func (g *Game) SetHighScores(level int, scores []model.Score) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.Level != level || g.Board != "" {
+		return // The server doesn't keep the boards of levels of their own
+	}
+	g.HighScores = scores
+}
 
-// SetPersistenceFunctions allows injecting the actual persistence functions
-// This avoids import cycles if persistence needs game types.
-func SetPersistenceFunctions(loader func(string) ([]model.Score, error)) { // saver func( []Score, string) error) {
-	loadHighScoresFunc = loader
-	// saveHighScoresFunc = saver // Pass saver to HandleEnter
+// SetHighScoreLoader makes the game read a level's high scores with fn
+// when the level starts. Without one, every level starts with none.
+func (g *Game) SetHighScoreLoader(fn func(path string) ([]model.Score, error)) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.loadHighScores = fn
 }
 
 // GetDataForSave provides necessary game state for saving.
 func (g *Game) GetDataForSave() (level int, totalBounces int, pacmans []PacmanSaveData) {
 	g.mu.RLock()
 	defer g.mu.RUnlock()
+	return g.dataForSave()
+}
 
+// dataForSave copies the game state kept in save files.
+// Assumes the read lock is held.
+func (g *Game) dataForSave() (level int, totalBounces int, pacmans []PacmanSaveData) {
 	level = g.Level
 	totalBounces = g.TotalBounces
 	pacmans = make([]PacmanSaveData, len(g.Pacmans))
 	for i, p := range g.Pacmans {
 		// Call the Pacman's safe data retrieval method
-		diameter, posX, posY, waitTimeMs, subDirection, bounces, direction, isStopped := p.GetDataForSave()
+		diameter, posX, posY, dirX, dirY, speed, waitTimeMs, bounces, isStopped := p.GetDataForSave()
 		pacmans[i] = PacmanSaveData{
-			Diameter:     diameter, // Store diameter as per original format
-			PosX:         posX,
-			PosY:         posY,
-			WaitTimeMs:   waitTimeMs,
-			Direction:    direction,
-			SubDirection: subDirection,
-			Bounces:      bounces,
-			IsStopped:    isStopped,
+			Diameter:   diameter, // Store diameter as per original format
+			PosX:       posX,
+			PosY:       posY,
+			DirX:       dirX,
+			DirY:       dirY,
+			Speed:      speed,
+			WaitTimeMs: waitTimeMs,
+			Bounces:    bounces,
+			IsStopped:  isStopped,
 		}
 	}
 	return level, totalBounces, pacmans
@@ -441,12 +1003,12 @@ func (g *Game) GetDataForSave() (level int, totalBounces int, pacmans []PacmanSa
 
 // PacmanSaveData is a helper struct to hold data for saving a single Pacman.
 type PacmanSaveData struct {
-	Diameter     float64
-	PosX         float64
-	PosY         float64
-	WaitTimeMs   int
-	Direction    rune
-	SubDirection int // Added this, seems necessary to restore state
-	Bounces      int
-	IsStopped    bool
+	Diameter   float64
+	PosX       float64
+	PosY       float64
+	DirX, DirY float64 // Heading, see Pacman
+	Speed      float64 // Changed from what WaitTimeMs makes it by collisions and stuns
+	WaitTimeMs int
+	Bounces    int
+	IsStopped  bool
 }
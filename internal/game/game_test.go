@@ -0,0 +1,297 @@
+package game
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/model"
+)
+
+// Play area of the tests, large enough that Pacmans placed in the middle
+// don't reach a wall in the ticks a test steps through.
+const (
+	testWidth  = 400
+	testHeight = 300
+)
+
+// testSpeed is the speed of a Pacman of testWaitMs, in pixels per second:
+// one pixel per SimTick.
+const (
+	testWaitMs = 99
+	testSpeed  = baseSpeed
+)
+
+// newTestSim starts level right away on a simulated game of the test play
+// area.
+func newTestSim(level *Game) *Simulator {
+	sim := NewSimulator(NewGame(testWidth, testHeight, nil))
+	sim.Load(level, "test")
+	return sim
+}
+
+// approxEqual reports whether a and b are equal but for rounding.
+func approxEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestWallReflection(t *testing.T) {
+	tests := []struct {
+		name       string
+		x, y       float64
+		dirX, dirY float64
+		wantX      float64 // Position once snapped back in the play area
+		wantY      float64
+		wantDirX   float64 // Heading after the bounce
+		wantDirY   float64
+	}{
+		{"left", 10.5, 150, -1, 0, 10, 150, 1, 0},
+		{"right", testWidth - 10.5, 150, 1, 0, testWidth - 10, 150, -1, 0},
+		{"top", 200, 10.5, 0, -1, 200, 10, 0, 1},
+		{"bottom", 200, testHeight - 10.5, 0, 1, 200, testHeight - 10, 0, -1},
+		{"corner", testWidth - 10.5, testHeight - 10.5, 1, 1, testWidth - 10, testHeight - 10, -math.Sqrt2 / 2, -math.Sqrt2 / 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewPacman(0, 10, tt.x, tt.y, tt.dirX, tt.dirY, testWaitMs, 0, false)
+			sim := newTestSim(&Game{Level: 0, Pacmans: []*Pacman{p}})
+			sim.Step()
+
+			if !approxEqual(p.PosX, tt.wantX) || !approxEqual(p.PosY, tt.wantY) {
+				t.Errorf("position = (%g, %g), want (%g, %g)", p.PosX, p.PosY, tt.wantX, tt.wantY)
+			}
+			if !approxEqual(p.DirX, tt.wantDirX) || !approxEqual(p.DirY, tt.wantDirY) {
+				t.Errorf("heading = (%g, %g), want (%g, %g)", p.DirX, p.DirY, tt.wantDirX, tt.wantDirY)
+			}
+			if p.Speed != testSpeed {
+				t.Errorf("speed = %g, want %g", p.Speed, float64(testSpeed))
+			}
+			// A corner flips both components but is a single bounce
+			if p.Bounces != 1 || sim.Game.Stats().Bounces != 1 {
+				t.Errorf("bounces = %d, game's %d, want 1", p.Bounces, sim.Game.Stats().Bounces)
+			}
+		})
+	}
+}
+
+func TestWallReflectionAwayFromWall(t *testing.T) {
+	// Still touching the wall it just bounced off, heading away from it
+	p := NewPacman(0, 10, 9, 150, 1, 0, testWaitMs, 0, false)
+	sim := newTestSim(&Game{Level: 0, Pacmans: []*Pacman{p}})
+	sim.Step()
+
+	if p.DirX != 1 || p.Bounces != 0 {
+		t.Errorf("heading x = %g with %d bounces, want 1 with none", p.DirX, p.Bounces)
+	}
+}
+
+func TestCollisionImpulse(t *testing.T) {
+	tests := []struct {
+		name             string
+		radiusA, radiusB float64
+		stunB            bool
+		wantBounces      int
+	}{
+		{"equal", 10, 10, false, 2},
+		{"heavier", 20, 10, false, 2},
+		{"lighter", 10, 20, false, 2},
+		{"stunned", 10, 10, true, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Head-on, already overlapping by 2 pixels, in the middle of the play area
+			gap := tt.radiusA + tt.radiusB - 2
+			a := NewPacman(0, tt.radiusA, 200-gap/2, 150, 1, 0, testWaitMs, 0, false)
+			b := NewPacman(1, tt.radiusB, 200+gap/2, 150, -1, 0, testWaitMs, 0, false)
+			if tt.stunB {
+				b.stunned = 1
+			}
+			sim := newTestSim(&Game{Level: 0, Pacmans: []*Pacman{a, b}})
+
+			// Velocities just before the collision; Update moved them this tick
+			avx, _ := a.Velocity()
+			bvx, _ := b.Velocity()
+			sim.Step()
+			avx2, avy2 := a.Velocity()
+			bvx2, bvy2 := b.Velocity()
+
+			if got := sim.Game.Stats().Bounces; got != tt.wantBounces {
+				t.Errorf("bounces = %d, want %d", got, tt.wantBounces)
+			}
+			if avy2 != 0 || bvy2 != 0 {
+				t.Errorf("vertical velocities = %g, %g after a horizontal collision, want 0", avy2, bvy2)
+			}
+			if dist := b.PosX - a.PosX; dist < tt.radiusA+tt.radiusB-1e-9 {
+				t.Errorf("centers %g apart, still overlapping", dist)
+			}
+
+			if tt.stunB {
+				// Held in place like a wall: a reflects, b stays put
+				if !approxEqual(avx2, -avx) || bvx2 != 0 {
+					t.Errorf("velocities = %g, %g, want %g, 0", avx2, bvx2, -avx)
+				}
+				return
+			}
+			// Radii stand for masses: momentum and kinetic energy are kept
+			ma, mb := tt.radiusA, tt.radiusB
+			if !approxEqual(ma*avx+mb*bvx, ma*avx2+mb*bvx2) {
+				t.Errorf("momentum %g before, %g after", ma*avx+mb*bvx, ma*avx2+mb*bvx2)
+			}
+			if !approxEqual(ma*avx*avx+mb*bvx*bvx, ma*avx2*avx2+mb*bvx2*bvx2) {
+				t.Errorf("energy %g before, %g after", ma*avx*avx+mb*bvx*bvx, ma*avx2*avx2+mb*bvx2*bvx2)
+			}
+			if avx2 >= bvx2 {
+				t.Errorf("velocities = %g, %g, still closing in", avx2, bvx2)
+			}
+		})
+	}
+}
+
+func TestCollisionMovingApart(t *testing.T) {
+	// Overlapping but heading away from each other: pushed apart, no bounce
+	a := NewPacman(0, 10, 195, 150, -1, 0, testWaitMs, 0, false)
+	b := NewPacman(1, 10, 205, 150, 1, 0, testWaitMs, 0, false)
+	sim := newTestSim(&Game{Level: 0, Pacmans: []*Pacman{a, b}})
+	sim.Step()
+
+	if got := sim.Game.Stats().Bounces; got != 0 {
+		t.Errorf("bounces = %d, want 0", got)
+	}
+	if a.DirX != -1 || b.DirX != 1 {
+		t.Errorf("headings = %g, %g, want -1, 1", a.DirX, b.DirX)
+	}
+}
+
+func TestAllStoppedEndsGame(t *testing.T) {
+	a := NewPacman(0, 10, 100, 150, 0, -1, testWaitMs, 0, false)
+	b := NewPacman(1, 10, 300, 150, 0, 1, testWaitMs, 0, false)
+	sim := newTestSim(&Game{Level: 0, Pacmans: []*Pacman{a, b}})
+	sim.Game.SetPractice(true) // Or the run would make the empty Hall of Fame
+	sim.Step()
+
+	x, y, _, _, _ := a.GetData()
+	sim.Game.HandleClick(x, y)
+	sim.Step()
+	if state, _, _ := sim.Game.GetGameState(); state != StatePlaying {
+		t.Fatalf("state = %v with a Pacman still running, want playing", state)
+	}
+
+	x, y, _, _, _ = b.GetData()
+	sim.Game.HandleClick(x, y)
+	sim.Step()
+	if state, _, _ := sim.Game.GetGameState(); state != StateGameOver {
+		t.Fatalf("state = %v with every Pacman caught, want game over", state)
+	}
+
+	// The run is over: the game no longer moves on
+	result := sim.Result()
+	sim.Step()
+	if got := sim.Result(); got.Bounces != result.Bounces || got.Elapsed != result.Elapsed+SimTick {
+		t.Errorf("result moved on after game over: %+v, was %+v", got, result)
+	}
+	if result.TimedOut || result.Failed {
+		t.Errorf("result = %+v, want a cleared run", result)
+	}
+}
+
+// narrowHeight is the height of a play area barely taller than a Pacman,
+// see bouncingLevel.
+const narrowHeight = 30
+
+// bouncingLevel is a level whose only Pacman bounces between the top and
+// bottom walls of a play area narrowHeight tall, every 10 ticks from the
+// 5th. Caught by a ReactionPlayer of 30 ticks, its run scores 3.
+func bouncingLevel(rules model.HallOfFameRules) *Game {
+	p := NewPacman(0, 10, 200, narrowHeight/2, 0, 1, testWaitMs, 0, false)
+	return &Game{Level: 0, Pacmans: []*Pacman{p}, HallOfFame: rules}
+}
+
+// scores returns n entries of the given score.
+func scores(n, score int) []model.Score {
+	s := make([]model.Score, n)
+	for i := range s {
+		s[i] = model.Score{Name: "Rival", Score: score}.Stamp(time.Unix(int64(i), 0))
+	}
+	return s
+}
+
+func TestHallOfFameQualification(t *testing.T) {
+	tests := []struct {
+		name     string
+		rules    model.HallOfFameRules
+		existing []model.Score
+		practice bool
+		want     bool
+	}{
+		{"empty", model.HallOfFameRules{}, nil, false, true},
+		{"room left", model.HallOfFameRules{}, scores(model.MaxHighScores-1, 1), false, true},
+		{"beats the worst", model.HallOfFameRules{}, scores(model.MaxHighScores, 4), false, true},
+		{"ties the worst", model.HallOfFameRules{}, scores(model.MaxHighScores, 3), false, false},
+		{"worse than all", model.HallOfFameRules{}, scores(model.MaxHighScores, 1), false, false},
+		{"larger board", model.HallOfFameRules{Size: 20}, scores(model.MaxHighScores, 1), false, true},
+		{"smaller board full", model.HallOfFameRules{Size: 3}, scores(3, 2), false, false},
+		{"under threshold", model.HallOfFameRules{Threshold: 3}, nil, false, true},
+		{"over threshold", model.HallOfFameRules{Threshold: 2}, nil, false, false},
+		{"practice", model.HallOfFameRules{}, nil, true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sim := NewSimulator(NewGame(testWidth, narrowHeight, nil))
+			sim.Game.SetPractice(tt.practice)
+			sim.Load(bouncingLevel(tt.rules), "test")
+			sim.Game.SetHighScores(0, tt.existing)
+
+			result := sim.Run(ReactionPlayer(30*SimTick), time.Minute)
+			if result.Bounces != 3 || result.Score.Score != 3 {
+				t.Fatalf("run scored %d with %d bounces, want 3", result.Score.Score, result.Bounces)
+			}
+			wantState := StateGameOver
+			if tt.want {
+				wantState = StateEnteringHighScore
+			}
+			if state, _, _ := sim.Game.GetGameState(); state != wantState {
+				t.Errorf("state = %v, want %v", state, wantState)
+			}
+		})
+	}
+}
+
+func TestHallOfFameEntryStampedWithClock(t *testing.T) {
+	sim := NewSimulator(NewGame(testWidth, narrowHeight, nil))
+	sim.Load(bouncingLevel(model.HallOfFameRules{}), "test")
+	sim.Run(ReactionPlayer(30*SimTick), time.Minute)
+	end := sim.clock.Now()
+
+	sim.Game.HandleEnter(func([]model.Score, string) error { return nil })
+	_, highScores, _ := sim.Game.GetHighScoreData()
+	if len(highScores) != 1 {
+		t.Fatalf("Hall of Fame has %d entries, want 1", len(highScores))
+	}
+	if got := highScores[0].SetAt; got != end.UnixMilli() {
+		t.Errorf("entry set at %d, want the simulated end of the run %d", got, end.UnixMilli())
+	}
+}
+
+func TestChainsScoredPerBoard(t *testing.T) {
+	for _, chains := range []bool{false, true} {
+		a := NewPacman(0, 10, 100, 150, 0, -1, testWaitMs, 0, false)
+		b := NewPacman(1, 10, 300, 150, 0, 1, testWaitMs, 0, false)
+		c := NewPacman(2, 10, 200, 100, 1, 0, testWaitMs, 0, false)
+		sim := newTestSim(&Game{Level: 0, Pacmans: []*Pacman{a, b, c}, HallOfFame: model.HallOfFameRules{Chains: chains}})
+		sim.Game.SetPractice(true)
+
+		// Two catches a tick apart make a chain of 2
+		for _, p := range []*Pacman{a, b} {
+			x, y, _, _, _ := p.GetData()
+			sim.Game.HandleClick(x, y)
+			sim.Step()
+		}
+		want := 0
+		if chains {
+			want = 2
+		}
+		if got := sim.Game.Stats().BestChain; got != want {
+			t.Errorf("chains %v: best chain = %d, want %d", chains, got, want)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package game
+
+// Hooks let the frontend layers (effects, sound indicators, stats,
+// achievements) react to gameplay without the game importing them. Any
+// number of functions can be registered for each event. They're called
+// from Update, HandleClick and the state changes with the game locked, so
+// they must not call back into the game.
+type hooks struct {
+	sound       []func(SoundEvent)
+	catch       []func(CatchEvent)
+	bounce      []func(BounceEvent)
+	stateChange []func(old, new GameState)
+}
+
+// CatchEvent is a Pacman being caught.
+type CatchEvent struct {
+	ID     int            // Of the Pacman, see Pacman.ID
+	Pacman PacmanDrawData // As it was drawn last, e.g. for effects
+	Chain  int            // Multiplier of the chain the catch carried on, 1 for a new one, 0 without chains
+}
+
+// BounceEvent is a Pacman bouncing off a wall.
+type BounceEvent struct {
+	Index            int     // Of the Pacman, in GetPacmanData's order
+	X, Y             float64 // Where it touched the wall
+	NormalX, NormalY float64 // Unit normal of the wall, pointing into the play area
+}
+
+// OnSound registers fn to be told about every sound event, including the
+// wall cues and wall bounces whether or not they're audible.
+func (g *Game) OnSound(fn func(SoundEvent)) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.hooks.sound = append(g.hooks.sound, fn)
+}
+
+// OnCatch registers fn to be told about every catch.
+func (g *Game) OnCatch(fn func(CatchEvent)) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.hooks.catch = append(g.hooks.catch, fn)
+}
+
+// OnBounce registers fn to be told about every wall bounce.
+func (g *Game) OnBounce(fn func(BounceEvent)) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.hooks.bounce = append(g.hooks.bounce, fn)
+}
+
+// OnStateChange registers fn to be told whenever the game goes from one
+// GameState to another.
+func (g *Game) OnStateChange(fn func(old, new GameState)) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.hooks.stateChange = append(g.hooks.stateChange, fn)
+}
+
+// soundEvent reports a sound to the sound hooks.
+// Assumes the write lock is held.
+func (g *Game) soundEvent(name string, x, y float64) {
+	for _, fn := range g.hooks.sound {
+		fn(SoundEvent{Name: name, X: x, Y: y})
+	}
+}
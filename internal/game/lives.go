@@ -0,0 +1,62 @@
+package game
+
+import (
+	"log"
+
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/model"
+)
+
+// SetLives gives every level n lives instead of its own, see Game.Lives:
+// each click that catches nothing costs one, and running out ends the run
+// as failed. 0 goes back to the levels' own. Takes effect from the next
+// click.
+func (g *Game) SetLives(n int) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.livesOverride = max(n, 0)
+}
+
+// LivesLeft returns how many more clicks that catch nothing the run can
+// afford, with limited false if misses cost nothing on the level.
+func (g *Game) LivesLeft() (left int, limited bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	lives := g.lives()
+	return max(lives-g.counters.misses, 0), lives > 0
+}
+
+// OutOfLives reports whether the run failed by running out of lives.
+func (g *Game) OutOfLives() bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.failed && g.outOfLives()
+}
+
+// lives returns the lives of the level being played, 0 for no limit.
+// Assumes the lock is held.
+func (g *Game) lives() int {
+	if g.livesOverride > 0 {
+		return g.livesOverride
+	}
+	return g.Lives
+}
+
+// outOfLives reports whether the run missed as many times as it had
+// lives. Assumes the lock is held.
+func (g *Game) outOfLives() bool {
+	lives := g.lives()
+	return lives > 0 && g.counters.misses >= lives
+}
+
+// loseLife takes the life of a click that caught nothing, failing the run
+// right away on the last one. Assumes the write lock is held.
+func (g *Game) loseLife() {
+	g.countMiss()
+	if !g.outOfLives() {
+		return
+	}
+	g.entry = model.Score{}.Stamp(g.clock.Now())
+	g.setState(StateGameOver)
+	g.failed = true
+	log.Printf("Run failed out of lives with %d bounces", g.TotalBounces)
+}
@@ -0,0 +1,87 @@
+package game
+
+import (
+	"log"
+	"slices"
+)
+
+// LoadProgress is how far the loading shown in StateLoading got.
+type LoadProgress struct {
+	Done, Total int
+	Item        string   // Asset or level done last
+	Failed      []string // Items that failed to load; the game goes on without them
+}
+
+// StartLoading shows StateLoading until FinishLoading, e.g. while the
+// assets load at startup. Levels started meanwhile wait behind it.
+func (g *Game) StartLoading() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.startLoading()
+}
+
+// startLoading enters StateLoading, for one more loading.
+// Assumes the write lock is held.
+func (g *Game) startLoading() {
+	if g.loaders == 0 {
+		g.afterLoading = g.CurrentState
+		g.setState(StateLoading)
+		g.loadProgress = LoadProgress{}
+	}
+	g.loaders++
+}
+
+// ReportLoadProgress records done of total items loaded, the last one
+// being item, which failed to load with failed.
+func (g *Game) ReportLoadProgress(done, total int, item string, failed bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.loadProgress.Done, g.loadProgress.Total, g.loadProgress.Item = done, total, item
+	if failed {
+		g.loadProgress.Failed = append(g.loadProgress.Failed, item)
+	}
+}
+
+// LoadProgress returns how far the loading got.
+func (g *Game) LoadProgress() LoadProgress {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	p := g.loadProgress
+	p.Failed = slices.Clone(p.Failed)
+	return p
+}
+
+// FinishLoading ends the loading StartLoading began. Once nothing else is
+// loading, the game leaves StateLoading for the state it was started from,
+// or the level started meanwhile.
+func (g *Game) FinishLoading() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.finishLoading()
+}
+
+// finishLoading ends one loading. Assumes the write lock is held.
+func (g *Game) finishLoading() {
+	if g.loaders == 0 {
+		return
+	}
+	g.loaders--
+	if g.loaders == 0 {
+		g.setState(g.afterLoading)
+	}
+}
+
+// enterState moves the game to state, or once the loading is done while in
+// StateLoading. Like setState, it refuses illegal jumps.
+// Assumes the write lock is held.
+func (g *Game) enterState(state GameState) {
+	if g.CurrentState != StateLoading {
+		g.setState(state)
+		return
+	}
+	if !canTransition(g.afterLoading, state) {
+		log.Printf("Warning: refusing illegal game state change %d -> %d", g.afterLoading, state)
+		return
+	}
+	g.afterLoading = state
+}
@@ -0,0 +1,96 @@
+package game
+
+import (
+	"math"
+	"time"
+)
+
+// Magnet power-up, see UseMagnet.
+const (
+	MagnetCharges  = 1               // Magnets per level
+	MagnetDuration = 3 * time.Second // How long a magnet pulls
+	MagnetRange    = 150.0           // Pacmans further from the cursor than this aren't pulled
+	magnetPull     = 90.0            // Pixels per second right at the cursor, fading out to the range
+)
+
+// SetCursor tells the game where the mouse is, in logical screen
+// coordinates. Only a magnet uses it.
+func (g *Game) SetCursor(x, y float64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.cursorX, g.cursorY = x, y
+}
+
+// UseMagnet spends one of the level's magnets: for MagnetDuration, Pacmans
+// within MagnetRange of the cursor are pulled towards it, bunching them up
+// to catch. Returns false if none are left or no level is being played.
+func (g *Game) UseMagnet() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.CurrentState != StatePlaying || g.magnets <= 0 {
+		return false
+	}
+	g.magnets--
+	g.magnetFrom, g.magnetUntil = g.simTime, g.simTime+MagnetDuration.Seconds()
+	return true
+}
+
+// Magnets returns how many magnets are left for the level.
+func (g *Game) Magnets() int {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.magnets
+}
+
+// Magnet returns where a magnet pulls to and for how many more simulated
+// seconds, with active false if none is.
+func (g *Game) Magnet() (x, y, left float64, active bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	if !g.magnetActive() {
+		return 0, 0, 0, false
+	}
+	return g.cursorX, g.cursorY, g.magnetUntil - g.simTime, true
+}
+
+// magnetActive reports whether a magnet is pulling. It goes by simulated
+// time, so pauses and rewinds move it along. Assumes the lock is held.
+func (g *Game) magnetActive() bool {
+	return g.simTime >= g.magnetFrom && g.simTime < g.magnetUntil
+}
+
+// resetMagnets gives a freshly loaded level its magnets. Assumes the write lock is held.
+func (g *Game) resetMagnets() {
+	g.magnets = MagnetCharges
+	g.magnetFrom, g.magnetUntil = 0, 0
+}
+
+// pullPacmans moves the Pacmans in range towards the cursor while a magnet
+// is active. Assumes the write lock is held.
+func (g *Game) pullPacmans() {
+	if !g.magnetActive() {
+		return
+	}
+	for _, p := range g.Pacmans {
+		p.Attract(g.cursorX, g.cursorY, g.deltaTime, g.ScreenWidth, g.ScreenHeight)
+	}
+}
+
+// Attract pulls the Pacman dt seconds towards (x, y) on top of its own
+// movement, the harder the closer it is, keeping it on screen. Stopped and
+// stunned Pacmans and those out of MagnetRange stay put.
+func (p *Pacman) Attract(x, y, dt, screenWidth, screenHeight float64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.IsStopped || p.stunned > 0 {
+		return
+	}
+	dx, dy := x-p.PosX, y-p.PosY
+	dist := math.Hypot(dx, dy)
+	if dist == 0 || dist > MagnetRange {
+		return
+	}
+	step := min(dist, magnetPull*(1-dist/MagnetRange)*dt)
+	p.PosX = max(p.Radius, min(p.PosX+dx/dist*step, screenWidth-p.Radius))
+	p.PosY = max(p.Radius, min(p.PosY+dy/dist*step, screenHeight-p.Radius))
+}
@@ -0,0 +1,139 @@
+package game
+
+import (
+	"math"
+	"time"
+
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/model"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/paths"
+)
+
+// Mode is a game mode: how a level is played and scored. Every mode has
+// its own Hall of Fame per level.
+type Mode int
+
+const (
+	ModeClassic    Mode = iota // Catch every Pacman in as few bounces as possible
+	ModeArcade                 // See SetArcade
+	ModeTimeAttack             // See TimeAttackScorer
+)
+
+func (m Mode) String() string {
+	switch m {
+	case ModeArcade:
+		return "Arcade"
+	case ModeTimeAttack:
+		return "Time Attack"
+	default:
+		return "Classic"
+	}
+}
+
+// Time attack mode, see TimeAttackScorer.
+const (
+	TimeAttackDuration = 60 * time.Second // Countdown of levels that don't set one
+	TimeAttackBonus    = 10               // Points per whole second left
+)
+
+// TimeAttackScorer scores time attack runs, which must catch every Pacman
+// before the level's countdown runs out: TimeAttackBonus points per whole
+// second left, minus the bounces, the more the better. Score holds it
+// negated so that lower stays better, TimeAttackPoints turns it back. Runs
+// that run out of time fail.
+type TimeAttackScorer struct {
+	Limit time.Duration // The level's countdown
+}
+
+func (s TimeAttackScorer) Score(run RunStats) model.Score {
+	left := math.Floor(max(0, s.Limit.Seconds()-run.Elapsed))
+	return model.Score{Score: run.Bounces - TimeAttackBonus*int(left)}
+}
+func (s TimeAttackScorer) Failed(run RunStats) bool { return run.Elapsed >= s.Limit.Seconds() }
+
+// TimeAttackPoints returns the points of a score set in time attack mode.
+func TimeAttackPoints(s model.Score) int {
+	return -s.Score
+}
+
+// SetMode sets the game mode, scored with its Scorer: ArcadeScorer,
+// TimeAttackScorer, or back to BounceScorer for ModeClassic unless another
+// classic scorer is set. Takes effect from the next level loaded.
+func (g *Game) SetMode(m Mode) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.mode = m
+	switch m {
+	case ModeArcade:
+		g.modeScorer = ArcadeScorer{}
+	case ModeTimeAttack:
+		g.modeScorer = TimeAttackScorer{}
+	default:
+		switch g.modeScorer.(type) {
+		case ArcadeScorer, TimeAttackScorer:
+			g.modeScorer = BounceScorer{}
+		}
+	}
+}
+
+// SetTimeAttack turns on time attack mode, see TimeAttackScorer. Turning it
+// off goes back to ModeClassic.
+func (g *Game) SetTimeAttack(enabled bool) {
+	if enabled {
+		g.SetMode(ModeTimeAttack)
+	} else if g.Mode() == ModeTimeAttack {
+		g.SetMode(ModeClassic)
+	}
+}
+
+// Mode returns the game mode.
+func (g *Game) Mode() Mode {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.mode
+}
+
+// TimeAttackTimeLeft returns how long the time attack run has left to catch
+// every Pacman, in simulated seconds.
+func (g *Game) TimeAttackTimeLeft() float64 {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return max(0, g.timeLimit().Seconds()-g.simTime)
+}
+
+// timeLimit returns the countdown of the level being played in time attack
+// mode. Assumes the lock is held.
+func (g *Game) timeLimit() time.Duration {
+	if g.TimeLimit > 0 {
+		return g.TimeLimit
+	}
+	return TimeAttackDuration
+}
+
+// levelHighScorePath returns the Hall of Fame of the loaded level for the
+// game mode. Assumes the lock is held.
+func (g *Game) levelHighScorePath() string {
+	return HighScorePath(g.dataDir, g.mode, g.Level, g.Board)
+}
+
+// HighScorePath returns where the Hall of Fame of a level is kept for a
+// game mode. A level with a board of its own, see Game.Board, keeps it
+// under that name rather than its number's.
+func HighScorePath(dataDir string, mode Mode, level int, board string) string {
+	if board != "" {
+		switch mode {
+		case ModeArcade:
+			board += "_arcade"
+		case ModeTimeAttack:
+			board += "_timeattack"
+		}
+		return paths.BoardHighScorePath(dataDir, board)
+	}
+	switch mode {
+	case ModeArcade:
+		return paths.ArcadeHighScorePath(dataDir, level)
+	case ModeTimeAttack:
+		return paths.TimeAttackHighScorePath(dataDir, level)
+	default:
+		return paths.HighScorePath(dataDir, level)
+	}
+}
@@ -1,10 +1,12 @@
 package game
 
 import (
+	"math"
 	"sync"
-	"time"
 )
 
+// Directions of the level and save formats, for Pacmans moving along an
+// axis. Others are given as an angle, see AngleHeading.
 const (
 	DirHorizontal = 'H'
 	DirVertical   = 'V'
@@ -14,46 +16,57 @@ const (
 
 // Pacman represents a single Pac-Man character in the game.
 type Pacman struct {
-	ID           int
-	Radius       float64
-	PosX         float64 // Center X
-	PosY         float64 // Center Y
-	Speed        float64 // Pixels per second
-	Direction    rune    // 'H' or 'V'
-	SubDirection int     // 1 for right/down, -1 for left/up
-	IsStopped    bool
-	WaitTimeMs   int // Original config value, might influence speed or animation
-	Bounces      int // Bounces against walls or other Pacmans
+	ID         int
+	Radius     float64
+	PosX       float64 // Center X
+	PosY       float64 // Center Y
+	Speed      float64 // Pixels per second
+	DirX, DirY float64 // Heading, a unit vector: the velocity is Speed times it
+	IsStopped  bool
+	WaitTimeMs int // Original config value, might influence speed or animation
+	Bounces    int // Bounces against walls or other Pacmans
+
+	wallWarned bool       // Wall warning given for the current heading, see WallWarning
+	wallNormal [2]float64 // Normal of the wall last bounced off, see WallContact
+	caughtAt   float64    // When it was caught, see Game.Stats
+	stunned    float64    // Seconds left of a stun, see Stun
+	respawnAt  float64    // When a caught Pacman comes back in arcade mode, 0 for never
 
 	// Animation state
 	animFrame    int
-	lastAnimTime time.Time
-	animInterval time.Duration
+	animTime     float64 // Seconds the frame has been showing
+	animInterval float64 // Seconds between frames
 
 	// Mutex to protect this Pacman's state during concurrent access
 	// This is kept internal to the Pacman methods.
 	mu sync.Mutex
 }
 
-// NewPacman creates a new Pacman instance from configuration data.
-func NewPacman(id int, radius, posX, posY float64, direction rune, subDirection int, waitTimeMs, bounces int, isStopped bool) *Pacman {
+// NewPacman creates a new Pacman instance from configuration data. It heads
+// along dirX, dirY, which needn't be of unit length; a zero heading is
+// taken as right.
+func NewPacman(id int, radius, posX, posY, dirX, dirY float64, waitTimeMs, bounces int, isStopped bool) *Pacman {
 	// Example speed calculation: faster if waitTimeMs is lower
 	speed := baseSpeed * (100.0 / (float64(waitTimeMs) + 1)) // Avoid division by zero, adjust formula as needed
 
+	if length := math.Hypot(dirX, dirY); length > 0 {
+		dirX, dirY = dirX/length, dirY/length
+	} else {
+		dirX, dirY = 1, 0
+	}
 	return &Pacman{
 		ID:           id,
 		Radius:       radius,
 		PosX:         posX,
 		PosY:         posY,
 		Speed:        speed,
-		Direction:    direction,
-		SubDirection: subDirection,
+		DirX:         dirX,
+		DirY:         dirY,
 		IsStopped:    isStopped,
 		WaitTimeMs:   waitTimeMs,
 		Bounces:      bounces,
 		animFrame:    0,
-		lastAnimTime: time.Now(),
-		animInterval: 150 * time.Millisecond, // Adjust animation speed
+		animInterval: 0.15, // Adjust animation speed
 	}
 }
 
@@ -65,14 +78,16 @@ func (p *Pacman) Update(dt float64, screenWidth, screenHeight float64) (bounces
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	if p.IsStopped {
+	if p.IsStopped || p.updateStun(dt) {
 		return 0
 	}
 
 	// --- Animation ---
-	if time.Since(p.lastAnimTime) > p.animInterval {
+	// Simulated time, like the movement, so it doesn't depend on the wall clock
+	p.animTime += dt
+	if p.animTime > p.animInterval {
 		p.animFrame = (p.animFrame + 1) % 2 // Cycle between 0 and 1
-		p.lastAnimTime = time.Now()
+		p.animTime = 0
 	}
 
 	// --- Movement ---
@@ -80,59 +95,76 @@ func (p *Pacman) Update(dt float64, screenWidth, screenHeight float64) (bounces
 	bounced := false
 	startBounces := p.Bounces
 
-	if p.Direction == DirHorizontal {
-		p.PosX += distance * float64(p.SubDirection)
-		// Check boundaries
-		if p.PosX-p.Radius < 0 && p.SubDirection == -1 {
-			p.PosX = p.Radius // Snap to boundary
-			p.SubDirection *= -1
-			bounced = true
-		} else if p.PosX+p.Radius > screenWidth && p.SubDirection == 1 {
-			p.PosX = screenWidth - p.Radius // Snap to boundary
-			p.SubDirection *= -1
-			bounced = true
-		}
-	} else { // DirVertical
-		p.PosY += distance * float64(p.SubDirection)
-		// Check boundaries
-		if p.PosY-p.Radius < 0 && p.SubDirection == -1 {
-			p.PosY = p.Radius // Snap to boundary
-			p.SubDirection *= -1
-			bounced = true
-		} else if p.PosY+p.Radius > screenHeight && p.SubDirection == 1 {
-			p.PosY = screenHeight - p.Radius // Snap to boundary
-			p.SubDirection *= -1
-			bounced = true
-		}
+	p.PosX += distance * p.DirX
+	p.PosY += distance * p.DirY
+
+	// Reflect off the walls it's heading into: the component of the heading
+	// into a wall flips, the other is kept. Hitting a corner flips both, and
+	// counts as one bounce.
+	var normalX, normalY float64
+	if p.PosX-p.Radius < 0 && p.DirX < 0 {
+		p.PosX = p.Radius // Snap to boundary
+		p.DirX, normalX = -p.DirX, 1
+	} else if p.PosX+p.Radius > screenWidth && p.DirX > 0 {
+		p.PosX = screenWidth - p.Radius
+		p.DirX, normalX = -p.DirX, -1
+	}
+	if p.PosY-p.Radius < 0 && p.DirY < 0 {
+		p.PosY = p.Radius
+		p.DirY, normalY = -p.DirY, 1
+	} else if p.PosY+p.Radius > screenHeight && p.DirY > 0 {
+		p.PosY = screenHeight - p.Radius
+		p.DirY, normalY = -p.DirY, -1
 	}
+	bounced = normalX != 0 || normalY != 0
 
 	if bounced {
 		p.Bounces++
+		p.wallWarned = false
+		length := math.Hypot(normalX, normalY)
+		p.wallNormal = [2]float64{normalX / length, normalY / length}
 	}
 
 	return p.Bounces - startBounces // Return bounces occurred *in this step*
 }
 
-// Bounce changes the Pacman's direction due to collision with another Pacman.
-// It increments the bounce count and returns true.
-func (p *Pacman) Bounce() bool {
+// WallContact returns, right after a wall bounce, the point where the Pacman
+// touches the wall and the wall's normal, pointing back into the play area.
+func (p *Pacman) WallContact() (x, y, normalX, normalY float64) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
-	if p.IsStopped {
-		return false // Cannot bounce if stopped
-	}
-	p.SubDirection *= -1
-	p.Bounces++
+	normalX, normalY = p.wallNormal[0], p.wallNormal[1]
+	return p.PosX - normalX*p.Radius, p.PosY - normalY*p.Radius, normalX, normalY
+}
 
-	// Small positional nudge to prevent immediate re-collision
-	nudge := 1.1 // Adjust nudge factor if needed
-	if p.Direction == DirHorizontal {
-		p.PosX += nudge * float64(p.SubDirection)
-	} else {
-		p.PosY += nudge * float64(p.SubDirection)
-	}
+// WallWarning reports, once per approach, that the Pacman will hit the wall
+// it's heading for within lead seconds. wall is which one: 'L', 'R', 'T' or 'B'.
+func (p *Pacman) WallWarning(lead, screenWidth, screenHeight float64) (posX, posY float64, wall rune, warn bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
 
-	return true
+	if p.IsStopped || p.wallWarned || p.Speed <= 0 || p.stunned > 0 {
+		return 0, 0, 0, false
+	}
+	// Seconds left until it reaches the wall it hits first
+	left := math.Inf(1)
+	reach := func(gap, heading float64, w rune) {
+		if heading <= 0 {
+			return // Heading away from it, or along it
+		}
+		if t := gap / (heading * p.Speed); t < left {
+			left, wall = t, w
+		}
+	}
+	reach(p.PosX-p.Radius, -p.DirX, 'L')
+	reach(screenWidth-p.Radius-p.PosX, p.DirX, 'R')
+	reach(p.PosY-p.Radius, -p.DirY, 'T')
+	reach(screenHeight-p.Radius-p.PosY, p.DirY, 'B')
+	if left > lead {
+		return 0, 0, 0, false
+	}
+	p.wallWarned = true
+	return p.PosX, p.PosY, wall, true
 }
 
 // Stop marks the Pacman as stopped and returns true if it was running.
@@ -146,16 +178,40 @@ func (p *Pacman) Stop() bool {
 	return false // Was already stopped
 }
 
+// Velocity returns the Pacman's velocity in pixels per second, zero once
+// stopped or while stunned.
+func (p *Pacman) Velocity() (velX, velY float64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.IsStopped {
+		return 0, 0
+	}
+	return p.velocity()
+}
+
+// Stopped reports whether the Pacman has been caught.
+func (p *Pacman) Stopped() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.IsStopped
+}
+
 // IsClicked checks if the given coordinates (cx, cy) are inside the Pacman.
 // Safe for concurrent read access if needed, but Stop() must be called via Game.
 func (p *Pacman) IsClicked(cx, cy float64) bool {
+	return p.hit(cx, cy, 1)
+}
+
+// hit is IsClicked with clicks reaching scale times the Pacman's radius.
+func (p *Pacman) hit(cx, cy, scale float64) bool {
 	p.mu.Lock() // Lock needed to read position safely
 	defer p.mu.Unlock()
 	// Simple circle collision check
 	dx := p.PosX - cx
 	dy := p.PosY - cy
 	distanceSq := dx*dx + dy*dy
-	return distanceSq < p.Radius*p.Radius && !p.IsStopped
+	reach := p.Radius * scale
+	return distanceSq < reach*reach && !p.IsStopped
 }
 
 // GetData returns a thread-safe copy of the Pacman's current state for drawing or saving.
@@ -166,11 +222,11 @@ func (p *Pacman) GetData() (posX, posY, radius float64, animFrame int, isStopped
 }
 
 // GetDataForSave returns a thread-safe copy of the Pacman's state relevant for saving.
-func (p *Pacman) GetDataForSave() (radius, posX, posY float64, waitTimeMs, subDirection, bounces int, direction rune, isStopped bool) {
+func (p *Pacman) GetDataForSave() (radius, posX, posY, dirX, dirY, speed float64, waitTimeMs, bounces int, isStopped bool) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 	// Diameter is often stored in config, but radius is used internally. Save radius for consistency? Let's save diameter.
-	return p.Radius * 2, p.PosX, p.PosY, p.WaitTimeMs, p.SubDirection, p.Bounces, p.Direction, p.IsStopped
+	return p.Radius * 2, p.PosX, p.PosY, p.DirX, p.DirY, p.Speed, p.WaitTimeMs, p.Bounces, p.IsStopped
 }
 
 // CheckCollision detects collision with another Pacman.
@@ -196,3 +252,47 @@ func (p *Pacman) GetStateForCollisionCheck() (posX, posY, radius float64, isStop
 	defer p.mu.Unlock()
 	return p.PosX, p.PosY, p.Radius, p.IsStopped
 }
+
+// AxisHeading returns the heading of a Pacman moving along direction,
+// DirHorizontal or DirVertical: right or down for a subDirection of 1, left
+// or up for -1.
+func AxisHeading(direction rune, subDirection int) (dirX, dirY float64) {
+	if direction == DirVertical {
+		return 0, float64(subDirection)
+	}
+	return float64(subDirection), 0
+}
+
+// AngleHeading returns the heading of a Pacman moving at an angle, in
+// degrees clockwise from right as the screen's y axis points down: 0 is
+// right, 90 down, 180 left and 270 up.
+func AngleHeading(degrees float64) (dirX, dirY float64) {
+	sin, cos := math.Sincos(degrees * math.Pi / 180)
+	// Keep headings along an axis exactly on it
+	if math.Abs(cos) < 1e-12 {
+		cos = 0
+	}
+	if math.Abs(sin) < 1e-12 {
+		sin = 0
+	}
+	return cos, sin
+}
+
+// HeadingAngle returns the angle of a heading, see AngleHeading, between 0
+// and 360 degrees.
+func HeadingAngle(dirX, dirY float64) float64 {
+	degrees := math.Atan2(dirY, dirX) * 180 / math.Pi
+	if degrees < 0 {
+		degrees += 360
+	}
+	return degrees
+}
+
+// HeadingAxis returns the direction and sub-direction nearest to a heading,
+// for the formats written before Pacmans could move at an angle.
+func HeadingAxis(dirX, dirY float64) (direction rune, subDirection int) {
+	if math.Abs(dirY) > math.Abs(dirX) {
+		return DirVertical, int(math.Copysign(1, dirY))
+	}
+	return DirHorizontal, int(math.Copysign(1, dirX))
+}
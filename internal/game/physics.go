@@ -0,0 +1,86 @@
+package game
+
+import "math"
+
+// collide resolves a collision between two Pacmans whose circles overlap,
+// as between two balls: they're pushed apart along the line between their
+// centers, and if they're closing in on each other they exchange momentum
+// along it elastically, their radii standing for their masses. A stunned
+// Pacman is held in place, so it's pushed off like a wall that doesn't
+// move. Each Pacman whose velocity changed gets a bounce; it returns how
+// many did. Pacmans that don't overlap, and caught ones, are left alone.
+func collide(a, b *Pacman) (bounces int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if a.IsStopped || b.IsStopped {
+		return 0
+	}
+	dx, dy := b.PosX-a.PosX, b.PosY-a.PosY
+	dist := math.Hypot(dx, dy)
+	overlap := a.Radius + b.Radius - dist
+	if overlap <= 0 {
+		return 0
+	}
+	// Normal from a to b; right on top of each other, any will do
+	nx, ny := 1.0, 0.0
+	if dist > 0 {
+		nx, ny = dx/dist, dy/dist
+	}
+
+	// Inverse masses, 0 for one that's held in place
+	invA, invB := 1/a.Radius, 1/b.Radius
+	if a.stunned > 0 {
+		invA = 0
+	}
+	if b.stunned > 0 {
+		invB = 0
+	}
+	if invA+invB == 0 {
+		return 0
+	}
+
+	// Separate them, the lighter one moving further
+	shareA, shareB := invA/(invA+invB), invB/(invA+invB)
+	a.PosX, a.PosY = a.PosX-nx*overlap*shareA, a.PosY-ny*overlap*shareA
+	b.PosX, b.PosY = b.PosX+nx*overlap*shareB, b.PosY+ny*overlap*shareB
+
+	avx, avy := a.velocity()
+	bvx, bvy := b.velocity()
+	closing := (avx-bvx)*nx + (avy-bvy)*ny
+	if closing <= 0 {
+		return 0 // Already moving apart, e.g. still overlapping after the last one
+	}
+	impulse := 2 * closing / (invA + invB)
+	if invA > 0 {
+		a.setVelocity(avx-impulse*invA*nx, avy-impulse*invA*ny)
+		bounces++
+	}
+	if invB > 0 {
+		b.setVelocity(bvx+impulse*invB*nx, bvy+impulse*invB*ny)
+		bounces++
+	}
+	return bounces
+}
+
+// velocity returns the Pacman's velocity as it moves, zero while stunned.
+// Assumes the Pacman's lock is held.
+func (p *Pacman) velocity() (velX, velY float64) {
+	if p.stunned > 0 {
+		return 0, 0
+	}
+	return p.Speed * p.DirX, p.Speed * p.DirY
+}
+
+// setVelocity changes the Pacman's speed and heading to the velocity's,
+// counting it as a bounce. A Pacman brought to a halt keeps its heading.
+// Assumes the Pacman's lock is held.
+func (p *Pacman) setVelocity(velX, velY float64) {
+	p.Speed = math.Hypot(velX, velY)
+	if p.Speed > 0 {
+		p.DirX, p.DirY = velX/p.Speed, velY/p.Speed
+	}
+	p.Bounces++
+	p.wallWarned = false
+}
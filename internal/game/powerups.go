@@ -0,0 +1,182 @@
+package game
+
+import (
+	"slices"
+	"time"
+)
+
+// PowerUpKind is what a power-up does once collected.
+type PowerUpKind int
+
+const (
+	PowerUpSlowMotion PowerUpKind = iota // Pacmans move at PowerUpSlowFactor times their speed
+	PowerUpFreeze                        // Pacmans stand still
+	PowerUpBigHitbox                     // Clicks reach PowerUpHitboxScale times as far
+	numPowerUpKinds
+)
+
+func (k PowerUpKind) String() string {
+	switch k {
+	case PowerUpSlowMotion:
+		return "Slow motion"
+	case PowerUpFreeze:
+		return "Freeze"
+	case PowerUpBigHitbox:
+		return "Big hitbox"
+	default:
+		return "Unknown"
+	}
+}
+
+// Power-ups, see SetPowerUps.
+const (
+	PowerUpInterval    = 8 * time.Second // Average time between two power-ups showing up
+	PowerUpLifetime    = 5 * time.Second // How long a power-up waits to be collected
+	PowerUpDuration    = 5 * time.Second // How long its effect lasts
+	PowerUpRadius      = 14.0
+	PowerUpSlowFactor  = 0.4
+	PowerUpHitboxScale = 1.6
+)
+
+// SoundPowerUp plays when a power-up is collected.
+const SoundPowerUp = "powerup" // No sound file yet, only reported to the sound hooks
+
+// PowerUpDrawData is a power-up waiting to be collected, as frontends draw it.
+type PowerUpDrawData struct {
+	Kind   PowerUpKind
+	PosX   float64
+	PosY   float64
+	Radius float64
+	Left   float64 // Simulated seconds until it goes away
+}
+
+// PowerUpEffect is the effect of a collected power-up still running.
+type PowerUpEffect struct {
+	Kind PowerUpKind
+	Left float64 // Seconds until it wears off
+}
+
+// powerUp is a power-up waiting to be collected.
+type powerUp struct {
+	kind  PowerUpKind
+	x, y  float64
+	until float64 // Simulated seconds it goes away at
+}
+
+// powerUpState is the power-ups of the level being played. It's a value,
+// so practice snapshots can keep a copy of it.
+type powerUpState struct {
+	items   []powerUp
+	effects [numPowerUpKinds]float64 // Simulated seconds each effect runs until, slow motion aside
+	next    float64                  // Simulated seconds the next power-up shows up at
+}
+
+// SetPowerUps turns the power-ups showing up during play on or off. They're
+// on by default. Takes effect from the next level loaded.
+func (g *Game) SetPowerUps(enabled bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.noPowerUps = !enabled
+}
+
+// PowerUps returns the power-ups waiting to be collected.
+func (g *Game) PowerUps() []PowerUpDrawData {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	items := make([]PowerUpDrawData, len(g.powerUps.items))
+	for i, item := range g.powerUps.items {
+		items[i] = PowerUpDrawData{Kind: item.kind, PosX: item.x, PosY: item.y, Radius: PowerUpRadius, Left: item.until - g.simTime}
+	}
+	return items
+}
+
+// PowerUpEffects returns the effects of the power-ups collected that are
+// still running. Slow motion counts down in real time, like every slow
+// motion, the others in simulated time.
+func (g *Game) PowerUpEffects() []PowerUpEffect {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	var effects []PowerUpEffect
+	if left := g.slowMotionUntil.Sub(g.clock.Now()); g.slowPowerUp && left > 0 {
+		effects = append(effects, PowerUpEffect{PowerUpSlowMotion, left.Seconds()})
+	}
+	for kind, until := range g.powerUps.effects {
+		if until > g.simTime {
+			effects = append(effects, PowerUpEffect{PowerUpKind(kind), until - g.simTime})
+		}
+	}
+	return effects
+}
+
+// resetPowerUps clears the power-ups of the level played before. The ones
+// showing up draw from the run's randomness, see seedRun.
+// Assumes the write lock is held.
+func (g *Game) resetPowerUps() {
+	g.powerUps = powerUpState{next: PowerUpInterval.Seconds()}
+	g.slowPowerUp = false
+}
+
+// updatePowerUps drops the power-ups nobody collected in time and brings
+// on the next one when it's due, somewhere in the play area.
+// Assumes the write lock is held.
+func (g *Game) updatePowerUps() {
+	s := &g.powerUps
+	s.items = slices.DeleteFunc(s.items, func(item powerUp) bool { return g.simTime >= item.until })
+	if g.noPowerUps || g.simTime < s.next {
+		return
+	}
+	s.items = append(s.items, powerUp{
+		kind:  PowerUpKind(g.rng.IntN(int(numPowerUpKinds))),
+		x:     PowerUpRadius + g.rng.Float64()*(g.ScreenWidth-2*PowerUpRadius),
+		y:     PowerUpRadius + g.rng.Float64()*(g.ScreenHeight-2*PowerUpRadius),
+		until: g.simTime + PowerUpLifetime.Seconds(),
+	})
+	s.next = g.simTime + PowerUpInterval.Seconds()*(0.5+g.rng.Float64())
+}
+
+// powerUpActive reports whether the effect of a collected power-up is
+// running. Assumes the lock is held.
+func (g *Game) powerUpActive(kind PowerUpKind) bool {
+	return g.simTime < g.powerUps.effects[kind]
+}
+
+// hitboxScale returns how far clicks reach, as a factor of a Pacman's
+// radius. Assumes the lock is held.
+func (g *Game) hitboxScale() float64 {
+	if g.powerUpActive(PowerUpBigHitbox) {
+		return PowerUpHitboxScale
+	}
+	return 1
+}
+
+// collectPowerUp starts the effect of the i-th power-up and takes it away.
+// A power-up collected while its effect runs starts it over. Assumes the
+// write lock is held.
+func (g *Game) collectPowerUp(i int) {
+	item := g.powerUps.items[i]
+	g.powerUps.items = slices.Delete(g.powerUps.items, i, i+1)
+	if item.kind == PowerUpSlowMotion {
+		g.timeScale = PowerUpSlowFactor
+		g.slowMotionUntil = g.clock.Now().Add(PowerUpDuration)
+		g.slowPowerUp = true
+	} else {
+		g.powerUps.effects[item.kind] = g.simTime + PowerUpDuration.Seconds()
+	}
+	g.soundEvent(SoundPowerUp, item.x, item.y)
+}
+
+// powerUpTarget is a power-up as a Clickable: clicking it collects it.
+type powerUpTarget struct {
+	g *Game
+	i int // Index in the game's power-ups
+}
+
+func (t powerUpTarget) Contains(x, y float64) bool {
+	item := t.g.powerUps.items[t.i]
+	dx, dy := item.x-x, item.y-y
+	return dx*dx+dy*dy < PowerUpRadius*PowerUpRadius
+}
+
+func (t powerUpTarget) Click() {
+	t.g.collectPowerUp(t.i)
+}
@@ -0,0 +1,71 @@
+package game
+
+import "time"
+
+// PracticeStep is how far one frame step advances the simulation.
+const PracticeStep = time.Second / 60
+
+// Practice mode lets players pause the simulation and advance it one tick
+// at a time to study collisions. Practice runs never enter the Hall of Fame.
+
+// SetPractice turns practice mode on or off. Turning it off resumes play.
+func (g *Game) SetPractice(enabled bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.practice = enabled
+	if !enabled {
+		g.paused, g.pendingSteps = false, 0
+	}
+}
+
+// Practice reports whether practice mode is on.
+func (g *Game) Practice() bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.practice
+}
+
+// TogglePause pauses or resumes the level being played, going to
+// StatePaused and back. In practice mode the simulation is frozen in
+// StatePlaying instead, so it can be stepped through and rewound.
+func (g *Game) TogglePause() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	switch {
+	case g.practice:
+		g.paused = !g.paused
+		g.pendingSteps = 0
+	case g.CurrentState == StatePlaying && g.Level >= 0:
+		g.setState(StatePaused)
+	case g.CurrentState == StatePaused:
+		g.setState(StatePlaying) // Resets the tick clock, so nothing jumps
+	}
+}
+
+// Paused reports whether the simulation is frozen in practice mode.
+func (g *Game) Paused() bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.paused
+}
+
+// StepFrame advances the paused simulation by PracticeStep on the next Update.
+func (g *Game) StepFrame() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.paused {
+		g.pendingSteps++
+	}
+}
+
+// pausedStep decides how a paused Update proceeds: false if it doesn't move
+// at all, true with deltaTime set to one step if a step is pending.
+// Assumes the write lock is held.
+func (g *Game) pausedStep() bool {
+	if g.pendingSteps == 0 {
+		return false
+	}
+	g.pendingSteps--
+	g.deltaTime = PracticeStep.Seconds()
+	return true
+}
@@ -0,0 +1,102 @@
+package game
+
+import (
+	"math/rand/v2"
+	"slices"
+	"time"
+
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/model"
+)
+
+// RewindWindow is how far back practice mode can rewind.
+const RewindWindow = 5 * time.Second
+
+// snapshot is the state of a level at one tick, enough to rewind to it.
+type snapshot struct {
+	at           float64 // Simulated seconds since the level started
+	totalBounces int
+	counters     runCounters
+	powerUps     powerUpState
+	rng          rand.PCG // The run's randomness
+	pacmans      []pacmanSnapshot
+}
+
+// pacmanSnapshot is the part of a Pacman's state that changes during play.
+type pacmanSnapshot struct {
+	posX, posY float64
+	dirX, dirY float64
+	bounces    int
+	stopped    bool
+	wallWarned bool
+	wallNormal [2]float64
+	stunned    float64
+	speed      float64 // Changes when a Pacman breaks free of a stun or respawns
+	respawnAt  float64
+}
+
+func (p *Pacman) snapshot() pacmanSnapshot {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return pacmanSnapshot{p.PosX, p.PosY, p.DirX, p.DirY, p.Bounces, p.IsStopped, p.wallWarned, p.wallNormal, p.stunned, p.Speed, p.respawnAt}
+}
+
+func (p *Pacman) restore(s pacmanSnapshot) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.PosX, p.PosY, p.DirX, p.DirY, p.Bounces, p.IsStopped = s.posX, s.posY, s.dirX, s.dirY, s.bounces, s.stopped
+	p.wallWarned, p.wallNormal, p.stunned, p.Speed, p.respawnAt = s.wallWarned, s.wallNormal, s.stunned, s.speed, s.respawnAt
+}
+
+// recordHistory remembers the state before this tick's move, dropping what
+// is older than RewindWindow. Assumes the write lock is held.
+func (g *Game) recordHistory() {
+	powerUps := g.powerUps
+	powerUps.items = slices.Clone(powerUps.items)
+	s := snapshot{at: g.simTime, totalBounces: g.TotalBounces, counters: g.counters, powerUps: powerUps, rng: g.rngSrc, pacmans: make([]pacmanSnapshot, len(g.Pacmans))}
+	for i, p := range g.Pacmans {
+		s.pacmans[i] = p.snapshot()
+	}
+	g.history = append(g.history, s)
+
+	drop := 0
+	for drop < len(g.history) && g.simTime-g.history[drop].at > RewindWindow.Seconds() {
+		drop++
+	}
+	g.history = g.history[drop:]
+}
+
+// clearHistory forgets every snapshot, e.g. when another level starts.
+// Assumes the write lock is held.
+func (g *Game) clearHistory() {
+	g.history = nil
+	g.simTime = 0
+}
+
+// Rewind steps a practice run back by one recorded tick, also out of a game
+// over. Called every tick while the rewind key is held, it plays the run
+// backwards at normal speed. Returns false when there is nothing (more) to
+// rewind.
+func (g *Game) Rewind() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if !g.practice || len(g.history) == 0 || (g.CurrentState != StatePlaying && g.CurrentState != StateGameOver) {
+		return false
+	}
+	s := g.history[len(g.history)-1]
+	g.history = g.history[:len(g.history)-1]
+
+	g.Pacmans = g.Pacmans[:min(len(g.Pacmans), len(s.pacmans))] // Drop Pacmans spawned since
+	for i, p := range g.Pacmans {
+		p.restore(s.pacmans[i])
+	}
+	g.TotalBounces = s.totalBounces
+	g.counters = s.counters
+	g.powerUps = s.powerUps
+	g.rngSrc = s.rng
+	g.simTime = s.at
+	g.setState(StatePlaying)
+	g.failed = false
+	g.entry = model.Score{}
+	return true
+}
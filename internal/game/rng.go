@@ -0,0 +1,55 @@
+package game
+
+import "math/rand/v2"
+
+// The randomness of a run, arcade respawns, power-ups and random spawns
+// alike, comes from one source seeded when the run starts, so the same seed
+// and the same input play out the same game. Practice rewinds take it back
+// with the rest of the run.
+
+// RunSeed returns the seed of the run being played, see Game.Seed.
+func (g *Game) RunSeed() uint64 {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.Seed
+}
+
+// seedRun seeds the randomness of the run starting with seed, or with the
+// level's own seed, its number, if 0, so its runs stay comparable.
+// Assumes the write lock is held.
+func (g *Game) seedRun(seed uint64) {
+	if seed == 0 {
+		seed = uint64(max(g.Level, 0))
+	}
+	g.Seed = seed
+	g.rngSrc.Seed(seed, 0)
+}
+
+// SpawnRandomPacman adds an extra running Pacman somewhere on screen,
+// heading along an axis, with the position and heading drawn from the
+// run's randomness. Returns false if no level is being played.
+func (g *Game) SpawnRandomPacman(radius float64, waitTimeMs int) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.CurrentState != StatePlaying {
+		return false
+	}
+	x := radius + g.rng.Float64()*(g.ScreenWidth-2*radius)
+	y := radius + g.rng.Float64()*(g.ScreenHeight-2*radius)
+	dirX, dirY := AxisHeading(randomAxis(g.rng))
+	g.spawnPacman(radius, x, y, dirX, dirY, waitTimeMs)
+	return true
+}
+
+// randomAxis draws a direction and sub-direction, see AxisHeading.
+func randomAxis(rng *rand.Rand) (direction rune, subDirection int) {
+	direction, subDirection = DirHorizontal, 1
+	if rng.IntN(2) == 0 {
+		direction = DirVertical
+	}
+	if rng.IntN(2) == 0 {
+		subDirection = -1
+	}
+	return direction, subDirection
+}
@@ -0,0 +1,75 @@
+package game
+
+import (
+	"log"
+	"sync"
+)
+
+// SaveResult is how a save asked for with RequestSaveGame went.
+type SaveResult struct {
+	Path string
+	Err  error
+}
+
+// saver writes save files on a goroutine of its own, one at a time and in
+// the order they were asked for, so the game loop never waits on the disk.
+// It's guarded by its own mutex rather than the Game's.
+type saver struct {
+	mu      sync.Mutex
+	queue   []func() SaveResult
+	writing bool // A goroutine is working through the queue
+	results []SaveResult
+	pending sync.WaitGroup // Saves asked for and not written yet
+}
+
+// add queues a save, starting the goroutine writing them if it isn't running.
+func (s *saver) add(write func() SaveResult) {
+	s.pending.Add(1)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.queue = append(s.queue, write)
+	if !s.writing {
+		s.writing = true
+		go s.run()
+	}
+}
+
+// run writes the queued saves until there are none left.
+func (s *saver) run() {
+	for {
+		s.mu.Lock()
+		if len(s.queue) == 0 {
+			s.writing = false
+			s.mu.Unlock()
+			return
+		}
+		write := s.queue[0]
+		s.queue = s.queue[1:]
+		s.mu.Unlock()
+
+		result := write()
+		if result.Err != nil {
+			log.Printf("Error saving game state to %s: %v", result.Path, result.Err)
+		}
+		s.mu.Lock()
+		s.results = append(s.results, result)
+		s.mu.Unlock()
+		s.pending.Done()
+	}
+}
+
+// PollSaveResults returns how the saves finished since the last call went,
+// oldest first, for telling the player.
+func (g *Game) PollSaveResults() []SaveResult {
+	g.saves.mu.Lock()
+	defer g.saves.mu.Unlock()
+	results := g.saves.results
+	g.saves.results = nil
+	return results
+}
+
+// WaitForSaves blocks until every save asked for is written, e.g. before
+// quitting or reading a save back.
+func (g *Game) WaitForSaves() {
+	g.saves.pending.Wait()
+}
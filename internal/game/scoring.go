@@ -0,0 +1,199 @@
+package game
+
+import (
+	"math"
+
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/model"
+)
+
+// Scorer holds the scoring rules of a game mode: what a run scores, lower
+// being better, and whether it failed. Game.Update and the game over
+// handling only go through it, so a new mode is a new Scorer.
+type Scorer interface {
+	Score(run RunStats) model.Score // Score and the fields explaining it, without name or sprite scale
+	Failed(run RunStats) bool       // Checked every tick, a failed run ends right away
+}
+
+// BounceScorer is the classic scoring: every bounce counts, nothing fails.
+type BounceScorer struct{}
+
+func (BounceScorer) Score(run RunStats) model.Score { return model.Score{Score: run.Bounces} }
+func (BounceScorer) Failed(RunStats) bool           { return false }
+
+// AccuracyScorer adds model.MissPenalty bounces for every missed click, so
+// camping and spamming clicks don't pay off.
+type AccuracyScorer struct{}
+
+func (AccuracyScorer) Score(run RunStats) model.Score {
+	return model.Score{Score: run.Bounces + run.Misses*model.MissPenalty, Misses: run.Misses}
+}
+func (AccuracyScorer) Failed(RunStats) bool { return false }
+
+// BudgetScorer fails runs that bounce more than Limit times, scoring them
+// like the Scorer it wraps. Levels with a bounce budget get one.
+type BudgetScorer struct {
+	Scorer
+	Limit int
+}
+
+func (s BudgetScorer) Failed(run RunStats) bool {
+	return run.Bounces > s.Limit || s.Scorer.Failed(run)
+}
+
+// HandicapScorer counts bounces with a handicap's multiplier, rounding up,
+// before scoring like the Scorer it wraps.
+type HandicapScorer struct {
+	Scorer
+	Multiplier float64
+}
+
+func (s HandicapScorer) Score(run RunStats) model.Score {
+	run.Bounces = int(math.Ceil(float64(run.Bounces) * s.Multiplier))
+	return s.Scorer.Score(run)
+}
+
+// SetScorer sets the scoring rules of the game mode, BounceScorer if nil.
+// A level's bounce budget is applied on top of them.
+func (g *Game) SetScorer(s Scorer) {
+	if s == nil {
+		s = BounceScorer{}
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.modeScorer = s
+}
+
+// SetAccuracyScoring switches between AccuracyScorer and BounceScorer.
+func (g *Game) SetAccuracyScoring(enabled bool) {
+	if enabled {
+		g.SetScorer(AccuracyScorer{})
+	} else {
+		g.SetScorer(BounceScorer{})
+	}
+}
+
+// AccuracyScoring reports whether missed clicks count towards the score.
+func (g *Game) AccuracyScoring() bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	_, ok := g.modeScorer.(AccuracyScorer)
+	return ok
+}
+
+// SetHandicap sets the handicap of the player's profile: the game runs
+// slower by its slowdown, and bounces count less by its multiplier. Scores
+// are tagged with it.
+func (g *Game) SetHandicap(h model.Handicap) error {
+	if err := h.Validate(); err != nil {
+		return err
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.handicap = h
+	return nil
+}
+
+// Handicap returns the handicap set with SetHandicap.
+func (g *Game) Handicap() model.Handicap {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.handicap
+}
+
+// scorer returns the rules of the level being played: the mode's, with the
+// level's countdown in time attack, the bounces earned by chains if its
+// Hall of Fame scores them, the handicap's bounce multiplier and the level's bounce budget, which counts
+// real bounces. Assumes the lock is held.
+func (g *Game) scorer() Scorer {
+	s := g.modeScorer
+	if ta, ok := s.(TimeAttackScorer); ok {
+		ta.Limit = g.timeLimit()
+		s = ta
+	}
+	if g.HallOfFame.Chains {
+		s = ChainScorer{s}
+	}
+	if g.handicap.Multiplied() {
+		s = HandicapScorer{s, g.handicap.BounceMultiplier}
+	}
+	if g.MaxBounces > 0 {
+		s = BudgetScorer{s, g.MaxBounces}
+	}
+	return s
+}
+
+// Score returns the score of the run being played or just finished, without
+// a name. A finished run's score has the ID and time its Hall of Fame entry
+// gets, on every board.
+func (g *Game) Score() model.Score {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.score()
+}
+
+// score is Score for callers holding the lock.
+func (g *Game) score() model.Score {
+	s := g.scorer().Score(g.totals())
+	s.SpriteScale = g.spriteScale
+	s.ID, s.SetAt = g.entry.ID, g.entry.SetAt
+	if g.handicap.Active() {
+		s.Handicap = g.handicap
+	}
+	return s
+}
+
+// BounceLimit returns the level's bounce budget, 0 if it has none.
+func (g *Game) BounceLimit() int {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.MaxBounces
+}
+
+// HallOfFameRules returns which runs make the Hall of Fame of the level
+// being played.
+func (g *Game) HallOfFameRules() model.HallOfFameRules {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.hallOfFameRules()
+}
+
+// LevelBoard returns the name of the Hall of Fame of the level being
+// played, empty if it's its level number's, see Game.Board.
+func (g *Game) LevelBoard() string {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.Board
+}
+
+// hallOfFameRules is HallOfFameRules for callers holding the lock. Arcade
+// and time attack runs score points rather than bounces, so a bounce
+// threshold means nothing to their Hall of Fame.
+func (g *Game) hallOfFameRules() model.HallOfFameRules {
+	rules := g.HallOfFame
+	if g.mode != ModeClassic {
+		rules.Threshold = 0
+	}
+	return rules
+}
+
+// Failed reports whether the run ended by failing the scoring rules, e.g.
+// going over the bounce budget. Failed runs end in StateGameOver and never
+// make the Hall of Fame.
+func (g *Game) Failed() bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.failed
+}
+
+// EnterName asks for the player's name after a run that missed the Hall of
+// Fame but still earns a place on another board, e.g. this week's. The name
+// confirmed goes through HandleEnter, which only adds the run to the Hall
+// of Fame if it made it. Ignored unless a run that counts just ended.
+func (g *Game) EnterName() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.CurrentState != StateGameOver || g.failed || g.practice || g.mode != ModeClassic {
+		return false
+	}
+	return g.setState(StateEnteringHighScore)
+}
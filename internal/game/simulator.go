@@ -0,0 +1,144 @@
+package game
+
+import (
+	"time"
+
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/model"
+)
+
+// SimTick is the time a Simulator moves the game on by every step, one
+// frame of the frontends.
+const SimTick = time.Second / 60
+
+// SimPlayer decides the clicks of a simulated run. It's asked before every
+// tick, with the run time so far, and reports whether to click and where.
+type SimPlayer func(elapsed time.Duration, g *Game) (x, y float64, click bool)
+
+// SimClick is a click a ScriptedPlayer makes, At into the run.
+type SimClick struct {
+	At   time.Duration
+	X, Y float64
+}
+
+// ScriptedPlayer makes clicks at set times, in the order given. A tick
+// makes at most one of them, the ones due at once follow tick by tick.
+func ScriptedPlayer(clicks []SimClick) SimPlayer {
+	return func(elapsed time.Duration, _ *Game) (float64, float64, bool) {
+		if len(clicks) == 0 || clicks[0].At > elapsed {
+			return 0, 0, false
+		}
+		c := clicks[0]
+		clicks = clicks[1:]
+		return c.X, c.Y, true
+	}
+}
+
+// ReactionPlayer clicks the first Pacman still running, where it is, every
+// reaction, like a player that never misses but takes its time.
+func ReactionPlayer(reaction time.Duration) SimPlayer {
+	next := reaction
+	return func(elapsed time.Duration, g *Game) (float64, float64, bool) {
+		if elapsed < next {
+			return 0, 0, false
+		}
+		next = elapsed + reaction
+		for _, p := range g.GetPacmanData() {
+			if !p.IsStopped {
+				return p.PosX, p.PosY, true
+			}
+		}
+		return 0, 0, false
+	}
+}
+
+// SimResult is how a simulated run ended.
+type SimResult struct {
+	Bounces  int
+	Score    model.Score // Without a name, ID or time
+	Failed   bool        // Failed the scoring rules, see Game.Failed
+	TimedOut bool        // Was still going when the Simulator gave up on it
+	Elapsed  time.Duration
+	Stats    RunStats
+}
+
+// stepClock is a Clock that only moves when told to, see Simulator.
+type stepClock struct {
+	now time.Time
+}
+
+func (c *stepClock) Now() time.Time { return c.now }
+
+// Simulator plays levels on a Game without a frontend, a tick at a time on
+// a clock of its own, so runs take no longer than the computing and come
+// out the same every time: for tests of the game logic and batch runs.
+// It isn't safe for concurrent use, nor is the Game meant to be driven by
+// anything else meanwhile.
+type Simulator struct {
+	Game  *Game
+	clock *stepClock
+	start time.Time // When the level being played started
+}
+
+// NewSimulator takes g over to simulate runs on it. Options such as the
+// mode or the scoring are set on g as usual, before Load.
+func NewSimulator(g *Game) *Simulator {
+	s := &Simulator{Game: g, clock: &stepClock{now: time.Unix(0, 0)}}
+	g.SetClock(s.clock)
+	return s
+}
+
+// Load starts playing level, as the level loaders read it, right away,
+// whatever the run before ended in. The run draws from the level's seed
+// like any other, see seedRun. High scores aren't loaded nor saved.
+func (s *Simulator) Load(level *Game, source string) {
+	g := s.Game
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.setState(StateStarting) // Or a run waiting for a name couldn't be left
+	g.swapInLevel(levelLoadResult{data: level}, source)
+	s.start = s.clock.now
+}
+
+// Elapsed returns the time since the level being played started.
+func (s *Simulator) Elapsed() time.Duration {
+	return s.clock.now.Sub(s.start)
+}
+
+// Playing reports whether the run is still going.
+func (s *Simulator) Playing() bool {
+	state, _, _ := s.Game.GetGameState()
+	return state == StatePlaying
+}
+
+// Step moves the game on by one tick.
+func (s *Simulator) Step() {
+	s.clock.now = s.clock.now.Add(SimTick)
+	s.Game.Update()
+}
+
+// Run plays the level loaded until the run ends, or for limit at most,
+// with player's clicks, and returns how it ended.
+func (s *Simulator) Run(player SimPlayer, limit time.Duration) SimResult {
+	for s.Playing() && s.Elapsed() < limit {
+		if x, y, click := player(s.Elapsed(), s.Game); click {
+			s.Game.HandleClick(x, y)
+		}
+		s.Step()
+	}
+	return s.Result()
+}
+
+// Result returns how the run being played went so far, or how it ended.
+func (s *Simulator) Result() SimResult {
+	stats := s.Game.Stats()
+	score := s.Game.Score()
+	score.ID, score.SetAt = "", 0
+	return SimResult{
+		Bounces:  stats.Bounces,
+		Score:    score,
+		Failed:   s.Game.Failed(),
+		TimedOut: s.Playing(),
+		Elapsed:  s.Elapsed(),
+		Stats:    stats,
+	}
+}
@@ -0,0 +1,81 @@
+package game
+
+import "log"
+
+// transitions lists the states the game can go to from each state. Any
+// state can go back to the start screen or show the loading screen, and
+// the loading screen goes on to whatever was entered while it showed, see
+// enterState. A level is only left for the Hall of Fame through the name
+// entry, and the name entry only by confirming a name.
+var transitions = map[GameState][]GameState{
+	StateStarting:          {StatePlaying},
+	StatePlaying:           {StatePlaying, StateGameOver, StatePaused},
+	StatePaused:            {StatePlaying},
+	StateGameOver:          {StatePlaying, StateEnteringHighScore},
+	StateEnteringHighScore: {StateHallOfFame},
+	StateHallOfFame:        {StatePlaying},
+}
+
+// canTransition reports whether the game may go from one state to another.
+func canTransition(from, to GameState) bool {
+	if to == StateStarting || to == StateLoading || from == StateLoading {
+		return true
+	}
+	for _, next := range transitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// setState moves the game to state, with the side effects of leaving the
+// current state and entering the new one, and tells the state change hooks.
+// An illegal jump is logged and refused. Assumes the write lock is held.
+func (g *Game) setState(state GameState) bool {
+	old := g.CurrentState
+	if !canTransition(old, state) {
+		log.Printf("Warning: refusing illegal game state change %d -> %d", old, state)
+		return false
+	}
+	g.onLeave(old)
+	g.CurrentState = state
+	g.onEnter(state)
+	if old != state {
+		for _, fn := range g.hooks.stateChange {
+			fn(old, state)
+		}
+	}
+	return true
+}
+
+// onLeave runs the side effects of leaving a state.
+// Assumes the write lock is held.
+func (g *Game) onLeave(state GameState) {
+	switch state {
+	case StateLoading:
+		g.lastUpdateTime = g.clock.Now() // Not a tick as long as the loading
+	case StatePaused:
+		// Slow motion runs on the wall clock, it picks up where it was
+		if g.slowMotionUntil.After(g.pausedAt) {
+			g.slowMotionUntil = g.slowMotionUntil.Add(g.clock.Now().Sub(g.pausedAt))
+		}
+	}
+}
+
+// onEnter runs the side effects of entering a state, the same
+// state again included, e.g. for a level started over.
+// Assumes the write lock is held.
+func (g *Game) onEnter(state GameState) {
+	switch state {
+	case StatePlaying:
+		g.lastUpdateTime = g.clock.Now() // Time spent elsewhere doesn't count as a move
+	case StatePaused:
+		g.pausedAt = g.clock.Now()
+		g.stopSounds()
+	case StateGameOver:
+		g.playGameOver()
+	case StateEnteringHighScore, StateHallOfFame:
+		g.playerNameInput = []rune{}
+	}
+}
@@ -0,0 +1,90 @@
+package game
+
+// RunStats sums up the run being played, for the results screen.
+type RunStats struct {
+	Bounces    int
+	Catches    int
+	Misses     int     // Clicks that caught nothing
+	Elapsed    float64 // Simulated seconds since the level started
+	BestCombo  int     // Most catches in a row without a miss
+	BestChain  int     // Highest multiplier of a chain of quick catches, see model.HallOfFameRules.Chains
+	ChainBonus int     // Bounces earned by chains
+	Pacmans    []PacmanStats
+}
+
+// PacmanStats is one Pacman's part of a run.
+type PacmanStats struct {
+	ID       int
+	X, Y     float64 // Where it is, or was caught
+	Bounces  int
+	Caught   bool
+	CaughtAt float64 // Simulated seconds into the run, 0 if not caught during it
+}
+
+// Accuracy is the share of clicks that caught a Pacman, 1 without clicks.
+func (s RunStats) Accuracy() float64 {
+	if s.Catches+s.Misses == 0 {
+		return 1
+	}
+	return float64(s.Catches) / float64(s.Catches+s.Misses)
+}
+
+// runCounters are the statistics kept as the run is played.
+type runCounters struct {
+	catches, misses  int
+	combo, bestCombo int
+
+	// Chains, see countChain
+	chain, bestChain int
+	chainBonus       int
+	lastCatchAt      float64 // Simulated seconds
+}
+
+// totals returns the run's statistics without the per-Pacman breakdown,
+// cheap enough to check every tick. Assumes the lock is held.
+func (g *Game) totals() RunStats {
+	return RunStats{
+		Bounces:    g.TotalBounces,
+		Catches:    g.counters.catches,
+		Misses:     g.counters.misses,
+		Elapsed:    g.simTime,
+		BestCombo:  g.counters.bestCombo,
+		BestChain:  g.counters.bestChain,
+		ChainBonus: g.counters.chainBonus,
+	}
+}
+
+// countCatch adds a catch to the run's statistics and returns its chain
+// multiplier, see countChain. Assumes the write lock is held.
+func (g *Game) countCatch(p *Pacman) int {
+	g.counters.catches++
+	g.counters.combo++
+	g.counters.bestCombo = max(g.counters.bestCombo, g.counters.combo)
+	p.mu.Lock()
+	p.caughtAt = g.simTime
+	p.mu.Unlock()
+	return g.countChain()
+}
+
+// countMiss adds a click that caught nothing to the run's statistics.
+// Assumes the write lock is held.
+func (g *Game) countMiss() {
+	g.counters.misses++
+	g.counters.combo = 0
+	g.counters.chain = 0
+}
+
+// Stats returns the statistics of the run being played or just finished.
+func (g *Game) Stats() RunStats {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	s := g.totals()
+	s.Pacmans = make([]PacmanStats, len(g.Pacmans))
+	for i, p := range g.Pacmans {
+		p.mu.Lock()
+		s.Pacmans[i] = PacmanStats{p.ID, p.PosX, p.PosY, p.Bounces, p.IsStopped, p.caughtAt}
+		p.mu.Unlock()
+	}
+	return s
+}
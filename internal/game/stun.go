@@ -0,0 +1,54 @@
+package game
+
+import "time"
+
+// Two-stage catch, see SetTwoStageCatch.
+const (
+	StunDuration = 2 * time.Second // How long a stunned Pacman waits to be grabbed
+	StunSpeedup  = 1.25            // Speed factor of a Pacman that broke free
+)
+
+// SetTwoStageCatch turns on the two-stage catch: the first click stuns a
+// Pacman in place for StunDuration, and only a second click within that
+// time catches it. A Pacman that isn't grabbed in time resumes StunSpeedup
+// times faster. The one-switch mode still catches in one go.
+func (g *Game) SetTwoStageCatch(enabled bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.twoStageCatch = enabled
+}
+
+// Stun holds the Pacman in place for d seconds. Returns false if it's
+// stopped or already stunned.
+func (p *Pacman) Stun(d float64) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.IsStopped || p.stunned > 0 {
+		return false
+	}
+	p.stunned = d
+	return true
+}
+
+// Stunned reports whether the Pacman is stunned and waiting to be grabbed.
+func (p *Pacman) Stunned() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.stunned > 0
+}
+
+// updateStun counts a stun down by dt seconds, speeding the Pacman up when
+// it breaks free. Returns true while it's still stunned.
+// Assumes the Pacman's lock is held.
+func (p *Pacman) updateStun(dt float64) bool {
+	if p.stunned <= 0 {
+		return false
+	}
+	p.stunned -= dt
+	if p.stunned > 0 {
+		return true
+	}
+	p.stunned = 0
+	p.Speed *= StunSpeedup
+	return false
+}
@@ -0,0 +1,82 @@
+package game
+
+import "time"
+
+// One-switch mode: a highlight cycles through the running Pacmans and a
+// single key or button catches the highlighted one, so the game can be
+// played with a switch input device.
+
+// SetSwitchScan turns on one-switch mode, moving the highlight to the next
+// running Pacman every interval. Zero turns it off.
+func (g *Game) SetSwitchScan(interval time.Duration) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.switchScan = interval
+	g.resetHighlight()
+}
+
+// Highlighted returns the Pacman the highlight is on, false if there is none
+// (one-switch mode is off, or no Pacman is running).
+func (g *Game) Highlighted() (PacmanDrawData, bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	if g.switchScan <= 0 || g.highlighted < 0 || g.highlighted >= len(g.Pacmans) {
+		return PacmanDrawData{}, false
+	}
+	var data PacmanDrawData
+	data.PosX, data.PosY, data.Radius, data.AnimFrame, data.IsStopped = g.Pacmans[g.highlighted].GetData()
+	if data.IsStopped {
+		return PacmanDrawData{}, false
+	}
+	return data, true
+}
+
+// CatchHighlighted catches the highlighted Pacman, the one-switch
+// counterpart of HandleClick. The highlight moves on right away.
+func (g *Game) CatchHighlighted() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.CurrentState != StatePlaying || g.switchScan <= 0 || g.highlighted < 0 || g.highlighted >= len(g.Pacmans) {
+		return
+	}
+	g.catch(g.Pacmans[g.highlighted])
+	g.advanceHighlight(g.clock.Now())
+}
+
+// resetHighlight starts the cycle over on the first running Pacman.
+// Assumes the write lock is held.
+func (g *Game) resetHighlight() {
+	g.highlighted = -1
+	g.advanceHighlight(g.clock.Now())
+}
+
+// updateHighlight moves the highlight on when its time is up, or right away
+// if its Pacman stopped. Assumes the write lock is held.
+func (g *Game) updateHighlight(now time.Time) {
+	if g.switchScan <= 0 {
+		return
+	}
+	if g.highlighted >= 0 && g.highlighted < len(g.Pacmans) && !g.Pacmans[g.highlighted].Stopped() &&
+		now.Sub(g.highlightedAt) < g.switchScan {
+		return
+	}
+	g.advanceHighlight(now)
+}
+
+// advanceHighlight moves the highlight to the next running Pacman, -1 if
+// there is none. Assumes the write lock is held.
+func (g *Game) advanceHighlight(now time.Time) {
+	g.highlightedAt = now
+	for i := 1; i <= len(g.Pacmans); i++ {
+		next := (g.highlighted + i) % len(g.Pacmans)
+		if next < 0 {
+			next += len(g.Pacmans)
+		}
+		if !g.Pacmans[next].Stopped() {
+			g.highlighted = next
+			return
+		}
+	}
+	g.highlighted = -1
+}
@@ -0,0 +1,28 @@
+package game
+
+import "image/color"
+
+// LevelTheme is the colors a level asks to be drawn with, declared in its
+// level file (see config.LoadLevelConfig). Unset colors are zero, keeping
+// the usual ones.
+type LevelTheme struct {
+	Background color.RGBA   // In place of the palette's background
+	Accent     color.RGBA   // Of the HUD
+	Tints      []color.RGBA // Cycled through by the Pacmans, multiplying the sprites' colors
+}
+
+// LevelTheme returns the colors of the loaded level.
+func (g *Game) LevelTheme() LevelTheme {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.Theme
+}
+
+// tint returns the color the level's theme tints a Pacman with, zero for none.
+// Assumes the lock is held.
+func (g *Game) tint(p *Pacman) color.RGBA {
+	if len(g.Theme.Tints) == 0 {
+		return color.RGBA{}
+	}
+	return g.Theme.Tints[p.ID%len(g.Theme.Tints)]
+}
@@ -0,0 +1,154 @@
+// Package ghost records where the Pacmans were and where the player clicked
+// during a run, so the best run of a level can be replayed as a translucent
+// "ghost" to race against.
+package ghost
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/game"
+)
+
+// Version of the ghost file format.
+const Version = 1
+
+// SampleInterval is how often Pacman positions are recorded.
+const SampleInterval = 100 * time.Millisecond
+
+// Run is a recorded run of a level.
+type Run struct {
+	Version  int       `json:"version"`
+	Level    int       `json:"level"`
+	Bounces  int       `json:"bounces"`
+	Recorded time.Time `json:"recorded"`
+	Frames   []Frame   `json:"frames"` // In time order
+	Clicks   []Click   `json:"clicks"` // In time order
+}
+
+// Frame is the running Pacmans at one point of a run.
+type Frame struct {
+	T       float64  `json:"t"` // Seconds since the level started
+	Pacmans []Circle `json:"pacmans"`
+}
+
+// Circle is a Pacman's position and radius.
+type Circle struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+	R float64 `json:"r"`
+}
+
+// Click is where and when the player clicked.
+type Click struct {
+	T float64 `json:"t"`
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+// At returns the running Pacmans at t seconds into the run, those of the
+// last frame at or before t. False once the run is over.
+func (r *Run) At(t float64) ([]Circle, bool) {
+	i := sort.Search(len(r.Frames), func(i int) bool { return r.Frames[i].T > t })
+	if i == 0 || i == len(r.Frames) {
+		return nil, false
+	}
+	return r.Frames[i-1].Pacmans, true
+}
+
+// ClicksBetween returns the clicks made from t0 to t1 seconds into the run.
+func (r *Run) ClicksBetween(t0, t1 float64) []Click {
+	from := sort.Search(len(r.Clicks), func(i int) bool { return r.Clicks[i].T >= t0 })
+	to := sort.Search(len(r.Clicks), func(i int) bool { return r.Clicks[i].T > t1 })
+	return r.Clicks[from:max(from, to)]
+}
+
+// Recorder records a run as it's played.
+type Recorder struct {
+	run        Run
+	lastSample float64
+}
+
+// NewRecorder starts recording a run of level.
+func NewRecorder(level int) *Recorder {
+	return &Recorder{run: Run{Version: Version, Level: level}, lastSample: -1}
+}
+
+// Sample records the running Pacmans at t seconds into the run, at most
+// once per SampleInterval.
+func (rec *Recorder) Sample(t float64, pacmans []game.PacmanDrawData) {
+	if rec.lastSample >= 0 && t-rec.lastSample < SampleInterval.Seconds() {
+		return
+	}
+	rec.lastSample = t
+	frame := Frame{T: t, Pacmans: []Circle{}}
+	for _, p := range pacmans {
+		if !p.IsStopped {
+			frame.Pacmans = append(frame.Pacmans, Circle{p.PosX, p.PosY, p.Radius})
+		}
+	}
+	rec.run.Frames = append(rec.run.Frames, frame)
+}
+
+// Click records a click at t seconds into the run.
+func (rec *Recorder) Click(t, x, y float64) {
+	rec.run.Clicks = append(rec.run.Clicks, Click{t, x, y})
+}
+
+// Finish ends the recording at t seconds, when the last Pacman was caught.
+func (rec *Recorder) Finish(t float64, bounces int) *Run {
+	rec.lastSample = -1
+	rec.Sample(t, nil) // An empty last frame: every Pacman is caught
+	rec.run.Bounces = bounces
+	rec.run.Recorded = time.Now().UTC()
+	return &rec.run
+}
+
+// Load reads a recorded run.
+func Load(path string) (*Run, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var run Run
+	if err := json.Unmarshal(data, &run); err != nil {
+		return nil, fmt.Errorf("invalid ghost file %s: %w", path, err)
+	}
+	if run.Version > Version {
+		return nil, fmt.Errorf("ghost file %s has version %d, newer than supported version %d", path, run.Version, Version)
+	}
+	return &run, nil
+}
+
+// Save writes a run to path through a temporary file renamed into place,
+// creating its directory if needed.
+func Save(run *Run, path string) error {
+	data, err := json.Marshal(run)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("could not create directory for %s: %w", path, err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("error creating %s: %w", path, err)
+	}
+	defer os.Remove(tmp.Name()) // No-op once renamed
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("error writing %s: %w", path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("error writing %s: %w", path, err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("error replacing %s: %w", path, err)
+	}
+	return nil
+}
@@ -6,8 +6,12 @@ import (
 	_ "image/png" // Import for PNG decoding side effects
 	"log"
 	"os"
+	"slices"
+	"sync"
+	"time"
 
 	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/audio" // Adjust path
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/game"
 	"github.com/hajimehoshi/ebiten/v2"
 )
 
@@ -15,51 +19,105 @@ import (
 type Assets struct {
 	PacmanFrames []*ebiten.Image
 	AudioManager *audio.AudioManager
+	Shaders      *Shaders // Nil if they didn't compile, which turns the effects off
 	// Add fonts later if needed
 	// Font font.Face
 }
 
-// LoadAssets loads all required resources.
-func LoadAssets() (*Assets, error) {
-	assets := &Assets{
-		PacmanFrames: make([]*ebiten.Image, 2), // 2 frames for mouth animation
+// AssetProgress reports an asset done loading, see LoadAssets.
+type AssetProgress struct {
+	Asset       string // File path, or name of a synthesized sound
+	Err         error  // Why it failed to load, the game goes on without it
+	Done, Total int    // Assets done so far, failed ones included, out of all of them
+}
+
+// assetJob loads a single asset.
+type assetJob struct {
+	name string
+	load func() error
+}
+
+// LoadAssets loads the images, shaders and sounds concurrently, the sounds
+// into am. Each asset done is sent on progress, if it isn't nil, which is
+// closed once they all are. Assets fail soft: one that can't be loaded is
+// logged and left out, and the game draws or plays without it.
+func LoadAssets(am *audio.AudioManager, progress chan<- AssetProgress) *Assets {
+	assets := &Assets{AudioManager: am}
+	frames := make([]*ebiten.Image, 2) // 2 frames for mouth animation
+
+	// --- Images ---
+	// Without them Pacmans are drawn as vector shapes, see EbitenGame.SetTheme
+	var jobs []assetJob
+	for i := range frames {
+		path := fmt.Sprintf("assets/images/pacman-%d.png", i)
+		jobs = append(jobs, assetJob{path, func() (err error) {
+			frames[i], err = loadImage(path)
+			return err
+		}})
 	}
+	jobs = append(jobs, assetJob{"shaders", func() (err error) {
+		assets.Shaders, err = loadShaders()
+		return err
+	}})
 
-	// --- Load Images ---
-	var err error
-	assets.PacmanFrames[0], err = loadImage("assets/images/pacman-0.png")
-	if err != nil {
-		return nil, fmt.Errorf("failed to load pacman-0.png: %w", err)
+	// --- Sounds ---
+	// Loaded even if the audio failed to start - LoadSound checks initialization status
+	for _, sound := range []struct{ name, path string }{
+		{"pacman_death", "assets/audio/pacman_death.wav"},
+		{game.SoundGameOver, "assets/audio/level_up.wav"},
+	} {
+		jobs = append(jobs, assetJob{sound.path, func() error { return am.LoadSound(sound.name, sound.path) }})
 	}
-	assets.PacmanFrames[1], err = loadImage("assets/images/pacman-1.png")
-	if err != nil {
-		return nil, fmt.Errorf("failed to load pacman-1.png: %w", err)
+	// Audio cues are synthesized: short beeps told apart by pitch, see game.SetAudioCues
+	for _, cue := range []struct {
+		name string
+		freq float64
+	}{
+		{game.SoundCueWall, 660},
+		{game.SoundCueWallTop, 990},
+		{game.SoundCueWallBottom, 440},
+	} {
+		jobs = append(jobs, assetJob{cue.name, func() error { return am.LoadTone(cue.name, cue.freq, 120*time.Millisecond) }})
 	}
-	log.Println("Loaded Pac-Man images.")
+	// Add other sounds: title_game, pacman_move (if desired)
 
-	// --- Initialize and Load Audio ---
-	assets.AudioManager, err = audio.NewAudioManager()
-	if err != nil {
-		// Non-fatal error, audio manager handles internal state
-		log.Printf("Audio Manager initialization partially failed: %v", err)
-		// Continue without audio or with limited audio functionality
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex // Keeps progress in order
+		done int
+	)
+	for _, job := range jobs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := job.load()
+			if err != nil {
+				log.Printf("Warning: failed to load %s: %v", job.name, err)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			done++
+			if progress != nil {
+				progress <- AssetProgress{Asset: job.name, Err: err, Done: done, Total: len(jobs)}
+			}
+		}()
+	}
+	wg.Wait()
+	if progress != nil {
+		close(progress)
 	}
 
-	// Load sounds even if init failed - LoadSound checks initialization status
-	err = assets.AudioManager.LoadSound("pacman_death", "assets/audio/pacman_death.wav")
-	if err != nil {
-		log.Printf("Warning: failed to load pacman_death sound: %v", err)
+	if !slices.Contains(frames, nil) {
+		assets.PacmanFrames = frames
+		log.Println("Loaded Pac-Man images.")
+	} else {
+		log.Println("Warning: drawing Pac-Man as a vector shape without its images")
 	}
-	err = assets.AudioManager.LoadSound("level_up", "assets/audio/level_up.wav") // Example: use for game over
-	if err != nil {
-		log.Printf("Warning: failed to load level_up sound: %v", err)
+	if assets.Shaders == nil {
+		log.Println("Warning: shader effects disabled")
 	}
-	// Add other sounds: title_game, pacman_move (if desired)
-	// err = assets.AudioManager.LoadSound("title_game", "assets/audio/title_game.wav")
-	// if err != nil { log.Printf("Warning: failed to load title_game sound: %v", err) }
-
-	log.Println("Assets loaded successfully.")
-	return assets, nil
+	log.Println("Assets loaded.")
+	return assets
 }
 
 // loadImage is a helper function to load an ebiten.Image from a file path.
@@ -76,3 +134,44 @@ func loadImage(path string) (*ebiten.Image, error) {
 	}
 	return ebiten.NewImageFromImage(img), nil
 }
+
+// Music settings, see EbitenGame.SetMusic.
+const (
+	MusicOff     = "off"
+	MusicCycle   = "cycle"   // The tracks in order of their file names, over and over
+	MusicShuffle = "shuffle" // A new random order every round
+)
+
+// MusicModes lists every music setting.
+var MusicModes = []string{MusicOff, MusicCycle, MusicShuffle}
+
+// MusicDir holds the music tracks, WAV files titled after their names.
+const MusicDir = "assets/audio/music"
+
+// MenuMusic loops on the start screen.
+const MenuMusic = "assets/audio/title_theme.wav"
+
+// SetMusic plays the tracks in dir during levels, in one of MusicModes, and
+// MenuMusic on the start screen. ] and [ skip between the tracks.
+func (eg *EbitenGame) SetMusic(dir, mode string) error {
+	switch mode {
+	case MusicOff:
+		eg.controller.Music = nil
+		return nil
+	case MusicCycle, MusicShuffle:
+	default:
+		return fmt.Errorf("unknown music setting %q, must be one of %v", mode, MusicModes)
+	}
+	tracks, err := audio.LoadPlaylist(dir)
+	if err != nil {
+		return err
+	}
+	if eg.Assets == nil || eg.Assets.AudioManager == nil {
+		return nil
+	}
+	eg.Assets.AudioManager.SetPlaylist(tracks, mode == MusicShuffle)
+	eg.Assets.AudioManager.SetMenuMusic(MenuMusic)
+	eg.controller.Music = eg.Assets.AudioManager
+	log.Printf("Loaded %d music tracks from %s", len(tracks), dir)
+	return nil
+}
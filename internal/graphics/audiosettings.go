@@ -0,0 +1,174 @@
+package graphics
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/audio"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/frontend"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/game"
+)
+
+// AudioSettings are the sound output and volumes, in percent, kept across
+// sessions.
+type AudioSettings struct {
+	Device       string `json:"device,omitempty"` // ID of one of audio.Devices, "" for the system default
+	Master       int    `json:"master"`
+	Music        int    `json:"music"`
+	Effects      int    `json:"effects"`
+	MasterMuted  bool   `json:"master_muted,omitempty"`
+	MusicMuted   bool   `json:"music_muted,omitempty"`
+	EffectsMuted bool   `json:"effects_muted,omitempty"`
+}
+
+// LoadAudioSettings reads the audio settings file. A missing file yields
+// every channel at full volume.
+func LoadAudioSettings(path string) (AudioSettings, error) {
+	s := AudioSettings{Master: 100, Music: 100, Effects: 100}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return s, fmt.Errorf("error reading audio settings %s: %w", path, err)
+	}
+	if err := json.Unmarshal(data, &s); err != nil {
+		return s, fmt.Errorf("error decoding audio settings %s: %w", path, err)
+	}
+	for _, volume := range []*int{&s.Master, &s.Music, &s.Effects} {
+		*volume = max(0, min(100, *volume))
+	}
+	return s, nil
+}
+
+// Save writes the audio settings file.
+func (s AudioSettings) Save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("could not create audio settings directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("error writing audio settings %s: %w", path, err)
+	}
+	return nil
+}
+
+// volumes converts the settings to the audio manager's levels.
+func (s AudioSettings) volumes() audio.Volumes {
+	return audio.Volumes{
+		Master: float64(s.Master) / 100, Music: float64(s.Music) / 100, Effects: float64(s.Effects) / 100,
+		MasterMuted: s.MasterMuted, MusicMuted: s.MusicMuted, EffectsMuted: s.EffectsMuted,
+	}
+}
+
+// audioSettingsOf converts the audio manager's levels to settings.
+func audioSettingsOf(v audio.Volumes) AudioSettings {
+	percent := func(volume float64) int { return int(math.Round(volume * 100)) }
+	return AudioSettings{
+		Master: percent(v.Master), Music: percent(v.Music), Effects: percent(v.Effects),
+		MasterMuted: v.MasterMuted, MusicMuted: v.MusicMuted, EffectsMuted: v.EffectsMuted,
+	}
+}
+
+// mixer implements frontend.Mixer with the audio manager's volumes.
+type mixer struct {
+	path  string // Where the settings are kept
+	audio *audio.AudioManager
+}
+
+// SetAudioSettings lets the player set the sound volumes from the audio
+// settings page, kept at path, and restores the saved ones.
+func (eg *EbitenGame) SetAudioSettings(path string) {
+	if eg.Assets == nil || eg.Assets.AudioManager == nil {
+		return
+	}
+	settings, err := LoadAudioSettings(path)
+	if err != nil {
+		log.Printf("Using the default audio settings: %v", err)
+	}
+	am := eg.Assets.AudioManager
+	am.SetVolumes(settings.volumes())
+	if settings.Device != "" {
+		if err := am.SelectDevice(settings.Device); err != nil {
+			log.Printf("Using the default audio output: %v", err)
+		}
+	}
+	eg.controller.Mixer = &mixer{path: path, audio: am}
+}
+
+func (m *mixer) Volume(channel int) (int, bool) {
+	s := audioSettingsOf(m.audio.Volumes())
+	switch channel {
+	case frontend.ChannelMusic:
+		return s.Music, s.MusicMuted
+	case frontend.ChannelEffects:
+		return s.Effects, s.EffectsMuted
+	default:
+		return s.Master, s.MasterMuted
+	}
+}
+
+func (m *mixer) SetVolume(channel, percent int, muted bool) {
+	s := audioSettingsOf(m.audio.Volumes())
+	switch channel {
+	case frontend.ChannelMusic:
+		s.Music, s.MusicMuted = percent, muted
+	case frontend.ChannelEffects:
+		s.Effects, s.EffectsMuted = percent, muted
+	default:
+		s.Master, s.MasterMuted = percent, muted
+	}
+	m.audio.SetVolumes(s.volumes())
+}
+
+func (m *mixer) Save() error {
+	s := audioSettingsOf(m.audio.Volumes())
+	s.Device = m.audio.Device()
+	return s.Save(m.path)
+}
+
+func (m *mixer) TestSound() {
+	m.audio.PlaySound(game.SoundCatch)
+}
+
+func (m *mixer) Device() string {
+	return m.audio.OutputDevice()
+}
+
+func (m *mixer) Outputs() []string {
+	var names []string
+	for _, d := range audio.Devices() {
+		names = append(names, d.Name)
+	}
+	return names
+}
+
+func (m *mixer) Output() int {
+	id := m.audio.Device()
+	for i, d := range audio.Devices() {
+		if d.ID == id {
+			return i
+		}
+	}
+	return 0
+}
+
+func (m *mixer) SetOutput(output int) error {
+	devices := audio.Devices()
+	if output < 0 || output >= len(devices) {
+		return fmt.Errorf("no audio output %d", output)
+	}
+	return m.audio.SelectDevice(devices[output].ID)
+}
+
+func (m *mixer) RestartOutput() error {
+	return m.audio.Restart()
+}
@@ -0,0 +1,88 @@
+package graphics
+
+import (
+	"image"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/game"
+)
+
+// entityRenderer draws the Pacmans of a frame together: Add queues one and
+// Flush draws the queue. Sprites and vector shapes both take the same draw data.
+type entityRenderer interface {
+	Add(p game.PacmanDrawData, spriteScale float64)
+	Flush(dst *ebiten.Image)
+}
+
+// maxBatchQuads is how many Pacmans one DrawTriangles call can take, as its
+// vertex indices are 16-bit.
+const maxBatchQuads = (math.MaxUint16 + 1) / 4
+
+// entityBatch draws Pacmans with as few DrawTriangles calls as possible
+// instead of a DrawImage each: every animation frame sits in one atlas
+// texture, and the Pacmans queued with Add are drawn together by Flush, one
+// call per maxBatchQuads of them. Its buffers are kept from frame to frame.
+type entityBatch struct {
+	atlas    *ebiten.Image
+	frames   []image.Rectangle // Each animation frame's part of the atlas
+	vertices []ebiten.Vertex   // Four per queued Pacman
+	indices  []uint16          // Two triangles per quad, the same for every call
+}
+
+// newEntityBatch packs the animation frames side by side into an atlas, a
+// pixel apart so that they never bleed into each other.
+func newEntityBatch(frames []*ebiten.Image) *entityBatch {
+	width, height := 0, 0
+	for _, f := range frames {
+		width += f.Bounds().Dx() + 1
+		height = max(height, f.Bounds().Dy())
+	}
+	b := &entityBatch{atlas: ebiten.NewImage(width, height)}
+	x := 0
+	for _, f := range frames {
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Translate(float64(x), 0)
+		b.atlas.DrawImage(f, op)
+		b.frames = append(b.frames, image.Rect(x, 0, x+f.Bounds().Dx(), f.Bounds().Dy()))
+		x += f.Bounds().Dx() + 1
+	}
+	return b
+}
+
+// Add queues a Pacman, its sprite scaled by scale around its position.
+func (b *entityBatch) Add(p game.PacmanDrawData, scale float64) {
+	src := b.frames[p.AnimFrame]
+	scaleX, scaleY := p.Scale()
+	halfW, halfH := float64(src.Dx())*scale*scaleX/2, float64(src.Dy())*scale*scaleY/2
+	left, top := float32(p.PosX-halfW), float32(p.PosY-halfH)
+	right, bottom := float32(p.PosX+halfW), float32(p.PosY+halfH)
+	srcLeft, srcTop := float32(src.Min.X), float32(src.Min.Y)
+	srcRight, srcBottom := float32(src.Max.X), float32(src.Max.Y)
+	red, green, blue := float32(1), float32(1), float32(1)
+	if p.Tint.A != 0 {
+		red, green, blue = float32(p.Tint.R)/0xff, float32(p.Tint.G)/0xff, float32(p.Tint.B)/0xff
+	}
+
+	b.vertices = append(b.vertices,
+		ebiten.Vertex{DstX: left, DstY: top, SrcX: srcLeft, SrcY: srcTop, ColorR: red, ColorG: green, ColorB: blue, ColorA: 1},
+		ebiten.Vertex{DstX: right, DstY: top, SrcX: srcRight, SrcY: srcTop, ColorR: red, ColorG: green, ColorB: blue, ColorA: 1},
+		ebiten.Vertex{DstX: left, DstY: bottom, SrcX: srcLeft, SrcY: srcBottom, ColorR: red, ColorG: green, ColorB: blue, ColorA: 1},
+		ebiten.Vertex{DstX: right, DstY: bottom, SrcX: srcRight, SrcY: srcBottom, ColorR: red, ColorG: green, ColorB: blue, ColorA: 1},
+	)
+}
+
+// Flush draws the queued Pacmans onto dst and empties the queue.
+func (b *entityBatch) Flush(dst *ebiten.Image) {
+	quads := len(b.vertices) / 4
+	for len(b.indices) < 6*min(quads, maxBatchQuads) {
+		i := uint16(len(b.indices) / 6 * 4)
+		b.indices = append(b.indices, i, i+1, i+2, i+1, i+3, i+2)
+	}
+	for start := 0; start < quads; start += maxBatchQuads {
+		n := min(quads-start, maxBatchQuads)
+		dst.DrawTriangles(b.vertices[4*start:4*(start+n)], b.indices[:6*n], b.atlas, &ebiten.DrawTrianglesOptions{})
+	}
+	b.vertices = b.vertices[:0]
+}
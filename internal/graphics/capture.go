@@ -0,0 +1,176 @@
+package graphics
+
+import (
+	"fmt"
+	"image"
+	"image/color/palette"
+	"image/draw"
+	"image/gif"
+	"image/png"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// Scales screenshots and GIFs can be captured at, relative to the logical screen.
+const (
+	MinCaptureScale = 2
+	MaxCaptureScale = 4
+)
+
+const (
+	gifFrameTicks = 3   // Ticks between two GIF frames, 20 frames a second at 60 TPS
+	gifMaxFrames  = 400 // GIF recordings stop by themselves after 20s
+)
+
+// capture holds the settings and the GIF recording of screenshots and GIF export.
+type capture struct {
+	dir   string // Where captures are saved, empty while turned off
+	scale int
+	image *ebiten.Image // Reused to render each capture into
+
+	recording bool
+	ticks     int
+	frames    []*image.Paletted
+	encoding  sync.WaitGroup // GIFs still being written, waited for on exit
+}
+
+// SetCapture turns on screenshots (F12) and GIF recordings (F11 starts and
+// stops one), saved to dir. They're rendered at scale times the logical
+// resolution, one of MinCaptureScale to MaxCaptureScale, whatever the
+// window size. Shader effects are left out of captures.
+func (eg *EbitenGame) SetCapture(dir string, scale int) error {
+	if scale < MinCaptureScale || scale > MaxCaptureScale {
+		return fmt.Errorf("capture scale %d must be between %d and %d", scale, MinCaptureScale, MaxCaptureScale)
+	}
+	eg.capture.dir, eg.capture.scale = dir, scale
+	return nil
+}
+
+// RenderAt renders the current frame at scale times the logical resolution.
+// The image is reused by the next call.
+func (eg *EbitenGame) RenderAt(scale int) *ebiten.Image {
+	w, h := ScreenWidth*scale, ScreenHeight*scale
+	if eg.capture.image == nil || eg.capture.image.Bounds().Dx() != w || eg.capture.image.Bounds().Dy() != h {
+		if eg.capture.image != nil {
+			eg.capture.image.Dispose()
+		}
+		eg.capture.image = ebiten.NewImage(w, h)
+	}
+	eg.capture.image.Clear()
+
+	r := &screenRenderer{screen: eg.capture.image, entities: eg.entities, spriteScale: eg.GameLogic.SpriteScale(), scale: float64(scale),
+		text: &eg.texts, textScale: eg.textScale(), images: &eg.images}
+	eg.controller.Draw(r)
+	r.flush()
+	return eg.capture.image
+}
+
+// updateCapture takes the screenshots and GIF frames asked for this tick.
+func (eg *EbitenGame) updateCapture() {
+	c := &eg.capture
+	if c.dir == "" {
+		return
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyF12) {
+		if path, err := eg.saveScreenshot(); err != nil {
+			log.Printf("Error saving screenshot: %v", err)
+		} else {
+			log.Printf("Screenshot saved to %s", path)
+		}
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyF11) {
+		if c.recording {
+			eg.finishGIF()
+		} else {
+			c.recording, c.ticks = true, 0
+			log.Println("Recording GIF, press F11 to stop")
+		}
+	}
+	if !c.recording {
+		return
+	}
+	if c.ticks%gifFrameTicks == 0 {
+		c.frames = append(c.frames, paletted(eg.RenderAt(c.scale)))
+		if len(c.frames) >= gifMaxFrames {
+			eg.finishGIF()
+		}
+	}
+	c.ticks++
+}
+
+// saveScreenshot saves the current frame as a PNG, returning its path.
+func (eg *EbitenGame) saveScreenshot() (string, error) {
+	img := eg.RenderAt(eg.capture.scale)
+	rgba := image.NewRGBA(img.Bounds())
+	img.ReadPixels(rgba.Pix)
+
+	path, f, err := eg.createCapture("png")
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	if err := png.Encode(f, rgba); err != nil {
+		return "", fmt.Errorf("could not encode %s: %w", path, err)
+	}
+	return path, f.Close()
+}
+
+// finishGIF stops the GIF recording and saves it in the background, as
+// encoding hundreds of large frames takes a while.
+func (eg *EbitenGame) finishGIF() {
+	c := &eg.capture
+	frames := c.frames
+	c.recording, c.frames = false, nil
+	if len(frames) == 0 {
+		return
+	}
+
+	path, f, err := eg.createCapture("gif")
+	if err != nil {
+		log.Printf("Error saving GIF: %v", err)
+		return
+	}
+	eg.capture.encoding.Add(1)
+	go func() {
+		defer eg.capture.encoding.Done()
+		defer f.Close()
+		anim := &gif.GIF{Image: frames, Delay: make([]int, len(frames))}
+		for i := range anim.Delay {
+			anim.Delay[i] = 100 * gifFrameTicks / ebiten.DefaultTPS // In 100ths of a second
+		}
+		if err := gif.EncodeAll(f, anim); err != nil {
+			log.Printf("Error encoding GIF %s: %v", path, err)
+			return
+		}
+		log.Printf("GIF of %d frames saved to %s", len(frames), path)
+	}()
+}
+
+// createCapture creates a file with the given extension in the capture
+// directory, named after the current time.
+func (eg *EbitenGame) createCapture(ext string) (string, *os.File, error) {
+	if err := os.MkdirAll(eg.capture.dir, 0755); err != nil {
+		return "", nil, fmt.Errorf("could not create directory %s: %w", eg.capture.dir, err)
+	}
+	path := filepath.Join(eg.capture.dir, fmt.Sprintf("pacman-%s.%s", time.Now().Format("20060102-150405.000"), ext))
+	f, err := os.Create(path)
+	if err != nil {
+		return "", nil, fmt.Errorf("could not create %s: %w", path, err)
+	}
+	return path, f, nil
+}
+
+// paletted copies img into a GIF frame, dithered to the web-safe palette.
+func paletted(img *ebiten.Image) *image.Paletted {
+	rgba := image.NewRGBA(img.Bounds())
+	img.ReadPixels(rgba.Pix)
+	frame := image.NewPaletted(rgba.Bounds(), palette.WebSafe)
+	draw.FloydSteinberg.Draw(frame, frame.Bounds(), rgba, image.Point{})
+	return frame
+}
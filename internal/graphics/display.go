@@ -0,0 +1,219 @@
+package graphics
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/frontend"
+)
+
+// DisplaySettings are the window settings kept across sessions.
+type DisplaySettings struct {
+	Monitor      string `json:"monitor"`              // Name of the monitor fullscreen uses
+	MonitorIndex int    `json:"monitor_index"`        // Its position, to tell monitors with the same name apart
+	Mode         string `json:"mode"`                 // One of frontend.DisplayModes
+	TextScale    int    `json:"text_scale,omitempty"` // Size of the HUD and menu text in percent, 0 for 100
+
+	Window *WindowGeometry `json:"window,omitempty"` // Where the window was last left in windowed mode
+
+	Fullscreen bool `json:"fullscreen,omitempty"` // Mode of files written before there were modes
+}
+
+// WindowGeometry is the position and size of a window, in device-independent
+// pixels relative to its monitor.
+type WindowGeometry struct {
+	X      int `json:"x"`
+	Y      int `json:"y"`
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+// Clamp keeps the window on a monitor of the given size: no larger than it,
+// no smaller than a quarter of the logical screen, and fully on it.
+func (g WindowGeometry) Clamp(monitorWidth, monitorHeight int) WindowGeometry {
+	g.Width = max(ScreenWidth/4, min(g.Width, monitorWidth))
+	g.Height = max(ScreenHeight/4, min(g.Height, monitorHeight))
+	g.X = max(0, min(g.X, monitorWidth-g.Width))
+	g.Y = max(0, min(g.Y, monitorHeight-g.Height))
+	return g
+}
+
+// LoadDisplaySettings reads the display settings file. A missing file yields
+// windowed mode on the primary monitor.
+func LoadDisplaySettings(path string) (DisplaySettings, error) {
+	var s DisplaySettings
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return s, fmt.Errorf("error reading display settings %s: %w", path, err)
+	}
+	if err := json.Unmarshal(data, &s); err != nil {
+		return s, fmt.Errorf("error decoding display settings %s: %w", path, err)
+	}
+	if s.Mode == "" && s.Fullscreen {
+		s.Mode = frontend.DisplayFullscreen
+	}
+	s.Fullscreen = false
+	if s.TextScale != 0 && (s.TextScale < frontend.MinTextScale || s.TextScale > frontend.MaxTextScale) {
+		log.Printf("Ignoring the text size of %d%% in %s, must be %d to %d", s.TextScale, path, frontend.MinTextScale, frontend.MaxTextScale)
+		s.TextScale = 0
+	}
+	return s, nil
+}
+
+// Save writes the display settings file.
+func (s DisplaySettings) Save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("could not create display settings directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("error writing display settings %s: %w", path, err)
+	}
+	return nil
+}
+
+// window implements frontend.Display with Ebiten's monitor APIs.
+type window struct {
+	path     string // Where the settings are kept
+	settings DisplaySettings
+	geometry *WindowGeometry // Current geometry in windowed mode, see track
+}
+
+// SetDisplaySettings lets the player pick the monitor fullscreen uses from
+// the display settings page, kept at path, and restores the saved choice.
+func (eg *EbitenGame) SetDisplaySettings(path string) {
+	settings, err := LoadDisplaySettings(path)
+	if err != nil {
+		log.Printf("Using the default display settings: %v", err)
+	}
+	w := &window{path: path, settings: settings, geometry: settings.Window}
+	if settings.Mode == "" {
+		w.settings.Mode = frontend.DisplayWindowed
+	}
+	w.switchTo(w.savedMonitor(), w.settings.Mode)
+	eg.controller.Display = w
+	eg.window = w
+}
+
+// SavedWindowGeometry returns where the window was left last session, kept
+// on the current monitor. It's false if there's none or the window covers
+// the monitor anyway.
+func (eg *EbitenGame) SavedWindowGeometry() (WindowGeometry, bool) {
+	if eg.window == nil || eg.window.geometry == nil || eg.window.settings.Mode == frontend.DisplayBorderless {
+		return WindowGeometry{}, false
+	}
+	g := *eg.window.geometry
+	if m := ebiten.Monitor(); m != nil {
+		g = g.Clamp(m.Size())
+	}
+	return g, true
+}
+
+// track notes the window's geometry while it's a normal window, so it can
+// be saved on exit; there's no event for the window being moved or resized.
+func (w *window) track() {
+	if w.settings.Mode != frontend.DisplayWindowed || ebiten.IsFullscreen() {
+		return
+	}
+	g := &WindowGeometry{}
+	g.X, g.Y = ebiten.WindowPosition()
+	g.Width, g.Height = ebiten.WindowSize()
+	if g.Width > 0 && g.Height > 0 {
+		w.geometry = g
+	}
+}
+
+// save writes the settings along with the window's last geometry.
+func (w *window) save() error {
+	w.settings.Window = w.geometry
+	return w.settings.Save(w.path)
+}
+
+func (w *window) Monitors() []string {
+	var names []string
+	for _, m := range ebiten.AppendMonitors(nil) {
+		names = append(names, m.Name())
+	}
+	return names
+}
+
+func (w *window) Current() (int, string) {
+	return w.savedMonitor(), w.settings.Mode
+}
+
+func (w *window) Apply(monitor int, mode string) error {
+	name := w.switchTo(monitor, mode)
+	w.settings = DisplaySettings{Monitor: name, MonitorIndex: monitor, Mode: mode, TextScale: w.settings.TextScale}
+	return w.save()
+}
+
+func (w *window) TextScale() int {
+	if w.settings.TextScale == 0 {
+		return 100
+	}
+	return w.settings.TextScale
+}
+
+func (w *window) SetTextScale(percent int) {
+	w.settings.TextScale = percent
+}
+
+// savedMonitor finds the saved monitor among the connected ones: by its
+// position if the name there still matches, otherwise by name. It falls
+// back to the primary monitor once the saved one is unplugged.
+func (w *window) savedMonitor() int {
+	monitors := w.Monitors()
+	if i := w.settings.MonitorIndex; i < len(monitors) && monitors[i] == w.settings.Monitor {
+		return i
+	}
+	for i, name := range monitors {
+		if name == w.settings.Monitor {
+			return i
+		}
+	}
+	return 0
+}
+
+// switchTo moves the window to a monitor and puts it in a display mode,
+// returning the monitor's name.
+func (w *window) switchTo(monitor int, mode string) string {
+	monitors := ebiten.AppendMonitors(nil)
+	if len(monitors) == 0 {
+		ebiten.SetFullscreen(mode == frontend.DisplayFullscreen)
+		return ""
+	}
+	if monitor < 0 || monitor >= len(monitors) {
+		monitor = 0
+	}
+	m := monitors[monitor]
+	ebiten.SetMonitor(m)
+
+	ebiten.SetFullscreen(mode == frontend.DisplayFullscreen)
+	ebiten.SetWindowDecorated(mode != frontend.DisplayBorderless)
+	if mode == frontend.DisplayBorderless {
+		// Positions are relative to the window's monitor
+		width, height := m.Size()
+		ebiten.SetWindowSize(width, height)
+		ebiten.SetWindowPosition(0, 0)
+	} else if w.settings.Mode == frontend.DisplayBorderless {
+		if g := w.geometry; g != nil {
+			ebiten.SetWindowSize(g.Width, g.Height)
+			ebiten.SetWindowPosition(g.X, g.Y)
+		} else {
+			ebiten.SetWindowSize(ScreenWidth, ScreenHeight)
+		}
+	}
+	return m.Name()
+}
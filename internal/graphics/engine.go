@@ -4,55 +4,89 @@ import (
 	"fmt"
 	"image/color" // Import color
 	"log"
-	"strconv"
+	"sync/atomic"
+	"time"
+	"unicode/utf8"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil" // For DebugPrint
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
 
 	// Use your actual module path
-	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/config"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/achievements"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/audio"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/frontend"
 	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/game"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/inputlog"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/leaderboard"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/levelgen"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/model"
 	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/persistence"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/scoresync"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/spectate"
 )
 
 const (
-	ScreenWidth  = 640
-	ScreenHeight = 480
-)
-
-// Define colors used
-var (
-	colorBlack    = color.RGBA{0, 0, 0, 255}
-	colorWhite    = color.RGBA{255, 255, 255, 255}
-	colorYellow   = color.RGBA{R: 255, G: 255, B: 0, A: 255} // Define Yellow
-	colorRed      = color.RGBA{R: 255, G: 50, B: 50, A: 255}
-	colorGray     = color.Gray{Y: 150}
-	colorDarkBlue = color.RGBA{0, 0, 10, 255}
+	ScreenWidth  = frontend.ScreenWidth
+	ScreenHeight = frontend.ScreenHeight
 )
 
 // EbitenGame implements ebiten.Game interface and manages the game loop.
+// It is the Ebiten frontend: it polls input and renders through the shared frontend.Controller.
 type EbitenGame struct {
-	GameLogic *game.Game
-	Assets    *Assets
+	GameLogic  *game.Game
+	Assets     *Assets // Without images or shaders until loaded, see pollLoading
+	controller *frontend.Controller
+	loading    *assetLoading    // Non-nil while the assets load
+	theme      string           // See SetTheme
+	entities   entityRenderer   // Draws every Pacman of a frame at once, see SetTheme
+	shadersOn  bool             // See SetShaders
+	crt        bool             // See SetCRT
+	offscreen  [2]*ebiten.Image // The frame between its shader effects, see drawEffects
+
+	dynamicResolution bool // See SetDynamicResolution
+	resolution        resolutionScaler
+	world             *ebiten.Image // The frame at the lowered resolution
+
+	capture capture    // See SetCapture
+	window  *window    // See SetDisplaySettings
+	texts   textCache  // Text drawn larger, see textScale
+	images  imageCache // Images the frontend draws, see screenRenderer.DrawImage
+	ime     imeInput   // Name entry, see pollText
+
+	layoutMode                string        // See SetLayoutMode
+	windowWidth, windowHeight int           // Screen size returned by Layout
+	logical                   *ebiten.Image // The frame before it's placed on the window
+
+	quit atomic.Bool // Set from other goroutines, see RequestQuit
 }
 
-// NewEbitenGame creates the main game controller for Ebiten.
+// NewEbitenGame creates the main game controller for Ebiten. The assets
+// load in the background, with the game in StateLoading.
 func NewEbitenGame() (*EbitenGame, error) {
-	assets, err := LoadAssets()
+	audioManager, err := audio.NewAudioManager()
 	if err != nil {
-		return nil, fmt.Errorf("failed to load assets: %w", err)
+		// Non-fatal error, audio manager handles internal state
+		log.Printf("Audio Manager initialization partially failed: %v", err)
 	}
 
-	coreGame := game.NewGame(float64(ScreenWidth), float64(ScreenHeight), assets.AudioManager)
-
-	// Inject persistence function - Use the correct LoadHighScores from persistence
-	game.SetPersistenceFunctions(persistence.LoadHighScores)
+	coreGame := game.NewGame(float64(ScreenWidth), float64(ScreenHeight), audioManager)
 
 	eg := &EbitenGame{
-		GameLogic: coreGame,
-		Assets:    assets,
+		GameLogic:  coreGame,
+		Assets:     &Assets{AudioManager: audioManager},
+		controller: frontend.NewController(coreGame),
+		theme:      ThemeSprites,
+		entities:   &vectorEntities{},
+		shadersOn:  true,
+
+		dynamicResolution: true,
+		layoutMode:        LayoutLetterbox,
 	}
+	eg.controller.Thumbnail = eg.thumbnail
+	eg.loading = startLoading(audioManager)
+	coreGame.StartLoading()
 
 	// Initial state is Starting, let Update handle transition based on input
 	// No need to explicitly load level 0 here if StateStarting handles it
@@ -60,196 +94,397 @@ func NewEbitenGame() (*EbitenGame, error) {
 	return eg, nil
 }
 
-// Update proceeds the game state.
-func (eg *EbitenGame) Update() error {
-	// Use the game's method to get state safely
-	state, _, currentLevel := eg.GameLogic.GetGameState()
+// SetDataDir sets the directory saves and high scores are kept in.
+func (eg *EbitenGame) SetDataDir(dir string) {
+	eg.GameLogic.SetDataDir(dir)
+}
 
-	// --- Global Input Handling ---
-	if inpututil.IsKeyJustPressed(ebiten.KeyQ) {
-		return fmt.Errorf("user requested quit")
+// SetTheme picks how Pacmans are drawn, one of Themes. The sprite theme
+// falls back to vector shapes when the images couldn't be loaded, or until
+// they are.
+func (eg *EbitenGame) SetTheme(theme string) error {
+	switch theme {
+	case ThemeSprites:
+		if eg.Assets.PacmanFrames != nil {
+			eg.entities = newEntityBatch(eg.Assets.PacmanFrames)
+		}
+	case ThemeMinimal:
+		eg.entities = &vectorEntities{}
+	default:
+		return fmt.Errorf("unknown theme %q, must be one of %v", theme, Themes)
 	}
+	eg.theme = theme
+	return nil
+}
 
-	// --- Input based on Game State ---
-	switch state {
-	case game.StatePlaying: // **Use game. prefix**
-		if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
-			x, y := ebiten.CursorPosition()
-			eg.GameLogic.HandleClick(float64(x), float64(y))
-		}
-		if inpututil.IsKeyJustPressed(ebiten.KeyS) {
-			// Pass the actual SaveGame function from persistence
-			err := eg.GameLogic.RequestSaveGame(persistence.SaveGame)
-			if err != nil {
-				log.Printf("Save failed: %v", err)
-			} else {
-				log.Println("Game Saved (press L to load)")
-			}
-		}
-		if inpututil.IsKeyJustPressed(ebiten.KeyL) {
-			if currentLevel >= 0 {
-				savePath := fmt.Sprintf("assets/saves/savegame_%d.txt", currentLevel)
-				// Pass the actual LoadGame function from persistence
-				err := eg.GameLogic.RequestLoadSavedGame(savePath, persistence.LoadGame)
-				if err != nil {
-					log.Printf("Load failed: %v", err)
-				} else {
-					log.Println("Game Loaded.")
-				}
-			} else {
-				log.Println("Cannot load: No level currently active to determine save file.")
-			}
-		}
-		if inpututil.IsKeyJustPressed(ebiten.KeyF1) {
-			eg.loadLevel(0)
-		}
-		if inpututil.IsKeyJustPressed(ebiten.KeyF2) {
-			eg.loadLevel(1)
-		}
-		if inpututil.IsKeyJustPressed(ebiten.KeyF3) {
-			eg.loadLevel(2)
-		}
+// SetSpriteScale enlarges every Pacman by scale from the next level on, see game.Game.SetSpriteScale.
+func (eg *EbitenGame) SetSpriteScale(scale float64) error {
+	return eg.GameLogic.SetSpriteScale(scale)
+}
 
-		eg.GameLogic.Update()
+// SetAudioCues turns on positional sound cues warning of wall hits, see game.Game.SetAudioCues.
+func (eg *EbitenGame) SetAudioCues(enabled bool) {
+	eg.GameLogic.SetAudioCues(enabled)
+}
 
-	case game.StateGameOver: // **Use game. prefix**
-		if inpututil.IsKeyJustPressed(ebiten.KeyEnter) || inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
-			if currentLevel >= 0 {
-				eg.loadLevel(currentLevel)
-			} else {
-				eg.loadLevel(0) // Default fallback
-			}
-		}
+// SetPalette picks the palette of the background and the HUD, see frontend.Controller.SetPalette.
+func (eg *EbitenGame) SetPalette(name string) error {
+	return eg.controller.SetPalette(name)
+}
 
-	case game.StateEnteringHighScore: // **Use game. prefix**
-		// **Use ebiten.InputChars() instead of AppendInputChars**
-		inputChars := ebiten.InputChars()
-		if len(inputChars) > 0 {
-			eg.GameLogic.HandleTextInput(inputChars)
-		}
-		if repeatingKeyPressed(ebiten.KeyBackspace) { // Allow holding backspace
-			eg.GameLogic.HandleBackspace()
-		}
-		if inpututil.IsKeyJustPressed(ebiten.KeyEnter) {
-			// **Pass the actual SaveHighScores function from persistence**
-			eg.GameLogic.HandleEnter(persistence.SaveHighScores)
-		}
+// SetReducedMotion turns off decorative animation and effects, see frontend.Controller.ReducedMotion.
+func (eg *EbitenGame) SetReducedMotion(enabled bool) {
+	eg.controller.ReducedMotion = enabled
+}
+
+// ShowSoundIndicators mirrors sound events on screen, see frontend.Controller.ShowSoundIndicators.
+func (eg *EbitenGame) ShowSoundIndicators() {
+	eg.controller.ShowSoundIndicators()
+}
+
+// EnableOneSwitch makes the game playable with a single switch, see frontend.Controller.EnableOneSwitch.
+func (eg *EbitenGame) EnableOneSwitch(scan time.Duration) {
+	eg.controller.EnableOneSwitch(scan)
+}
+
+// SetPractice turns practice mode on or off, see game.Game.SetPractice.
+func (eg *EbitenGame) SetPractice(enabled bool) {
+	eg.GameLogic.SetPractice(enabled)
+}
+
+// SetHandicap sets the handicap of the player's profile, see game.Game.SetHandicap.
+func (eg *EbitenGame) SetHandicap(h model.Handicap) error {
+	return eg.GameLogic.SetHandicap(h)
+}
+
+// SetTwoStageCatch makes the first click stun a Pacman and the second catch it, see game.Game.SetTwoStageCatch.
+func (eg *EbitenGame) SetTwoStageCatch(enabled bool) {
+	eg.GameLogic.SetTwoStageCatch(enabled)
+}
+
+// SetPowerUps turns the power-ups showing up during play on or off, see game.Game.SetPowerUps.
+func (eg *EbitenGame) SetPowerUps(enabled bool) {
+	eg.GameLogic.SetPowerUps(enabled)
+}
+
+// SetLives gives every level the same lives, see game.Game.SetLives.
+func (eg *EbitenGame) SetLives(n int) {
+	eg.GameLogic.SetLives(n)
+}
+
+// SetArcade turns on the timed arcade mode where caught Pacmans respawn, see game.Game.SetArcade.
+func (eg *EbitenGame) SetArcade(enabled bool) {
+	eg.GameLogic.SetArcade(enabled)
+}
+
+// SetTimeAttack turns on the time attack mode with a countdown per level, see game.Game.SetTimeAttack.
+func (eg *EbitenGame) SetTimeAttack(enabled bool) {
+	eg.GameLogic.SetTimeAttack(enabled)
+}
+
+// SetAccuracyScoring makes missed clicks add penalty bounces to the score, see game.Game.SetAccuracyScoring.
+func (eg *EbitenGame) SetAccuracyScoring(enabled bool) {
+	eg.GameLogic.SetAccuracyScoring(enabled)
+}
+
+// SetShowGhost makes the game draw the best recorded run of each level, see frontend.Controller.ShowGhost.
+func (eg *EbitenGame) SetShowGhost(enabled bool) {
+	eg.controller.ShowGhost = enabled
+}
+
+// SetMotionTrails makes the game draw trails behind fast Pacmans, see frontend.Controller.MotionTrails.
+func (eg *EbitenGame) SetMotionTrails(enabled bool) {
+	eg.controller.MotionTrails = enabled
+}
+
+// SetLeaderboard makes the game submit new high scores to a score server.
+func (eg *EbitenGame) SetLeaderboard(client *leaderboard.Client) {
+	eg.controller.Leaderboard = client
+}
+
+// SetLiveScores makes the game follow leaderboard changes pushed by a score-sync server.
+func (eg *EbitenGame) SetLiveScores(watcher *scoresync.Watcher) {
+	eg.controller.LiveScores = watcher
+}
+
+// SetSpectators makes the game stream its run to a spectator hub.
+func (eg *EbitenGame) SetSpectators(hub *spectate.Hub) {
+	eg.controller.Spectators = hub
+}
+
+// SetAchievements makes the game unlock achievements with the given tracker.
+func (eg *EbitenGame) SetAchievements(tracker *achievements.Tracker) {
+	eg.controller.Achievements = tracker
+}
+
+// SetRecords makes the game keep the personal bests of a profile in records.
+func (eg *EbitenGame) SetRecords(records *persistence.Records) {
+	eg.controller.Records = records
+}
+
+// SetProfile makes the game mark Hall of Fame names as rivals in profile.
+func (eg *EbitenGame) SetProfile(profile *persistence.Profile) {
+	eg.controller.Profile = profile
+}
+
+// SetInputLog makes the game log every click and key action to recorder, which Close closes.
+func (eg *EbitenGame) SetInputLog(recorder *inputlog.Recorder) {
+	eg.controller.InputLog = recorder
+}
+
+// SetTwitchSettings enables the Twitch chat interaction mode, with its settings kept at path.
+func (eg *EbitenGame) SetTwitchSettings(path string) {
+	eg.controller.EnableTwitch(path)
+}
+
+// PlayLevelFile starts the game on a level read from any level file, skipping the start screen.
+func (eg *EbitenGame) PlayLevelFile(path string) error {
+	return eg.controller.PlayLevelFile(path)
+}
+
+// Resume starts the game in the most recent save, skipping the start
+// screen. It reports false if there's none.
+func (eg *EbitenGame) Resume() bool {
+	return eg.controller.Resume()
+}
+
+// Quickplay starts the game on a generated level, skipping the start screen.
+func (eg *EbitenGame) Quickplay(cfg levelgen.Config) error {
+	return eg.controller.Quickplay(cfg)
+}
 
-	case game.StateHallOfFame: // **Use game. prefix**
-		if inpututil.IsKeyJustPressed(ebiten.KeyEnter) || inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
-			eg.loadLevel(0) // Restart level 0 after viewing scores
+// Update proceeds the game state.
+func (eg *EbitenGame) Update() error {
+	if eg.quit.Load() || ebiten.IsWindowBeingClosed() {
+		return frontend.ErrQuit // Close saves the run
+	}
+	eg.pollLoading()
+	if err := eg.controller.Update(eg.PollInput()); err != nil {
+		return err
+	}
+	eg.updateCapture()
+	if eg.window != nil {
+		eg.window.track()
+	}
+	return nil
+}
+
+// PollInput translates this tick's keyboard, mouse and touch state into frontend actions.
+func (eg *EbitenGame) PollInput() frontend.Input {
+	var in frontend.Input
+
+	x, y := eg.logicalCursor(ebiten.CursorPosition())
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		in.Clicked = true
+		in.ClickX, in.ClickY = x, y
+	}
+	in.HasCursor, in.CursorX, in.CursorY = true, x, y
+	// A tap on a touch screen is a click where it touched
+	for _, id := range inpututil.AppendJustPressedTouchIDs(nil) {
+		x, y := eg.logicalCursor(ebiten.TouchPosition(id))
+		in.Clicked = true
+		in.ClickX, in.ClickY = x, y
+		in.CursorX, in.CursorY = x, y
+	}
+
+	keyMap := []struct {
+		key    ebiten.Key
+		action frontend.Key
+	}{
+		{ebiten.KeyEnter, frontend.KeyConfirm},
+		{ebiten.KeyQ, frontend.KeyQuit},
+		{ebiten.KeyS, frontend.KeySave},
+		{ebiten.KeyL, frontend.KeyLoad},
+		{ebiten.KeyF1, frontend.KeyLevel0},
+		{ebiten.KeyF2, frontend.KeyLevel1},
+		{ebiten.KeyF3, frontend.KeyLevel2},
+		{ebiten.KeyEscape, frontend.KeyBack},
+		{ebiten.KeyT, frontend.KeySettings},
+		{ebiten.KeySpace, frontend.KeySwitch},
+		{ebiten.KeyP, frontend.KeyPause},
+		{ebiten.KeyEscape, frontend.KeyPause}, // Backs out of menus too, see KeyBack
+		{ebiten.KeyPeriod, frontend.KeyStep},
+		{ebiten.KeyH, frontend.KeyStats},
+		{ebiten.KeyC, frontend.KeyCampaign},
+		{ebiten.KeyM, frontend.KeyMagnet},
+		{ebiten.KeyD, frontend.KeyDisplay},
+		{ebiten.KeyA, frontend.KeyAudio},
+		{ebiten.KeyArrowUp, frontend.KeyUp},
+		{ebiten.KeyArrowDown, frontend.KeyDown},
+		{ebiten.KeyArrowLeft, frontend.KeyLeft},
+		{ebiten.KeyArrowRight, frontend.KeyRight},
+		{ebiten.KeyBracketRight, frontend.KeyNextTrack},
+		{ebiten.KeyBracketLeft, frontend.KeyPrevTrack},
+	}
+	for _, m := range keyMap {
+		if inpututil.IsKeyJustPressed(m.key) {
+			in.Keys = append(in.Keys, m.action)
 		}
+	}
 
-	case game.StateStarting: // **Use game. prefix**
-		if inpututil.IsKeyJustPressed(ebiten.KeyEnter) || inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
-			err := eg.loadLevel(0) // Load level 0 on Enter/Click
-			if err != nil {
-				log.Printf("Failed to load level 0 on start: %v", err)
-				// Optionally, stay in Starting state or show an error
+	// Gamepads with a standard layout can get around the menus
+	gamepadMap := []struct {
+		button ebiten.StandardGamepadButton
+		action frontend.Key
+	}{
+		{ebiten.StandardGamepadButtonLeftTop, frontend.KeyUp},
+		{ebiten.StandardGamepadButtonLeftBottom, frontend.KeyDown},
+		{ebiten.StandardGamepadButtonLeftLeft, frontend.KeyLeft},
+		{ebiten.StandardGamepadButtonLeftRight, frontend.KeyRight},
+		{ebiten.StandardGamepadButtonRightBottom, frontend.KeyConfirm},
+		{ebiten.StandardGamepadButtonRightRight, frontend.KeyBack},
+		{ebiten.StandardGamepadButtonCenterRight, frontend.KeyPause}, // Start
+	}
+	for _, id := range ebiten.AppendGamepadIDs(nil) {
+		if !ebiten.IsStandardGamepadLayoutAvailable(id) {
+			continue
+		}
+		for _, m := range gamepadMap {
+			if inpututil.IsStandardGamepadButtonJustPressed(id, m.button) {
+				in.Keys = append(in.Keys, m.action)
 			}
 		}
 	}
 
-	return nil
+	if ebiten.IsKeyPressed(ebiten.KeyR) {
+		in.Held = append(in.Held, frontend.KeyRewind)
+	}
+
+	// Typed characters only matter during name entry and in the settings
+	eg.pollText(&in)
+
+	return in
 }
 
 // Draw renders the game screen based on the current state.
-func (eg *EbitenGame) Draw(screen *ebiten.Image) { // **screen is the *ebiten.Image parameter**
-	screen.Fill(colorDarkBlue) // Use defined color
-
-	// **Use game's method to get state safely**
-	state, bounces, level := eg.GameLogic.GetGameState()
-
-	switch state {
-	case game.StateStarting: // **Use game. prefix**
-		// **Pass screen to drawText and use defined colors**
-		drawText(screen, "Catch The Pac-Man!", ScreenWidth/2, ScreenHeight/3, colorWhite, true)
-		drawText(screen, "Press ENTER or Click to Start Level 0", ScreenWidth/2, ScreenHeight/2, colorYellow, true)
-		drawText(screen, "Q=Quit", 10, ScreenHeight-20, colorGray, false)
-
-	case game.StatePlaying, game.StateGameOver: // **Use game. prefix**
-		pacmanData := eg.GameLogic.GetPacmanData()
-		for _, pData := range pacmanData {
-			if !pData.IsStopped {
-				op := &ebiten.DrawImageOptions{}
-				img := eg.Assets.PacmanFrames[pData.AnimFrame]
-				bounds := img.Bounds()
-				w, h := float64(bounds.Dx()), float64(bounds.Dy())
-				op.GeoM.Translate(-w/2, -h/2)
-				op.GeoM.Translate(pData.PosX, pData.PosY)
-				screen.DrawImage(img, op) // **Draw onto screen**
-			}
-		}
+func (eg *EbitenGame) Draw(screen *ebiten.Image) {
+	if eg.layoutMode == LayoutLetterbox {
+		eg.drawFrame(screen)
+		return
+	}
+	frame := eg.logicalImage()
+	eg.drawFrame(frame)
+	op := &ebiten.DrawImageOptions{GeoM: eg.displayGeoM()}
+	if eg.layoutMode == LayoutStretch {
+		op.Filter = ebiten.FilterLinear
+	}
+	screen.DrawImage(frame, op)
+}
 
-		// **Pass screen to drawText and use defined colors**
-		drawText(screen, fmt.Sprintf("Level: %d", level), 10, 20, colorWhite, false)
-		drawText(screen, fmt.Sprintf("Bounces: %d", bounces), ScreenWidth-150, 20, colorWhite, false)
-		drawText(screen, "Click PacMan!", ScreenWidth/2, 20, colorYellow, true)
-		drawText(screen, "S=Save L=Load Q=Quit F1/F2/F3=Level", 10, ScreenHeight-20, colorGray, false)
+// drawFrame renders the logical screen onto screen.
+func (eg *EbitenGame) drawFrame(screen *ebiten.Image) {
+	effects := eg.screenEffects()
+	target := screen
+	if len(effects) > 0 {
+		target = eg.offscreenImage(screen, 0)
+	}
+	scale := 1.0
+	if eg.dynamicResolution {
+		scale = eg.resolution.Frame(time.Now())
+	}
+	r := &screenRenderer{screen: target, entities: eg.entities, spriteScale: eg.GameLogic.SpriteScale(), scale: scale,
+		text: &eg.texts, textScale: eg.textScale(), images: &eg.images}
+	if scale < 1 {
+		r.screen = eg.worldImage(target, scale)
+	}
+	eg.controller.Draw(r)
+	r.flush()
+	if scale < 1 {
+		op := &ebiten.DrawImageOptions{Filter: ebiten.FilterLinear}
+		op.GeoM.Scale(1/scale, 1/scale)
+		target.DrawImage(r.screen, op)
+		r.drawTexts(target)
+	}
+	eg.drawEffects(screen, target, effects)
+}
 
-		if state == game.StateGameOver { // **Use game. prefix**
-			drawText(screen, "GAME OVER!", ScreenWidth/2, ScreenHeight/2-30, colorRed, true)
-			drawText(screen, "Press ENTER or Click to Restart", ScreenWidth/2, ScreenHeight/2+10, colorWhite, true)
-		}
+// screenRenderer implements frontend.Renderer on top of an Ebiten screen image.
+// Entities are batched: they're drawn together when anything else is drawn
+// over them, or at the end of the frame.
+//
+// With a scale below 1 it draws onto a smaller image the frame is upscaled
+// from, see resolutionScaler. Text is kept sharp: it's held back and drawn
+// at full resolution over the upscaled frame with drawTexts. With a scale
+// above 1 it draws a high-resolution capture, see RenderAt.
+type screenRenderer struct {
+	screen      *ebiten.Image
+	entities    entityRenderer
+	spriteScale float64 // Accessibility scale the Pacman radii were enlarged by
+	scale       float64 // Resolution of screen relative to the logical screen
+	texts       []queuedText
+	text        *textCache
+	textScale   float64 // Size of the text relative to the debug font, see EbitenGame.textScale
+	images      *imageCache
+}
 
-	case game.StateEnteringHighScore: // **Use game. prefix**
-		drawText(screen, fmt.Sprintf("Level: %d", level), 10, 20, colorWhite, false)
-		drawText(screen, fmt.Sprintf("Bounces: %d", bounces), ScreenWidth-150, 20, colorWhite, false)
+// queuedText is a DrawText held back until the frame is upscaled.
+type queuedText struct {
+	str    string
+	x, y   float64
+	center bool
+}
 
-		drawText(screen, "New High Score!", ScreenWidth/2, ScreenHeight/2-60, colorYellow, true)
-		drawText(screen, "Enter Your Name:", ScreenWidth/2, ScreenHeight/2-20, colorWhite, true)
+// px converts logical screen coordinates to screen's.
+func (r *screenRenderer) px(v float64) float32 {
+	return float32(v * r.scale)
+}
 
-		// **Use game's method GetHighScoreData safely**
-		_, _, nameInput := eg.GameLogic.GetHighScoreData()
-		drawText(screen, nameInput+"_", ScreenWidth/2, ScreenHeight/2+20, colorWhite, true) // Add underscore cursor
+// flush draws the entities batched so far, keeping them under whatever is drawn next.
+func (r *screenRenderer) flush() {
+	r.entities.Flush(r.screen)
+}
 
-		drawText(screen, "Press ENTER to Confirm", ScreenWidth/2, ScreenHeight/2+60, colorWhite, true)
+func (r *screenRenderer) Fill(clr color.Color) {
+	r.flush()
+	r.screen.Fill(clr)
+}
 
-	case game.StateHallOfFame: // **Use game. prefix**
-		drawText(screen, "Hall of Fame - Level "+strconv.Itoa(level), ScreenWidth/2, 50, colorYellow, true)
+func (r *screenRenderer) DrawEntity(p game.PacmanDrawData) {
+	p.PosX, p.PosY, p.Radius = p.PosX*r.scale, p.PosY*r.scale, p.Radius*r.scale
+	r.entities.Add(p, r.spriteScale*r.scale)
+}
 
-		// **Use game's method GetHighScoreData safely**
-		_, scores, _ := eg.GameLogic.GetHighScoreData()
-		yPos := 100.0
-		for i, score := range scores {
-			rankStr := fmt.Sprintf("%d.", i+1)
-			scoreStr := fmt.Sprintf("%s  -  %d Bounces", score.Name, score.Score)
-			drawText(screen, rankStr, ScreenWidth/3, yPos, colorWhite, false)
-			drawText(screen, scoreStr, ScreenWidth/2+20, yPos, colorWhite, false) // Adjust X slightly for alignment
-			yPos += 30
-		}
+func (r *screenRenderer) DrawRect(x, y, width, height float64, clr color.Color) {
+	r.flush()
+	vector.DrawFilledRect(r.screen, r.px(x), r.px(y), r.px(width), r.px(height), clr, false)
+}
 
-		if len(scores) == 0 {
-			drawText(screen, "No scores yet!", ScreenWidth/2, ScreenHeight/2, colorGray, true)
-		}
+func (r *screenRenderer) DrawRing(x, y, radius float64, clr color.Color) {
+	r.flush()
+	vector.StrokeCircle(r.screen, r.px(x), r.px(y), r.px(radius), r.px(3), clr, true)
+}
 
-		drawText(screen, "Press ENTER or Click to Continue", ScreenWidth/2, ScreenHeight-50, colorWhite, true)
-	}
+func (r *screenRenderer) DrawLine(x1, y1, x2, y2 float64, clr color.Color) {
+	r.flush()
+	vector.StrokeLine(r.screen, r.px(x1), r.px(y1), r.px(x2), r.px(y2), r.px(2), clr, true)
 }
 
-// Layout defines the logical screen size.
-func (eg *EbitenGame) Layout(outsideWidth, outsideHeight int) (int, int) {
-	return ScreenWidth, ScreenHeight
+func (r *screenRenderer) DrawText(str string, x, y float64, clr color.Color, center bool) {
+	r.flush()
+	if r.scale < 1 {
+		r.texts = append(r.texts, queuedText{str, x, y, center})
+		return
+	}
+	r.text.draw(r.screen, str, x, y, center, r.textScale, r.scale)
 }
 
-// Helper function to load a specific level
-func (eg *EbitenGame) loadLevel(level int) error {
-	levelPath := fmt.Sprintf("assets/levels/level_%d.txt", level)
-	// Pass the actual LoadLevelConfig function from config
-	return eg.GameLogic.RequestLoadLevel(level, levelPath, config.LoadLevelConfig)
+// drawTexts draws the text held back while drawing at a lower resolution onto dst.
+func (r *screenRenderer) drawTexts(dst *ebiten.Image) {
+	for _, t := range r.texts {
+		r.text.draw(dst, t.str, t.x, t.y, t.center, r.textScale, 1)
+	}
+	r.texts = nil
 }
 
-// Helper function for drawing text
-// **Added screen parameter**
-func drawText(screen *ebiten.Image, str string, x, y float64, clr color.Color, center bool) {
+// debugPrint writes text with Ebiten's debug font, which is always white.
+func debugPrint(dst *ebiten.Image, str string, x, y float64, center bool) {
 	// Using DebugPrint for simplicity. Replace with text.Draw for fonts later.
 	drawX := x
 	if center {
-		textWidth := float64(len(str) * 6) // Approximate width for DebugPrint font
+		textWidth := float64(utf8.RuneCountInString(str) * 6) // Approximate width for DebugPrint font
 		drawX = x - textWidth/2
 	}
-	// **Use ebitenutil.DebugPrintAt correctly**
-	ebitenutil.DebugPrintAt(screen, str, int(drawX), int(y))
+	ebitenutil.DebugPrintAt(dst, str, int(drawX), int(y))
 }
 
 // repeatingKeyPressed simulates key repeats for keys like backspace.
@@ -269,11 +504,32 @@ func repeatingKeyPressed(key ebiten.Key) bool {
 	return false
 }
 
-// Close is called when the game is about to exit.
+// RequestQuit makes the game loop end on its next tick, as if the player
+// pressed Q. It's safe to call from any goroutine, e.g. on SIGTERM.
+func (eg *EbitenGame) RequestQuit() {
+	eg.quit.Store(true)
+}
+
+// Close is called when the game is about to exit. The level being played is
+// saved and every save still being written is waited for.
 func (eg *EbitenGame) Close() error {
+	eg.controller.SaveOnExit()
+	eg.GameLogic.WaitForSaves()
 	if eg.Assets != nil && eg.Assets.AudioManager != nil {
 		eg.Assets.AudioManager.Close()
 	}
+	eg.controller.Close()
+	eg.capture.encoding.Wait()
+	if eg.window != nil {
+		if err := eg.window.save(); err != nil {
+			log.Printf("Error saving display settings: %v", err)
+		}
+	}
+	if eg.controller.InputLog != nil {
+		if err := eg.controller.InputLog.Close(); err != nil {
+			log.Printf("Error closing input log: %v", err)
+		}
+	}
 	log.Println("EbitenGame closed.")
 	return nil
 }
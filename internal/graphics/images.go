@@ -0,0 +1,67 @@
+package graphics
+
+import (
+	"image"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// maxCachedImages bounds the images kept uploaded by imageCache. Past it
+// they're all dropped, as the ones still shown come back the next frame.
+const maxCachedImages = 32
+
+// Size of the save thumbnails, a quarter of the logical screen.
+const (
+	thumbnailWidth  = ScreenWidth / 4
+	thumbnailHeight = ScreenHeight / 4
+)
+
+// imageCache keeps the images the frontend draws, e.g. save thumbnails,
+// uploaded as Ebiten images for the frames after.
+type imageCache struct {
+	images map[image.Image]*ebiten.Image
+}
+
+// image returns img as an Ebiten image.
+func (c *imageCache) image(img image.Image) *ebiten.Image {
+	if e, ok := c.images[img]; ok {
+		return e
+	}
+	if len(c.images) >= maxCachedImages {
+		for _, e := range c.images {
+			e.Dispose()
+		}
+		c.images = nil
+	}
+	if c.images == nil {
+		c.images = map[image.Image]*ebiten.Image{}
+	}
+	e := ebiten.NewImageFromImage(img)
+	c.images[img] = e
+	return e
+}
+
+func (r *screenRenderer) DrawImage(img image.Image, x, y, width, height float64) {
+	r.flush()
+	e := r.images.image(img)
+	b := e.Bounds()
+	op := &ebiten.DrawImageOptions{Filter: ebiten.FilterLinear}
+	op.GeoM.Scale(width*r.scale/float64(b.Dx()), height*r.scale/float64(b.Dy()))
+	op.GeoM.Translate(x*r.scale, y*r.scale)
+	r.screen.DrawImage(e, op)
+}
+
+// thumbnail captures the frame shown, scaled down to thumbnailWidth by
+// thumbnailHeight, for the save browser. Shader effects are left out.
+func (eg *EbitenGame) thumbnail() image.Image {
+	frame := eg.RenderAt(1)
+	small := ebiten.NewImage(thumbnailWidth, thumbnailHeight)
+	defer small.Dispose()
+	op := &ebiten.DrawImageOptions{Filter: ebiten.FilterLinear}
+	op.GeoM.Scale(float64(thumbnailWidth)/ScreenWidth, float64(thumbnailHeight)/ScreenHeight)
+	small.DrawImage(frame, op)
+
+	img := image.NewRGBA(image.Rect(0, 0, thumbnailWidth, thumbnailHeight))
+	small.ReadPixels(img.Pix)
+	return img
+}
@@ -0,0 +1,80 @@
+package graphics
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Layout modes, how the logical screen maps to the window.
+const (
+	LayoutLetterbox = "letterbox" // Scaled to fit, with bars on the sides or top and bottom
+	LayoutStretch   = "stretch"   // Stretched to fill, ignoring the aspect ratio
+	LayoutInteger   = "integer"   // Scaled by a whole number for sharp pixels, centered
+)
+
+// LayoutModes lists the modes SetLayoutMode accepts.
+var LayoutModes = []string{LayoutLetterbox, LayoutStretch, LayoutInteger}
+
+// SetLayoutMode picks how the logical screen maps to the window, one of LayoutModes.
+func (eg *EbitenGame) SetLayoutMode(mode string) error {
+	switch mode {
+	case LayoutLetterbox, LayoutStretch, LayoutInteger:
+		eg.layoutMode = mode
+	default:
+		return fmt.Errorf("unknown layout mode %q, must be one of %v", mode, LayoutModes)
+	}
+	return nil
+}
+
+// Layout defines the screen size. Letterboxing is left to Ebiten, which
+// fits a fixed logical screen to the window; the other modes take the whole
+// window and place the logical screen on it themselves, see displayGeoM.
+func (eg *EbitenGame) Layout(outsideWidth, outsideHeight int) (int, int) {
+	if eg.layoutMode == LayoutLetterbox || outsideWidth <= 0 || outsideHeight <= 0 {
+		eg.windowWidth, eg.windowHeight = ScreenWidth, ScreenHeight
+	} else {
+		eg.windowWidth, eg.windowHeight = outsideWidth, outsideHeight
+	}
+	return eg.windowWidth, eg.windowHeight
+}
+
+// displayGeoM maps the logical screen onto the screen Layout asked for.
+func (eg *EbitenGame) displayGeoM() ebiten.GeoM {
+	var m ebiten.GeoM
+	sx := float64(eg.windowWidth) / ScreenWidth
+	sy := float64(eg.windowHeight) / ScreenHeight
+	switch eg.layoutMode {
+	case LayoutStretch:
+		m.Scale(sx, sy)
+	case LayoutInteger:
+		s := math.Min(sx, sy)
+		if s >= 1 { // Windows smaller than the logical screen still show all of it
+			s = math.Floor(s)
+		}
+		m.Scale(s, s)
+		m.Translate(math.Floor((float64(eg.windowWidth)-ScreenWidth*s)/2), math.Floor((float64(eg.windowHeight)-ScreenHeight*s)/2))
+	}
+	return m
+}
+
+// logicalCursor maps a cursor position on the screen back to the logical screen.
+func (eg *EbitenGame) logicalCursor(x, y int) (float64, float64) {
+	if eg.layoutMode == LayoutLetterbox {
+		return float64(x), float64(y)
+	}
+	m := eg.displayGeoM()
+	m.Invert()
+	return m.Apply(float64(x), float64(y))
+}
+
+// logicalImage returns the cleared image the frame is drawn on before being
+// placed on the window, reused between frames.
+func (eg *EbitenGame) logicalImage() *ebiten.Image {
+	if eg.logical == nil {
+		eg.logical = ebiten.NewImage(ScreenWidth, ScreenHeight)
+	}
+	eg.logical.Clear()
+	return eg.logical
+}
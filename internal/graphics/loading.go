@@ -0,0 +1,49 @@
+package graphics
+
+import "github.com/Y1m4r/Catch-The-PacMan-Game/internal/audio"
+
+// assetLoading is the assets loading in the background at startup.
+type assetLoading struct {
+	progress chan AssetProgress
+	loaded   chan *Assets
+}
+
+// startLoading loads the assets on another goroutine, see LoadAssets.
+func startLoading(am *audio.AudioManager) *assetLoading {
+	l := &assetLoading{
+		progress: make(chan AssetProgress, 16),
+		loaded:   make(chan *Assets, 1),
+	}
+	go func() {
+		l.loaded <- LoadAssets(am, l.progress)
+	}()
+	return l
+}
+
+// pollLoading passes the loading progress on to the game's StateLoading, and
+// takes the assets on once they're loaded. The images and shaders are only
+// swapped in here, on the game's goroutine, so drawing never sees them
+// half loaded.
+func (eg *EbitenGame) pollLoading() {
+	if eg.loading == nil {
+		return
+	}
+	for {
+		select {
+		case p, ok := <-eg.loading.progress:
+			if !ok {
+				eg.loading.progress = nil // Done, wait for the assets
+				continue
+			}
+			eg.GameLogic.ReportLoadProgress(p.Done, p.Total, p.Asset, p.Err != nil)
+		case assets := <-eg.loading.loaded:
+			eg.Assets = assets
+			eg.SetTheme(eg.theme) // Sprites now that there are images
+			eg.loading = nil
+			eg.GameLogic.FinishLoading()
+			return
+		default:
+			return
+		}
+	}
+}
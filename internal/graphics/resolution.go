@@ -0,0 +1,77 @@
+package graphics
+
+import (
+	"log"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Frame times the resolution is lowered and raised at. They're apart so
+// that it doesn't flip back and forth around a single threshold.
+const (
+	frameBudget        = 20 * time.Millisecond // Smoothed frame time above which resolution drops
+	frameRecovered     = 15 * time.Millisecond // Smoothed frame time below which it rises again
+	resolutionCooldown = time.Second           // Least time between two changes
+	frameSmoothing     = 0.1                   // Weight of the newest frame in the smoothed frame time
+)
+
+// resolutionScales are the steps the resolution goes down through under load.
+var resolutionScales = []float64{1, 0.75, 0.5}
+
+// resolutionScaler picks the resolution frames are drawn at: a step lower
+// while the frame time is over budget, a step higher once it has recovered.
+type resolutionScaler struct {
+	step       int     // Into resolutionScales
+	frameTime  float64 // Smoothed time between frames, in seconds
+	lastFrame  time.Time
+	lastChange time.Time
+}
+
+// Frame notes a frame starting at now and returns the scale to draw it at.
+func (s *resolutionScaler) Frame(now time.Time) float64 {
+	if !s.lastFrame.IsZero() {
+		dt := now.Sub(s.lastFrame).Seconds()
+		if s.frameTime == 0 {
+			s.frameTime = dt
+		} else {
+			s.frameTime += frameSmoothing * (dt - s.frameTime)
+		}
+	}
+	s.lastFrame = now
+
+	if now.Sub(s.lastChange) >= resolutionCooldown {
+		switch {
+		case s.frameTime > frameBudget.Seconds() && s.step < len(resolutionScales)-1:
+			s.step++
+			s.lastChange = now
+			log.Printf("Frame time %.1fms over budget, drawing at %gx resolution", 1000*s.frameTime, resolutionScales[s.step])
+		case s.frameTime < frameRecovered.Seconds() && s.step > 0:
+			s.step--
+			s.lastChange = now
+			log.Printf("Frame time back to %.1fms, drawing at %gx resolution", 1000*s.frameTime, resolutionScales[s.step])
+		}
+	}
+	return resolutionScales[s.step]
+}
+
+// SetDynamicResolution turns dynamic resolution on or off. It's on by
+// default: frames are drawn at a lower resolution and upscaled while the
+// game can't keep up, e.g. with thousands of Pacmans.
+func (eg *EbitenGame) SetDynamicResolution(enabled bool) {
+	eg.dynamicResolution = enabled
+}
+
+// worldImage returns the cleared image to draw a frame at the lowered
+// resolution on, reusing the last one when the scale hasn't changed.
+func (eg *EbitenGame) worldImage(target *ebiten.Image, scale float64) *ebiten.Image {
+	w, h := int(float64(target.Bounds().Dx())*scale), int(float64(target.Bounds().Dy())*scale)
+	if eg.world == nil || eg.world.Bounds().Dx() != w || eg.world.Bounds().Dy() != h {
+		if eg.world != nil {
+			eg.world.Dispose()
+		}
+		eg.world = ebiten.NewImage(w, h)
+	}
+	eg.world.Clear()
+	return eg.world
+}
@@ -0,0 +1,116 @@
+package graphics
+
+import (
+	"embed"
+	"fmt"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Settings of the screen effects, see the shaders' uniforms.
+const (
+	slowMotionDesaturation = 0.8
+	crtCurvature           = 0.04
+	crtScanlines           = 0.25
+	crtVignette            = 0.35
+)
+
+//go:embed shaders/*.kage
+var shaderFiles embed.FS
+
+// Shaders are the Kage shaders of the screen effects, compiled with the assets.
+type Shaders struct {
+	Desaturate *ebiten.Shader // Drains the colors while slow motion is on
+	CRT        *ebiten.Shader // Scanlines, curvature and vignette of an old screen, see SetCRT
+}
+
+// loadShaders compiles every shader.
+func loadShaders() (*Shaders, error) {
+	desaturate, err := compileShader("shaders/desaturate.kage")
+	if err != nil {
+		return nil, err
+	}
+	crt, err := compileShader("shaders/crt.kage")
+	if err != nil {
+		return nil, err
+	}
+	return &Shaders{Desaturate: desaturate, CRT: crt}, nil
+}
+
+func compileShader(name string) (*ebiten.Shader, error) {
+	src, err := shaderFiles.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	shader, err := ebiten.NewShader(src)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile %s: %w", name, err)
+	}
+	return shader, nil
+}
+
+// SetShaders turns the shader effects on or off. They're on by default
+// when the shaders compiled.
+func (eg *EbitenGame) SetShaders(enabled bool) {
+	eg.shadersOn = enabled
+}
+
+// SetCRT turns the retro CRT filter on or off. It needs the shader effects.
+func (eg *EbitenGame) SetCRT(enabled bool) {
+	eg.crt = enabled
+}
+
+// screenEffect is a shader pass over the whole frame.
+type screenEffect struct {
+	shader   *ebiten.Shader
+	uniforms map[string]any
+}
+
+// screenEffects returns the passes the frame goes through, in order.
+func (eg *EbitenGame) screenEffects() []screenEffect {
+	if !eg.shadersOn || eg.Assets.Shaders == nil {
+		return nil
+	}
+	var effects []screenEffect
+	if eg.GameLogic.SlowMotion() {
+		effects = append(effects, screenEffect{eg.Assets.Shaders.Desaturate, map[string]any{"Amount": float32(slowMotionDesaturation)}})
+	}
+	if eg.crt {
+		effects = append(effects, screenEffect{eg.Assets.Shaders.CRT, map[string]any{
+			"Curvature": float32(crtCurvature),
+			"Scanlines": float32(crtScanlines),
+			"Vignette":  float32(crtVignette),
+		}})
+	}
+	return effects
+}
+
+// drawEffects draws frame onto screen through the effects, passing it
+// between the offscreen images when there are several.
+func (eg *EbitenGame) drawEffects(screen, frame *ebiten.Image, effects []screenEffect) {
+	for i, e := range effects {
+		dst := screen
+		if i < len(effects)-1 {
+			dst = eg.offscreenImage(screen, (i+1)%len(eg.offscreen))
+		}
+		op := &ebiten.DrawRectShaderOptions{Uniforms: e.uniforms}
+		op.Images[0] = frame
+		dst.DrawRectShader(frame.Bounds().Dx(), frame.Bounds().Dy(), e.shader, op)
+		frame = dst
+	}
+}
+
+// offscreenImage returns the i-th image to draw a frame on before its
+// effects, cleared and the size of screen. They're kept between frames.
+func (eg *EbitenGame) offscreenImage(screen *ebiten.Image, i int) *ebiten.Image {
+	img := eg.offscreen[i]
+	if img == nil || img.Bounds() != screen.Bounds() {
+		if img != nil {
+			img.Dispose()
+		}
+		img = ebiten.NewImage(screen.Bounds().Dx(), screen.Bounds().Dy())
+		eg.offscreen[i] = img
+	}
+	img.Clear()
+	return img
+}
@@ -0,0 +1,101 @@
+package graphics
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/frontend"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/game"
+)
+
+// Themes Pacmans can be drawn with, see EbitenGame.SetTheme.
+const (
+	ThemeSprites = "sprites" // The Pac-Man images
+	ThemeMinimal = "minimal" // Vector shapes, also the fallback without images
+)
+
+// Themes lists every theme.
+var Themes = []string{ThemeSprites, ThemeMinimal}
+
+// Half the mouth's opening of each animation frame, in radians.
+var mouthAngles = []float64{math.Pi / 5, math.Pi / 24}
+
+// vectorPacmansPerCall bounds the Pacmans filled per DrawTriangles call, so
+// their vertices stay within its 16-bit indices.
+const vectorPacmansPerCall = 256
+
+// squashedArcSegments is how many lines trace a squashed Pacman's outline.
+const squashedArcSegments = 24
+
+var (
+	whiteImage    = ebiten.NewImage(3, 3)
+	whiteSubImage = whiteImage.SubImage(image.Rect(1, 1, 2, 2)).(*ebiten.Image)
+)
+
+func init() {
+	whiteImage.Fill(color.White)
+}
+
+// vectorEntities draws Pacmans as pie slices facing where they're heading,
+// their mouth opening and closing with the animation frame. Their size is
+// their radius, which already has the sprite scale.
+type vectorEntities struct {
+	queued   []game.PacmanDrawData
+	path     vector.Path
+	vertices []ebiten.Vertex
+	indices  []uint16
+}
+
+func (v *vectorEntities) Add(p game.PacmanDrawData, _ float64) {
+	v.queued = append(v.queued, p)
+}
+
+func (v *vectorEntities) Flush(dst *ebiten.Image) {
+	for start, end := 0, 0; start < len(v.queued); start = end {
+		// Each call fills one color, so tinted ones go in runs of the same tint
+		end = start + 1
+		for end < len(v.queued) && end-start < vectorPacmansPerCall && v.queued[end].Tint == v.queued[start].Tint {
+			end++
+		}
+		var clr color.Color = frontend.ColorYellow
+		if v.queued[start].Tint.A != 0 {
+			clr = v.queued[start].Tint
+		}
+		r, g, b, a := clr.RGBA()
+
+		v.path = vector.Path{}
+		for _, p := range v.queued[start:end] {
+			facing := 0.0 // Stopped ones face right
+			if p.VelX != 0 || p.VelY != 0 {
+				facing = math.Atan2(p.VelY, p.VelX)
+			}
+			mouth := mouthAngles[p.AnimFrame%len(mouthAngles)]
+			v.path.MoveTo(float32(p.PosX), float32(p.PosY))
+			if scaleX, scaleY := p.Scale(); scaleX != 1 || scaleY != 1 {
+				// Squashed, trace the ellipse by hand as Arc only does circles
+				for i := 0; i <= squashedArcSegments; i++ {
+					angle := facing + mouth + (2*math.Pi-2*mouth)*float64(i)/squashedArcSegments
+					v.path.LineTo(float32(p.PosX+p.Radius*scaleX*math.Cos(angle)), float32(p.PosY+p.Radius*scaleY*math.Sin(angle)))
+				}
+			} else {
+				v.path.Arc(float32(p.PosX), float32(p.PosY), float32(p.Radius), float32(facing+mouth), float32(facing+2*math.Pi-mouth), vector.Clockwise)
+			}
+			v.path.Close()
+		}
+		v.vertices, v.indices = v.path.AppendVerticesAndIndicesForFilling(v.vertices[:0], v.indices[:0])
+		for i := range v.vertices {
+			v.vertices[i].SrcX, v.vertices[i].SrcY = 1, 1
+			v.vertices[i].ColorR = float32(r) / 0xffff
+			v.vertices[i].ColorG = float32(g) / 0xffff
+			v.vertices[i].ColorB = float32(b) / 0xffff
+			v.vertices[i].ColorA = float32(a) / 0xffff
+		}
+		op := &ebiten.DrawTrianglesOptions{ColorScaleMode: ebiten.ColorScaleModePremultipliedAlpha, AntiAlias: true}
+		dst.DrawTriangles(v.vertices, v.indices, whiteSubImage, op)
+	}
+	v.queued = v.queued[:0]
+}
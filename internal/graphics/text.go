@@ -0,0 +1,70 @@
+package graphics
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// debugLineHeight is the height of a line of Ebiten's debug font.
+const debugLineHeight = 16
+
+// maxCachedTexts bounds the texts kept drawn by textCache. Past it they're
+// all dropped, as most were likely changing counters.
+const maxCachedTexts = 256
+
+// textCache draws text at other sizes than the debug font's. Each string is
+// drawn once at the font's size and then scaled, and kept for the frames
+// after, since the same labels come back every frame.
+type textCache struct {
+	images map[string]*ebiten.Image
+}
+
+// image returns str drawn with the debug font.
+func (c *textCache) image(str string) *ebiten.Image {
+	if img, ok := c.images[str]; ok {
+		return img
+	}
+	if len(c.images) >= maxCachedTexts {
+		for _, img := range c.images {
+			img.Dispose()
+		}
+		c.images = nil
+	}
+	if c.images == nil {
+		c.images = map[string]*ebiten.Image{}
+	}
+	img := ebiten.NewImage(utf8.RuneCountInString(str)*6+1, debugLineHeight*(strings.Count(str, "\n")+1))
+	debugPrint(img, str, 0, 0, false)
+	c.images[str] = img
+	return img
+}
+
+// draw writes text onto dst at textScale times the font's size, growing
+// around the middle of its first line so it stays where it's laid out for.
+// The whole of it is then scaled by scale, the resolution of dst relative
+// to the logical screen.
+func (c *textCache) draw(dst *ebiten.Image, str string, x, y float64, center bool, textScale, scale float64) {
+	if textScale == 1 && scale == 1 {
+		debugPrint(dst, str, x, y, center)
+		return
+	}
+	if center {
+		x -= float64(utf8.RuneCountInString(str)*6) * textScale / 2
+	}
+	y -= debugLineHeight * (textScale - 1) / 2
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Scale(textScale*scale, textScale*scale)
+	op.GeoM.Translate(x*scale, y*scale)
+	dst.DrawImage(c.image(str), op)
+}
+
+// textScale returns how much larger than the debug font the HUD and menu
+// text is drawn, see frontend.Display.SetTextScale.
+func (eg *EbitenGame) textScale() float64 {
+	if eg.window == nil || eg.window.settings.TextScale == 0 {
+		return 1
+	}
+	return float64(eg.window.settings.TextScale) / 100
+}
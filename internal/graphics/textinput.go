@@ -0,0 +1,81 @@
+package graphics
+
+import (
+	"log"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/exp/textinput"
+
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/frontend"
+)
+
+// imeInput collects typed text through the system's input method, so names
+// can be written in scripts typed by composing characters, like Japanese,
+// Chinese or Korean. Where there's no input method support it falls back to
+// plain typed characters.
+type imeInput struct {
+	field  textinput.Field
+	taken  int  // Bytes of the field's text already handed on as typed characters
+	failed bool // The input method gave an error, see poll
+}
+
+// pollText fills in the text typed this tick, while the controller wants text.
+func (eg *EbitenGame) pollText(in *frontend.Input) {
+	if !eg.controller.WantsText() {
+		eg.ime.stop()
+		return
+	}
+	in.Backspace = repeatingKeyPressed(ebiten.KeyBackspace) // Allow holding backspace
+	if eg.ime.failed {
+		in.Chars = ebiten.AppendInputChars(nil)
+		return
+	}
+	// The candidate window goes under the text, in Layout's coordinates
+	m := eg.displayGeoM()
+	x, y := m.Apply(eg.controller.TextPosition())
+	if err := eg.ime.poll(in, int(x), int(y)); err != nil {
+		log.Printf("Input method failed, falling back to plain typing: %v", err)
+		eg.ime.failed = true
+		in.Chars = ebiten.AppendInputChars(nil)
+	}
+}
+
+// poll hands on the text committed since the last tick as typed characters,
+// and what's still being composed. While composing, the input method has
+// Enter, Escape and Backspace, so they're taken out of in.
+func (t *imeInput) poll(in *frontend.Input, x, y int) error {
+	wasComposing := t.composing() != ""
+	t.field.Focus()
+	if _, err := t.field.HandleInput(x, y); err != nil {
+		return err
+	}
+	text := t.field.Text()
+	in.Chars = []rune(text[t.taken:])
+	t.taken = len(text)
+	in.Composing = t.composing()
+
+	if wasComposing || in.Composing != "" {
+		in.Keys = slices.DeleteFunc(in.Keys, func(k frontend.Key) bool {
+			return k == frontend.KeyConfirm || k == frontend.KeyBack
+		})
+		in.Backspace = false
+	}
+	return nil
+}
+
+// composing returns the text being composed. It's at the end of the field's
+// text, where typing always goes.
+func (t *imeInput) composing() string {
+	return t.field.TextForRendering()[len(t.field.Text()):]
+}
+
+// stop ends text input, once nothing is being typed anymore.
+func (t *imeInput) stop() {
+	if !t.field.IsFocused() {
+		return
+	}
+	t.field.Blur()
+	t.field.SetTextAndSelection("", 0, 0)
+	t.taken = 0
+}
@@ -0,0 +1,120 @@
+// Package heatmap keeps where the player clicked on each level, and where
+// Pacmans were actually caught, so the stats screen can show their aim.
+package heatmap
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// Version of the heatmap file format.
+const Version = 1
+
+// MaxPoints is how many clicks, and as many catches, a level keeps. The
+// oldest are dropped first, so the map follows how the player aims now.
+const MaxPoints = 5000
+
+// Map holds the clicks and catches of every run of one level.
+type Map struct {
+	Version int     `json:"version"`
+	Level   int     `json:"level"`
+	Clicks  []Point `json:"clicks"`
+	Catches []Point `json:"catches"`
+}
+
+// Point is a position on the logical screen.
+type Point struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+// New returns an empty map of a level.
+func New(level int) *Map {
+	return &Map{Version: Version, Level: level}
+}
+
+// Add appends the clicks and catches of a run.
+func (m *Map) Add(clicks, catches []Point) {
+	m.Clicks = appendCapped(m.Clicks, clicks)
+	m.Catches = appendCapped(m.Catches, catches)
+}
+
+func appendCapped(points, more []Point) []Point {
+	points = append(points, more...)
+	if len(points) > MaxPoints {
+		points = points[len(points)-MaxPoints:]
+	}
+	return points
+}
+
+// Grid counts the clicks in square cells of the given size covering a
+// width x height screen, indexed [row][column]. It also returns the
+// highest count, to scale colors by.
+func (m *Map) Grid(cell, width, height float64) (counts [][]int, highest int) {
+	rows, cols := int((height+cell-1)/cell), int((width+cell-1)/cell)
+	counts = make([][]int, rows)
+	for i := range counts {
+		counts[i] = make([]int, cols)
+	}
+	for _, p := range m.Clicks {
+		row, col := int(p.Y/cell), int(p.X/cell)
+		if row < 0 || row >= rows || col < 0 || col >= cols {
+			continue
+		}
+		counts[row][col]++
+		highest = max(highest, counts[row][col])
+	}
+	return counts, highest
+}
+
+// Load reads the map of a level, or returns an empty one if none was saved yet.
+func Load(path string, level int) (*Map, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return New(level), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var m Map
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("invalid heatmap file %s: %w", path, err)
+	}
+	if m.Version > Version {
+		return nil, fmt.Errorf("heatmap file %s has version %d, newer than supported version %d", path, m.Version, Version)
+	}
+	return &m, nil
+}
+
+// Save writes a map to path through a temporary file renamed into place,
+// creating its directory if needed.
+func Save(m *Map, path string) error {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("could not create directory for %s: %w", path, err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("error creating %s: %w", path, err)
+	}
+	defer os.Remove(tmp.Name()) // No-op once renamed
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("error writing %s: %w", path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("error writing %s: %w", path, err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("error replacing %s: %w", path, err)
+	}
+	return nil
+}
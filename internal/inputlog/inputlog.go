@@ -0,0 +1,153 @@
+// Package inputlog records every click and key action of a session to a
+// JSON-lines file players can attach to bug reports. Each level start is
+// logged with the level's seed and layout hash, so a session can be
+// reproduced by feeding the logged input back tick by tick.
+package inputlog
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// Version of the log format, written in the first entry.
+const Version = 1
+
+// Entry is one line of an input log. Exactly one of Header, Level or the
+// input fields is set.
+type Entry struct {
+	Time time.Time `json:"time"`
+	Tick uint64    `json:"tick"` // Update calls since recording started
+
+	Header *Header `json:"header,omitempty"`
+	Level  *Level  `json:"level,omitempty"`
+
+	Click     *Click   `json:"click,omitempty"`
+	Cursor    *Click   `json:"cursor,omitempty"` // Where the pointer moved to
+	Keys      []string `json:"keys,omitempty"`
+	Held      []string `json:"held,omitempty"` // Keys held down, for actions that repeat while held
+	Text      string   `json:"text,omitempty"`
+	Backspace bool     `json:"backspace,omitempty"`
+}
+
+// Header describes the recording session.
+type Header struct {
+	Version      int           `json:"version"`
+	TickInterval time.Duration `json:"tick_interval"` // Target time between ticks of the frontend
+}
+
+// Level is logged whenever a level (re)starts.
+type Level struct {
+	Number int    `json:"number"`
+	Source string `json:"source"`         // Level file, or the quick play settings
+	Seed   uint64 `json:"seed,omitempty"` // Seed of the run's randomness, and of the layout of a generated level
+	Hash   string `json:"hash"`           // Hash of the starting layout
+}
+
+// Click is a primary button press in logical screen coordinates.
+type Click struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+// Recorder writes an input log. It is driven from the game loop and isn't
+// safe for concurrent use.
+type Recorder struct {
+	file *os.File
+	w    *bufio.Writer
+	enc  *json.Encoder
+	tick uint64
+	err  error // First write error, later entries are dropped
+}
+
+// Create starts a new input log at path, replacing any existing file.
+func Create(path string, tickInterval time.Duration) (*Recorder, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not create input log: %w", err)
+	}
+	w := bufio.NewWriter(file)
+	r := &Recorder{file: file, w: w, enc: json.NewEncoder(w)}
+	r.write(Entry{Header: &Header{Version: Version, TickInterval: tickInterval}})
+	if r.err != nil {
+		file.Close()
+		return nil, r.err
+	}
+	return r, nil
+}
+
+// Tick advances the tick counter, called once per game loop update.
+func (r *Recorder) Tick() {
+	r.tick++
+}
+
+// Level logs the start of a level. It's flushed right away, so the log
+// identifies the level even if the game crashes.
+func (r *Recorder) Level(level Level) {
+	r.write(Entry{Level: &level})
+}
+
+// Input logs the input of the current tick. Empty input isn't logged.
+func (r *Recorder) Input(e Entry) {
+	if e.Click == nil && len(e.Keys) == 0 && len(e.Held) == 0 && e.Text == "" && !e.Backspace {
+		return
+	}
+	e.Header, e.Level = nil, nil
+	r.write(e)
+}
+
+func (r *Recorder) write(e Entry) {
+	if r.err != nil {
+		return
+	}
+	e.Time = time.Now()
+	e.Tick = r.tick
+	if err := r.enc.Encode(e); err != nil {
+		r.err = fmt.Errorf("could not write input log: %w", err)
+		return
+	}
+	if err := r.w.Flush(); err != nil {
+		r.err = fmt.Errorf("could not write input log: %w", err)
+	}
+}
+
+// Err returns the first error writing the log, if any.
+func (r *Recorder) Err() error {
+	return r.err
+}
+
+// Close flushes and closes the log.
+func (r *Recorder) Close() error {
+	if err := r.w.Flush(); err != nil && r.err == nil {
+		r.err = err
+	}
+	if err := r.file.Close(); err != nil && r.err == nil {
+		r.err = err
+	}
+	return r.err
+}
+
+// Read parses an input log, e.g. to replay it.
+func Read(rd io.Reader) ([]Entry, error) {
+	var entries []Entry
+	dec := json.NewDecoder(rd)
+	for {
+		var e Entry
+		if err := dec.Decode(&e); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("malformed input log entry %d: %w", len(entries)+1, err)
+		}
+		entries = append(entries, e)
+	}
+	if len(entries) == 0 || entries[0].Header == nil {
+		return nil, fmt.Errorf("not an input log: missing header")
+	}
+	if v := entries[0].Header.Version; v > Version {
+		return nil, fmt.Errorf("input log version %d is newer than supported version %d", v, Version)
+	}
+	return entries, nil
+}
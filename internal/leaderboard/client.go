@@ -0,0 +1,161 @@
+package leaderboard
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/model"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/scoreapi"
+)
+
+// Client talks to a score server over the scoreapi protocol.
+type Client struct {
+	BaseURL string // e.g. "http://localhost:8080"
+	Secret  []byte // Shared secret submissions are signed with; empty sends them unsigned
+	HTTP    *http.Client
+}
+
+// Run is what a submission tells the server about the run behind a score,
+// for it to check the score is plausible. The zero Run tells nothing, which
+// servers requiring sessions turn away.
+type Run struct {
+	Session string  // Token StartSession got when the run started
+	Hash    string  // See scoreapi.HashRun
+	Elapsed float64 // Seconds the run lasted in game time
+	Catches int
+}
+
+// NewClient creates a client for the score server at baseURL.
+func NewClient(baseURL string, secret []byte) *Client {
+	return &Client{
+		BaseURL: baseURL,
+		Secret:  secret,
+		HTTP:    &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+// Scores fetches the top model.MaxHighScores entries of a level, best score
+// first. Canceling ctx abandons the request.
+func (c *Client) Scores(ctx context.Context, level int) ([]model.Score, error) {
+	page, err := c.ScoresPage(ctx, level, 0, model.MaxHighScores)
+	if err != nil {
+		return nil, err
+	}
+	scores := make([]model.Score, len(page.Scores))
+	for i, e := range page.Scores {
+		scores[i] = model.Score{Name: e.Name, Score: e.Score, SpriteScale: e.SpriteScale, Misses: e.Misses, Handicap: e.Handicap.Model(),
+			Chain: e.Chain, ChainBonus: e.ChainBonus}
+	}
+	return scores, nil
+}
+
+// ScoresPage fetches one page of a level's leaderboard.
+func (c *Client) ScoresPage(ctx context.Context, level, offset, limit int) (*scoreapi.ScoresPage, error) {
+	query := url.Values{}
+	query.Set("offset", strconv.Itoa(offset))
+	query.Set("limit", strconv.Itoa(limit))
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s%s/levels/%d/scores?%s", c.BaseURL, scoreapi.Version, level, query.Encode()), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.HTTP.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching scores for level %d: %w", level, err)
+	}
+	defer resp.Body.Close()
+	if err := checkResponse(resp); err != nil {
+		return nil, err
+	}
+
+	var page scoreapi.ScoresPage
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+		return nil, fmt.Errorf("error decoding scores for level %d: %w", level, err)
+	}
+	return &page, nil
+}
+
+// StartSession tells the server a run of a level starts, getting the token
+// its score is submitted with.
+func (c *Client) StartSession(ctx context.Context, level int) (string, error) {
+	body, err := json.Marshal(scoreapi.SessionRequest{Level: level})
+	if err != nil {
+		return "", err
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.BaseURL+scoreapi.Version+"/sessions", bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	resp, err := c.HTTP.Do(httpReq)
+	if err != nil {
+		return "", fmt.Errorf("error starting session for level %d: %w", level, err)
+	}
+	defer resp.Body.Close()
+	if err := checkResponse(resp); err != nil {
+		return "", err
+	}
+
+	var session scoreapi.SessionResponse
+	if err := json.NewDecoder(resp.Body).Decode(&session); err != nil {
+		return "", fmt.Errorf("error decoding session: %w", err)
+	}
+	return session.Token, nil
+}
+
+// Submit sends a score of the given run and reports whether (and where) it
+// made the leaderboard.
+func (c *Client) Submit(ctx context.Context, level int, score model.Score, run Run) (*scoreapi.SubmitResponse, error) {
+	req := scoreapi.SubmitRequest{Level: level, Name: score.Name, Score: score.Score, Misses: score.Misses, Handicap: scoreapi.NewHandicap(score.Handicap),
+		Chain: score.Chain, ChainBonus: score.ChainBonus, Session: run.Session, RunHash: run.Hash, Elapsed: run.Elapsed, Catches: run.Catches}
+	if score.Assisted() {
+		req.SpriteScale = score.SpriteScale
+	}
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	path := scoreapi.Version + "/scores"
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.BaseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	if len(c.Secret) > 0 {
+		timestamp := time.Now().Unix()
+		httpReq.Header.Set(scoreapi.HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+		httpReq.Header.Set(scoreapi.HeaderSignature, scoreapi.Sign(c.Secret, http.MethodPost, httpReq.URL.Path, timestamp, body))
+	}
+
+	resp, err := c.HTTP.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("error submitting score for level %d: %w", level, err)
+	}
+	defer resp.Body.Close()
+	if err := checkResponse(resp); err != nil {
+		return nil, err
+	}
+
+	var result scoreapi.SubmitResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("error decoding submit response: %w", err)
+	}
+	return &result, nil
+}
+
+// checkResponse turns a non-2xx reply into an error carrying the server's message.
+func checkResponse(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	var errResp scoreapi.ErrorResponse
+	if err := json.NewDecoder(resp.Body).Decode(&errResp); err != nil || errResp.Error == "" {
+		return fmt.Errorf("score server returned %s", resp.Status)
+	}
+	return fmt.Errorf("score server returned %s: %s", resp.Status, errResp.Error)
+}
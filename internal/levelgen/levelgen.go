@@ -0,0 +1,103 @@
+// Package levelgen builds random levels. The same seed and difficulty always
+// give the same level, so a run can be shared by passing on its seed.
+package levelgen
+
+import (
+	"fmt"
+	"math/rand/v2"
+
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/game"
+)
+
+// Level is the level number generated levels are played as. They're scored
+// on a board of their Config's own, see Config.Board.
+const Level = 99
+
+// Difficulty range accepted by Generate.
+const (
+	MinDifficulty = 1
+	MaxDifficulty = 10
+)
+
+// Placement tries per Pacman before accepting an overlap.
+const placementTries = 50
+
+// Config identifies a generated level.
+type Config struct {
+	Seed       uint64
+	Difficulty int
+}
+
+func (c Config) String() string {
+	return fmt.Sprintf("seed %d, difficulty %d", c.Seed, c.Difficulty)
+}
+
+// Board names the Hall of Fame of the level generated from c, see
+// game.Game.Board: only runs of the same level compare.
+func (c Config) Board() string {
+	return fmt.Sprintf("quickplay_s%d_d%d", c.Seed, c.Difficulty)
+}
+
+// Generate builds the level of cfg for a screen of the given size. Higher
+// difficulties have more, smaller and faster Pacmans.
+func Generate(cfg Config, screenWidth, screenHeight float64) (*game.Game, error) {
+	if cfg.Difficulty < MinDifficulty || cfg.Difficulty > MaxDifficulty {
+		return nil, fmt.Errorf("difficulty must be between %d and %d, got %d", MinDifficulty, MaxDifficulty, cfg.Difficulty)
+	}
+	// PCG's output is fixed by its specification, so seeds stay valid across Go releases
+	rng := rand.New(rand.NewPCG(cfg.Seed, uint64(cfg.Difficulty)))
+
+	count := 2 + cfg.Difficulty
+	maxDiameter := 56.0 - 2*float64(cfg.Difficulty)
+	minDiameter := maxDiameter - 16
+	baseWait := 110 - 7*cfg.Difficulty
+
+	pacmans := make([]*game.Pacman, 0, count)
+	for id := 0; id < count; id++ {
+		radius := (minDiameter + rng.Float64()*(maxDiameter-minDiameter)) / 2
+		var posX, posY float64
+		for try := 0; try < placementTries; try++ {
+			posX = radius + rng.Float64()*(screenWidth-2*radius)
+			posY = radius + rng.Float64()*(screenHeight-2*radius)
+			if !overlaps(pacmans, posX, posY, radius) {
+				break
+			}
+		}
+
+		direction := rune(game.DirHorizontal)
+		if rng.IntN(2) == 0 {
+			direction = game.DirVertical
+		}
+		subDirection := 1
+		if rng.IntN(2) == 0 {
+			subDirection = -1
+		}
+		waitTimeMs := baseWait - 10 + rng.IntN(21)
+
+		dirX, dirY := game.AxisHeading(direction, subDirection)
+		pacmans = append(pacmans, game.NewPacman(id, radius, posX, posY, dirX, dirY, waitTimeMs, 0, false))
+	}
+
+	// A *partial* Game, like the level loader returns
+	return &game.Game{Level: Level, Pacmans: pacmans, Seed: cfg.Seed, Board: cfg.Board()}, nil
+}
+
+func overlaps(pacmans []*game.Pacman, x, y, radius float64) bool {
+	for _, p := range pacmans {
+		dx, dy := p.PosX-x, p.PosY-y
+		if minDist := p.Radius + radius; dx*dx+dy*dy < minDist*minDist {
+			return true
+		}
+	}
+	return false
+}
+
+// NewSeed picks a fresh random seed, never 0 so it can't be confused with
+// "no seed given".
+func NewSeed() uint64 {
+	for {
+		if seed := rand.Uint64(); seed != 0 {
+			return seed
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package migrate
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/config"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/fileformat"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/persistence"
+)
+
+var levelFormat = format{
+	current: config.LevelFormatVersion,
+	version: func(data []byte) (int, error) { return fileformat.Version(data, fileformat.KindLevel) },
+	steps: map[int]func([]byte) ([]byte, error){
+		// Rows: diameter, posX, posY, waitTimeMs, direction, bounces, isStopped
+		0: func(data []byte) ([]byte, error) {
+			return addHeader(data, fileformat.KindLevel, 1, 1, 4, 6)
+		},
+	},
+}
+
+var saveFormat = format{
+	current: persistence.SaveFormatVersion,
+	version: func(data []byte) (int, error) { return fileformat.Version(data, fileformat.KindSave) },
+	steps: map[int]func([]byte) ([]byte, error){
+		// Rows: diameter, posX, posY, waitTimeMs, direction, subDirection, bounces, isStopped
+		0: func(data []byte) ([]byte, error) {
+			return addHeader(data, fileformat.KindSave, 1, 2, 4, 7)
+		},
+	},
+}
+
+// High score files were gob-encoded .gob files (version 1) before becoming
+// versioned JSON documents in .json files (see persistence.HighScoreVersion).
+var highScoreFormat = format{
+	current: persistence.HighScoreVersion,
+	version: func(data []byte) (int, error) {
+		if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+			var doc struct {
+				Version int `json:"version"`
+			}
+			if err := json.Unmarshal(trimmed, &doc); err != nil {
+				return 0, fmt.Errorf("not a high score file: %w", err)
+			}
+			return doc.Version, nil
+		}
+		if _, err := persistence.DecodeHighScores(data); err != nil {
+			return 0, fmt.Errorf("not a high score file: %w", err)
+		}
+		return 1, nil
+	},
+	steps: map[int]func([]byte) ([]byte, error){
+		1: func(data []byte) ([]byte, error) {
+			scores, err := persistence.DecodeHighScores(data)
+			if err != nil {
+				return nil, err
+			}
+			return persistence.EncodeHighScores(scores)
+		},
+	},
+	rename: func(path string) string {
+		return strings.TrimSuffix(path, filepath.Ext(path)) + ".json"
+	},
+}
+
+// addHeader upgrades an unversioned text file to version 1: it adds the
+// format header and spells out the direction and stopped flag of every row
+// the way the game writes them (older files used lowercase directions and
+// 1/0 flags). headerLines is the number of value lines before the rows.
+// Comments and anything it doesn't understand are kept as they are.
+func addHeader(data []byte, kind string, version, headerLines, directionField, stoppedField int) ([]byte, error) {
+	var out bytes.Buffer
+	fmt.Fprintln(&out, fileformat.Header(kind, version))
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	values := 0
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			out.WriteString(line + "\n")
+			continue
+		}
+		if values < headerLines {
+			values++
+			out.WriteString(trimmed + "\n")
+			continue
+		}
+
+		fields := strings.Split(trimmed, "\t")
+		if len(fields) > stoppedField {
+			fields[directionField] = strings.ToUpper(fields[directionField])
+			switch strings.ToLower(fields[stoppedField]) {
+			case "true", "1":
+				fields[stoppedField] = "true"
+			case "false", "0":
+				fields[stoppedField] = "false"
+			}
+		}
+		out.WriteString(strings.Join(fields, "\t") + "\n")
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
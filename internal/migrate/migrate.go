@@ -0,0 +1,206 @@
+// Package migrate upgrades level files, saves and high score files written by
+// older versions of the game to the current formats, in place, keeping a
+// backup of every file it changes.
+package migrate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/fileformat"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/persistence"
+)
+
+// Kind identifies a type of game file.
+type Kind string
+
+// Kinds of files that can be migrated.
+const (
+	KindLevel      Kind = "level"
+	KindSave       Kind = "save"
+	KindHighScores Kind = "highscores"
+)
+
+// Result describes what happened to one file.
+type Result struct {
+	Path   string // File that was read
+	Target string // File the upgraded content went to; same as Path unless the format changed name
+	Kind   Kind
+	From   int    // Format version found
+	To     int    // Format version written; same as From if the file was up to date
+	Backup string // Copy of the original file, empty if nothing was changed
+}
+
+// Upgraded reports whether the file was rewritten.
+func (r Result) Upgraded() bool {
+	return r.To != r.From
+}
+
+// format knows how to recognise and upgrade one kind of file.
+type format struct {
+	current int
+	// version returns the format version of the file content
+	version func(data []byte) (int, error)
+	// steps[v] upgrades content from version v to v+1
+	steps map[int]func(data []byte) ([]byte, error)
+	// rename, if set, gives the name of an upgraded file when the current
+	// format is kept under a different name
+	rename func(path string) string
+}
+
+var formats = map[Kind]format{
+	KindLevel:      levelFormat,
+	KindSave:       saveFormat,
+	KindHighScores: highScoreFormat,
+}
+
+// File upgrades one file of the given kind to the current format. With
+// dryRun set, it only reports what it would do.
+func File(path string, kind Kind, dryRun bool) (Result, error) {
+	f, ok := formats[kind]
+	if !ok {
+		return Result{}, fmt.Errorf("unknown file kind %q", kind)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Result{}, fmt.Errorf("error reading %s: %w", path, err)
+	}
+	if persistence.IsEncrypted(data) {
+		return Result{}, fmt.Errorf("%s: can't upgrade an encrypted file", path)
+	}
+	from, err := f.version(data)
+	if err != nil {
+		return Result{}, fmt.Errorf("%s: %w", path, err)
+	}
+	result := Result{Path: path, Target: path, Kind: kind, From: from, To: from}
+	if from > f.current {
+		return result, fmt.Errorf("%s: %w: version %d is newer than this game supports (%d)", path, fileformat.ErrUnsupportedVersion, from, f.current)
+	}
+	if from == f.current {
+		return result, nil
+	}
+
+	for v := from; v < f.current; v++ {
+		data, err = f.steps[v](data)
+		if err != nil {
+			return result, fmt.Errorf("%s: upgrading from format version %d: %w", path, v, err)
+		}
+	}
+	result.To = f.current
+	if f.rename != nil {
+		result.Target = f.rename(path)
+	}
+	if result.Target != path {
+		if _, err := os.Stat(result.Target); err == nil {
+			return result, fmt.Errorf("%s: can't upgrade, %s already exists", path, result.Target)
+		}
+	}
+	if dryRun {
+		return result, nil
+	}
+
+	backup, err := backupFile(path, from)
+	if err != nil {
+		return result, err
+	}
+	result.Backup = backup
+	if err := replaceFile(result.Target, data); err != nil {
+		return result, err
+	}
+	if result.Target != path {
+		if err := os.Remove(path); err != nil {
+			return result, fmt.Errorf("error removing %s after upgrading it: %w", path, err)
+		}
+	}
+	return result, nil
+}
+
+// Dir upgrades every game file under an assets directory: levels/*.txt,
+// saves/*.txt and highscores/highscores_*.{gob,json}. It carries on past files it
+// can't upgrade and returns the first error at the end.
+func Dir(assetsDir string, dryRun bool) ([]Result, error) {
+	patterns := []struct {
+		glob string
+		kind Kind
+	}{
+		{filepath.Join(assetsDir, "levels", "*.txt"), KindLevel},
+		{filepath.Join(assetsDir, "saves", "*.txt"), KindSave},
+		{filepath.Join(assetsDir, "highscores", "highscores_*.gob"), KindHighScores},
+		{filepath.Join(assetsDir, "highscores", "highscores_*.json"), KindHighScores},
+	}
+
+	// List everything first, so files renamed by an upgrade aren't visited twice
+	type file struct {
+		path string
+		kind Kind
+	}
+	var files []file
+	for _, p := range patterns {
+		paths, err := filepath.Glob(p.glob)
+		if err != nil {
+			return nil, err
+		}
+		sort.Strings(paths)
+		for _, path := range paths {
+			files = append(files, file{path, p.kind})
+		}
+	}
+
+	var results []Result
+	var firstErr error
+	for _, f := range files {
+		result, err := File(f.path, f.kind, dryRun)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		results = append(results, result)
+	}
+	return results, firstErr
+}
+
+// backupFile copies path next to itself before it's upgraded from version.
+// An existing backup is kept, since it's the older one.
+func backupFile(path string, version int) (string, error) {
+	backup := fmt.Sprintf("%s.v%d.bak", path, version)
+	if _, err := os.Stat(backup); err == nil {
+		return backup, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("error reading %s: %w", path, err)
+	}
+	if err := os.WriteFile(backup, data, 0644); err != nil {
+		return "", fmt.Errorf("error writing backup %s: %w", backup, err)
+	}
+	return backup, nil
+}
+
+// replaceFile writes data to path through a temporary file, so a crash
+// never leaves a half-written file behind.
+func replaceFile(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("error creating %s: %w", path, err)
+	}
+	defer os.Remove(tmp.Name()) // No-op once renamed
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("error writing %s: %w", path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("error writing %s: %w", path, err)
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return fmt.Errorf("error writing %s: %w", path, err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("error replacing %s: %w", path, err)
+	}
+	return nil
+}
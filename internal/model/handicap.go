@@ -0,0 +1,59 @@
+package model
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Handicap evens out games between players of different skill. The zero
+// value is no handicap.
+type Handicap struct {
+	Slowdown         float64 // Game speed factor giving more time to react, e.g. 0.8; 0 or 1 for full speed
+	BounceMultiplier float64 // Factor bounces count with in the score, e.g. 0.5; 0 or 1 for all of them
+}
+
+// Slowdowns and BounceMultipliers are the handicaps players can pick from.
+var (
+	Slowdowns         = []float64{1, 0.9, 0.8, 0.7}
+	BounceMultipliers = []float64{1, 0.75, 0.5}
+)
+
+// Active reports whether the handicap changes anything.
+func (h Handicap) Active() bool {
+	return h.Slowed() || h.Multiplied()
+}
+
+// Slowed reports whether the game runs slower.
+func (h Handicap) Slowed() bool {
+	return h.Slowdown != 0 && h.Slowdown != 1
+}
+
+// Multiplied reports whether bounces count less.
+func (h Handicap) Multiplied() bool {
+	return h.BounceMultiplier != 0 && h.BounceMultiplier != 1
+}
+
+// Validate checks the handicap is one players can pick.
+func (h Handicap) Validate() error {
+	if h.Slowed() && !slices.Contains(Slowdowns, h.Slowdown) {
+		return fmt.Errorf("slowdown must be one of %v, got %g", Slowdowns, h.Slowdown)
+	}
+	if h.Multiplied() && !slices.Contains(BounceMultipliers, h.BounceMultiplier) {
+		return fmt.Errorf("bounce multiplier must be one of %v, got %g", BounceMultipliers, h.BounceMultiplier)
+	}
+	return nil
+}
+
+// String describes the handicap the way it's tagged in the Hall of Fame,
+// e.g. "speed 0.8x, bounces 0.5x", or "" if there is none.
+func (h Handicap) String() string {
+	var parts []string
+	if h.Slowed() {
+		parts = append(parts, fmt.Sprintf("speed %gx", h.Slowdown))
+	}
+	if h.Multiplied() {
+		parts = append(parts, fmt.Sprintf("bounces %gx", h.BounceMultiplier))
+	}
+	return strings.Join(parts, ", ")
+}
@@ -1,38 +1,141 @@
 package model
 
-import "sort"
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"sort"
+	"time"
+)
 
 const MaxHighScores = 10
 
 // Score holds the player's name and their score (number of bounces).
 // Needs to be exported for gob encoding/decoding.
 type Score struct {
-	Name  string
-	Score int // Lower is better (fewer bounces)
+	Name        string
+	Score       int      // Lower is better (fewer bounces)
+	SpriteScale float64  // Pacman size multiplier the run was played with; 0 or 1 for unassisted runs
+	Misses      int      // Missed clicks of a run played with accuracy scoring, each adding MissPenalty to Score
+	Handicap    Handicap // Handicap of the player's profile during the run
+	Chain       int      // Highest multiplier of a chain of quick catches during the run, 0 for runs without chains
+	ChainBonus  int      // Bounces chains took off Score
+	ID          string   // Tells entries apart, even with the same name and score; empty for entries older than IDs
+	SetAt       int64    // When the run ended, in Unix milliseconds; 0 for entries older than that
+}
+
+// Stamp returns the score as a Hall of Fame entry of a run that ended at
+// now, with an ID of its own, unless it already is one.
+func (s Score) Stamp(now time.Time) Score {
+	if s.ID == "" {
+		id := make([]byte, 8)
+		rand.Read(id) // Never fails
+		s.ID = hex.EncodeToString(id)
+	}
+	if s.SetAt == 0 {
+		s.SetAt = now.UnixMilli()
+	}
+	return s
+}
+
+// MissPenalty is how many bounces a missed click adds to the score with
+// accuracy scoring, so runs that camp and spam clicks don't pay off.
+const MissPenalty = 2
+
+// Bounces is the part of the score that came from bounces rather than misses.
+func (s Score) Bounces() int {
+	return s.Score - s.Misses*MissPenalty
+}
+
+// SpriteScales are the Pacman size multipliers players can pick from.
+var SpriteScales = []float64{1, 1.25, 1.5}
+
+// Assisted reports whether the score was set with enlarged Pacmans.
+func (s Score) Assisted() bool {
+	return s.SpriteScale > 1
 }
 
 // ByScore implements sort.Interface for []Score based on the Score field (ascending).
+// Of equal scores, the one set first ranks higher, then the lower ID, so
+// ties always sort the same way.
 type ByScore []Score
 
-func (a ByScore) Len() int           { return len(a) }
-func (a ByScore) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
-func (a ByScore) Less(i, j int) bool { return a[i].Score < a[j].Score }
+func (a ByScore) Len() int      { return len(a) }
+func (a ByScore) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
+func (a ByScore) Less(i, j int) bool {
+	if a[i].Score != a[j].Score {
+		return a[i].Score < a[j].Score
+	}
+	if a[i].SetAt != a[j].SetAt {
+		return a[i].SetAt < a[j].SetAt
+	}
+	return a[i].ID < a[j].ID
+}
+
+// HallOfFameRules decide which scores make a Hall of Fame. The zero value
+// keeps the best MaxHighScores scores, any number of them per player.
+type HallOfFameRules struct {
+	Size         int  // Scores kept; 0 is MaxHighScores
+	Threshold    int  // Scores above it don't qualify; 0 for no threshold
+	OnePerPlayer bool // Only each name's best score is kept
+	Chains       bool // Runs are scored with chains of quick catches, see game.ChainScorer
+}
 
-// AddScore adds a new score to the list, keeps it sorted, and trims to MaxHighScores.
+// Limit is how many scores the Hall of Fame keeps.
+func (r HallOfFameRules) Limit() int {
+	if r.Size > 0 {
+		return r.Size
+	}
+	return MaxHighScores
+}
+
+// AddScore adds a new score, of a run that ended at now, to the list, keeps it sorted, and trims to MaxHighScores.
 // Returns the updated list and true if the score was added (i.e., it made the top list).
 // Now operates on []model.Score.
-func AddScore(scores []Score, newScore Score) ([]Score, bool) {
-	// Check if the new score is better than the worst score currently in the top 10
+func AddScore(scores []Score, newScore Score, now time.Time) ([]Score, bool) {
+	return HallOfFameRules{}.Add(scores, newScore, now)
+}
+
+// AddScoreLimit is AddScore for a list holding up to limit scores instead of MaxHighScores.
+func AddScoreLimit(scores []Score, newScore Score, limit int, now time.Time) ([]Score, bool) {
+	return HallOfFameRules{Size: limit}.Add(scores, newScore, now)
+}
+
+// Add is AddScore under the rules. With OnePerPlayer, a better score
+// replaces the player's entry, and a worse one isn't added. A score without
+// a name, e.g. checked before the player typed theirs, is nobody's yet.
+// The score is stamped at now unless it already is, see Score.Stamp, so
+// it's told apart from entries with the same name and score, and ranks
+// below those set before it.
+func (r HallOfFameRules) Add(scores []Score, newScore Score, now time.Time) ([]Score, bool) {
+	newScore = newScore.Stamp(now)
+	if r.Threshold > 0 && newScore.Score > r.Threshold {
+		return scores, false
+	}
+	if r.OnePerPlayer && newScore.Name != "" {
+		for i, s := range scores {
+			if s.Name != newScore.Name {
+				continue
+			}
+			if newScore.Score >= s.Score {
+				return scores, false
+			}
+			scores = append(scores[:i:i], scores[i+1:]...)
+			break
+		}
+	}
+	limit := r.Limit()
+
+	// Check if the new score is better than the worst score currently in the list
 	// or if the list isn't full yet.
 	shouldAdd := false
-	if len(scores) < MaxHighScores {
+	if len(scores) < limit {
 		shouldAdd = true
 	} else {
 		// Sort scores temporarily to check against the worst if needed
 		// (Only sort if the list is full to avoid unnecessary sorting)
 		tempScores := make([]Score, len(scores))
 		copy(tempScores, scores)
-		sort.Sort(ByScore(tempScores))
+		sort.Stable(ByScore(tempScores))
 		if newScore.Score < tempScores[len(tempScores)-1].Score {
 			shouldAdd = true
 		}
@@ -40,16 +143,16 @@ func AddScore(scores []Score, newScore Score) ([]Score, bool) {
 
 	if shouldAdd {
 		scores = append(scores, newScore)
-		sort.Sort(ByScore(scores)) // Sort by score ascending
+		sort.Stable(ByScore(scores)) // Sort by score ascending, entries from before IDs keep their order
 
-		// Keep only the top MaxHighScores
-		if len(scores) > MaxHighScores {
-			scores = scores[:MaxHighScores]
+		// Keep only the top `limit` scores
+		if len(scores) > limit {
+			scores = scores[:limit]
 		}
 
 		// Check if the added score is actually still in the list after trimming
 		for _, s := range scores {
-			if s == newScore { // Compare value since it's a simple struct
+			if s.ID == newScore.ID {
 				return scores, true
 			}
 		}
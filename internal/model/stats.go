@@ -0,0 +1,117 @@
+package model
+
+import (
+	"cmp"
+	"slices"
+	"time"
+)
+
+// Run is one finished run of a level, as a profile's run history keeps it.
+type Run struct {
+	Level    int
+	Bounces  int
+	Failed   bool // Went over the level's bounce budget
+	Duration time.Duration
+	Date     time.Time
+}
+
+// Summary aggregates runs. Failed runs count as games, but their bounces
+// aren't comparable with cleared runs', so the bounce figures leave them out.
+type Summary struct {
+	Games   int // Runs, failed ones included
+	Cleared int // Runs that weren't failed
+	Best    int // Fewest bounces of a cleared run
+	Median  float64
+	Average float64
+	// Trend is how many bounces a cleared run gains on the one before, fitted
+	// over them in the order they were played. Negative while improving.
+	Trend float64
+}
+
+// Summarize aggregates runs of a level, in the order they were played.
+func Summarize(runs []Run) Summary {
+	s := Summary{Games: len(runs)}
+	var bounces []int
+	for _, run := range runs {
+		if !run.Failed {
+			bounces = append(bounces, run.Bounces)
+		}
+	}
+	s.Cleared = len(bounces)
+	if s.Cleared == 0 {
+		return s
+	}
+	s.Trend = Trend(bounces)
+
+	total := 0
+	for _, b := range bounces {
+		total += b
+	}
+	s.Average = float64(total) / float64(s.Cleared)
+	sorted := slices.Clone(bounces)
+	slices.Sort(sorted)
+	s.Best = sorted[0]
+	if mid := s.Cleared / 2; s.Cleared%2 == 1 {
+		s.Median = float64(sorted[mid])
+	} else {
+		s.Median = float64(sorted[mid-1]+sorted[mid]) / 2
+	}
+	return s
+}
+
+// SummarizeLevels aggregates runs level by level, in the order they were played.
+func SummarizeLevels(runs []Run) map[int]Summary {
+	byLevel := make(map[int][]Run)
+	for _, run := range runs {
+		byLevel[run.Level] = append(byLevel[run.Level], run)
+	}
+	summaries := make(map[int]Summary, len(byLevel))
+	for level, levelRuns := range byLevel {
+		summaries[level] = Summarize(levelRuns)
+	}
+	return summaries
+}
+
+// SummarizeAll aggregates runs of any levels, in the order they were
+// played. Bounces of different levels don't compare, so the trend is each
+// level's, weighted by its cleared runs.
+func SummarizeAll(runs []Run) Summary {
+	s := Summarize(runs)
+	s.Trend = 0
+	for _, level := range SummarizeLevels(runs) {
+		if s.Cleared > 0 {
+			s.Trend += level.Trend * float64(level.Cleared) / float64(s.Cleared)
+		}
+	}
+	return s
+}
+
+// SummarizeScores aggregates a Hall of Fame's entries as runs of level,
+// in the order they were set. Entries from before they had a time come first.
+func SummarizeScores(level int, scores []Score) Summary {
+	sorted := slices.Clone(scores)
+	slices.SortStableFunc(sorted, func(a, b Score) int { return cmp.Compare(a.SetAt, b.SetAt) })
+	runs := make([]Run, len(sorted))
+	for i, s := range sorted {
+		runs[i] = Run{Level: level, Bounces: s.Score, Date: time.UnixMilli(s.SetAt)}
+	}
+	return Summarize(runs)
+}
+
+// Trend fits a line through bounces, in order, by least squares and
+// returns its slope: the bounces gained per run. It's 0 for fewer than two.
+func Trend(bounces []int) float64 {
+	n := float64(len(bounces))
+	if n < 2 {
+		return 0
+	}
+	var sumX, sumY, sumXY, sumXX float64
+	for i, b := range bounces {
+		x, y := float64(i), float64(b)
+		sumX += x
+		sumY += y
+		sumXY += x * y
+		sumXX += x * x
+	}
+	return (n*sumXY - sumX*sumY) / (n*sumXX - sumX*sumX)
+}
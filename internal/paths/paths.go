@@ -0,0 +1,340 @@
+// Package paths works out where the game keeps the files it writes. Those
+// live in per-user directories rather than next to the game's assets, which
+// may be installed read-only (e.g. /usr/share or Program Files).
+//
+// On Linux and other Unix systems the XDG base directories are honoured:
+// saves and high scores go to $XDG_DATA_HOME, settings to $XDG_CONFIG_HOME
+// and logs to $XDG_STATE_HOME. macOS uses ~/Library/Application Support
+// and ~/Library/Logs, Windows %AppData% and %LocalAppData%.
+package paths
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// AppName names the game's directory inside each of the user's directories.
+const AppName = "catch-the-pacman"
+
+// LegacyDir is where versions of the game before the data directory kept
+// their saves and high scores.
+const LegacyDir = "assets"
+
+// Dirs are the directories the game writes to.
+type Dirs struct {
+	Data   string // Saves, high scores, achievements and screenshots
+	Config string // Settings
+	State  string // Logs
+}
+
+// PortableDirName is the directory next to the executable that holds
+// everything in portable mode.
+const PortableDirName = "pacman-data"
+
+// Options choose where the game's directories are.
+type Options struct {
+	Override string // Use this one directory for everything
+	Portable bool   // Keep everything next to the executable, for USB-stick play
+}
+
+// AddFlags registers the -data-dir and -portable flags on fs, so every
+// command picks its directories the same way, and returns the options they
+// fill in.
+func AddFlags(fs *flag.FlagSet) *Options {
+	opts := &Options{}
+	fs.StringVar(&opts.Override, "data-dir", "", "directory for saves, high scores, settings and logs (default: the user's data, config and state directories)")
+	fs.BoolVar(&opts.Portable, "portable", false, "keep saves, high scores, settings and logs in "+PortableDirName+" next to the executable")
+	return opts
+}
+
+// UserDirs returns the game's directories. Without options these are the
+// current user's directories; opts.Override, or else opts.Portable, puts
+// everything in one directory instead.
+func UserDirs(opts Options) (Dirs, error) {
+	switch {
+	case opts.Override != "":
+		return singleDir(opts.Override), nil
+	case opts.Portable:
+		exe, err := os.Executable()
+		if err != nil {
+			return Dirs{}, fmt.Errorf("could not find the executable for portable mode: %w", err)
+		}
+		if exe, err = filepath.EvalSymlinks(exe); err != nil {
+			return Dirs{}, fmt.Errorf("could not find the executable for portable mode: %w", err)
+		}
+		return singleDir(filepath.Join(filepath.Dir(exe), PortableDirName)), nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return Dirs{}, fmt.Errorf("could not find home directory (use a data directory flag instead): %w", err)
+	}
+
+	var dirs Dirs
+	switch runtime.GOOS {
+	case "windows":
+		appData := envDir("AppData", filepath.Join(home, "AppData", "Roaming"))
+		dirs = Dirs{
+			Data:   appData,
+			Config: appData,
+			State:  envDir("LocalAppData", filepath.Join(home, "AppData", "Local")),
+		}
+	case "darwin", "ios":
+		support := filepath.Join(home, "Library", "Application Support")
+		dirs = Dirs{Data: support, Config: support, State: filepath.Join(home, "Library", "Logs")}
+	case "plan9":
+		dirs = Dirs{Data: filepath.Join(home, "lib"), Config: filepath.Join(home, "lib"), State: filepath.Join(home, "lib")}
+	default:
+		dirs = Dirs{
+			Data:   envDir("XDG_DATA_HOME", filepath.Join(home, ".local", "share")),
+			Config: envDir("XDG_CONFIG_HOME", filepath.Join(home, ".config")),
+			State:  envDir("XDG_STATE_HOME", filepath.Join(home, ".local", "state")),
+		}
+	}
+	dirs.Data = filepath.Join(dirs.Data, AppName)
+	dirs.Config = filepath.Join(dirs.Config, AppName)
+	dirs.State = filepath.Join(dirs.State, AppName)
+	return dirs, nil
+}
+
+func singleDir(dir string) Dirs {
+	return Dirs{Data: dir, Config: dir, State: dir}
+}
+
+// envDir returns the directory in an environment variable, or fallback if
+// it's unset. Like the XDG spec says, relative paths are ignored.
+func envDir(name, fallback string) string {
+	if dir := os.Getenv(name); dir != "" && filepath.IsAbs(dir) {
+		return dir
+	}
+	return fallback
+}
+
+// ScreenshotsDir returns the directory screenshots are saved to.
+func ScreenshotsDir(dataDir string) string {
+	return filepath.Join(dataDir, "screenshots")
+}
+
+// SavesDir returns the directory holding the saves.
+func SavesDir(dataDir string) string {
+	return filepath.Join(dataDir, "saves")
+}
+
+// HighScoresDir returns the directory holding the high score files.
+func HighScoresDir(dataDir string) string {
+	return filepath.Join(dataDir, "highscores")
+}
+
+// DefaultProfile is the profile personal bests are kept for unless another is picked.
+const DefaultProfile = "default"
+
+// RecordsDir returns the directory the personal bests are kept in.
+func RecordsDir(dataDir string) string {
+	return filepath.Join(dataDir, "records")
+}
+
+// ProfilesDir returns the directory the profile settings are kept in.
+func ProfilesDir(dataDir string) string {
+	return filepath.Join(dataDir, "profiles")
+}
+
+// RecordsPath returns the personal bests file of a profile.
+func RecordsPath(dataDir, profile string) string {
+	return filepath.Join(RecordsDir(dataDir), profileFileName(profile))
+}
+
+// ProfilePath returns the settings file of a profile.
+func ProfilePath(dataDir, profile string) string {
+	return filepath.Join(ProfilesDir(dataDir), profileFileName(profile))
+}
+
+// profileFileName returns the file name of a profile's files, with the
+// characters that can't be in a file name replaced.
+func profileFileName(profile string) string {
+	name := strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || r == ':' || r < ' ' {
+			return '_'
+		}
+		return r
+	}, strings.Trim(profile, ". "))
+	if name == "" {
+		name = DefaultProfile
+	}
+	return name + ".json"
+}
+
+// GhostsDir returns the directory holding each level's best recorded run.
+func GhostsDir(dataDir string) string {
+	return filepath.Join(dataDir, "ghosts")
+}
+
+// GhostPath returns the best recorded run of a level.
+func GhostPath(dataDir string, level int) string {
+	return filepath.Join(GhostsDir(dataDir), fmt.Sprintf("ghost_%d.json", level))
+}
+
+// HeatmapPath returns where the player's clicks on a level are kept.
+func HeatmapPath(dataDir string, level int) string {
+	return filepath.Join(dataDir, "heatmaps", fmt.Sprintf("level_%d.json", level))
+}
+
+// SaveGamePath returns the save file of a level.
+func SaveGamePath(dataDir string, level int) string {
+	return filepath.Join(SavesDir(dataDir), fmt.Sprintf("savegame_%d.txt", level))
+}
+
+// HighScorePath returns the high score file of a level.
+func HighScorePath(dataDir string, level int) string {
+	return filepath.Join(HighScoresDir(dataDir), fmt.Sprintf("highscores_%d.json", level))
+}
+
+// PeriodHighScorePath returns the board of a level for a window of time,
+// such as "2026-W42" or "2026-10". The boards of past windows are kept.
+func PeriodHighScorePath(dataDir string, level int, period string) string {
+	return filepath.Join(HighScoresDir(dataDir), "periods", fmt.Sprintf("highscores_%d_%s.json", level, period))
+}
+
+// ArcadeHighScorePath returns the arcade mode Hall of Fame of a level.
+func ArcadeHighScorePath(dataDir string, level int) string {
+	return filepath.Join(HighScoresDir(dataDir), fmt.Sprintf("arcade_%d.json", level))
+}
+
+// TimeAttackHighScorePath returns the time attack mode Hall of Fame of a level.
+func TimeAttackHighScorePath(dataDir string, level int) string {
+	return filepath.Join(HighScoresDir(dataDir), fmt.Sprintf("timeattack_%d.json", level))
+}
+
+// BoardHighScorePath returns the Hall of Fame of a level kept under a name
+// of its own rather than its number, e.g. a level file played directly.
+func BoardHighScorePath(dataDir, board string) string {
+	return filepath.Join(HighScoresDir(dataDir), "boards", board+".json")
+}
+
+// CampaignHighScorePath returns the Hall of Fame of completed campaigns.
+func CampaignHighScorePath(dataDir string) string {
+	return filepath.Join(HighScoresDir(dataDir), "campaign.json")
+}
+
+// CampaignPath returns where the progress of the campaign being played is saved.
+func CampaignPath(dataDir string) string {
+	return filepath.Join(dataDir, "campaign.json")
+}
+
+// Resolve returns path inside dir if it's relative. Empty and absolute
+// paths are returned unchanged.
+func Resolve(dir, path string) string {
+	if path == "" || filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(dir, path)
+}
+
+// legacyFile is a file or directory an older version of the game may have
+// left behind, and the directory it belongs in now.
+type legacyFile struct {
+	name string
+	dest func(Dirs) string
+}
+
+var legacyFiles = []legacyFile{
+	{"saves", func(d Dirs) string { return d.Data }},
+	{"highscores", func(d Dirs) string { return d.Data }},
+	{"achievements.json", func(d Dirs) string { return d.Data }},
+	{"machine.key", func(d Dirs) string { return d.Data }},
+	{"twitch.json", func(d Dirs) string { return d.Config }},
+}
+
+// Prepare creates the game's directories and copies over the saves, high
+// scores and settings an older version of the game left behind, either in
+// LegacyDir or all together in the user config directory, unless there's
+// already a copy in the new place. Originals are left where they are.
+func Prepare(dirs Dirs) error {
+	for _, dir := range []string{SavesDir(dirs.Data), HighScoresDir(dirs.Data), dirs.Config, dirs.State} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("could not create directory %s: %w", dir, err)
+		}
+	}
+
+	sources := []string{LegacyDir}
+	if configDir, err := os.UserConfigDir(); err == nil {
+		sources = append(sources, filepath.Join(configDir, AppName))
+	}
+	for _, source := range sources {
+		for _, f := range legacyFiles {
+			if err := importLegacy(filepath.Join(source, f.name), filepath.Join(f.dest(dirs), f.name)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// importLegacy copies the file or the files of the directory src to dst,
+// skipping those already there.
+func importLegacy(src, dst string) error {
+	info, err := os.Stat(src)
+	if err != nil {
+		return nil // Nothing to bring over
+	}
+	if same, _ := sameFile(src, dst); same {
+		return nil
+	}
+	if !info.IsDir() {
+		return copyIfMissing(src, dst, info.Mode().Perm())
+	}
+
+	entries, err := os.ReadDir(src)
+	if err != nil {
+		return fmt.Errorf("error reading %s: %w", src, err)
+	}
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		if err := copyIfMissing(filepath.Join(src, e.Name()), filepath.Join(dst, e.Name()), 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copyIfMissing copies src to dst unless dst already exists.
+func copyIfMissing(src, dst string, perm os.FileMode) error {
+	if _, err := os.Stat(dst); err == nil {
+		return nil
+	}
+	in, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("error opening %s: %w", src, err)
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
+	if err != nil {
+		return fmt.Errorf("error creating %s: %w", dst, err)
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		os.Remove(dst)
+		return fmt.Errorf("error copying %s to %s: %w", src, dst, err)
+	}
+	return out.Close()
+}
+
+// sameFile reports whether a and b are the same file or directory.
+func sameFile(a, b string) (bool, error) {
+	infoA, err := os.Stat(a)
+	if err != nil {
+		return false, err
+	}
+	infoB, err := os.Stat(b)
+	if err != nil {
+		return false, err
+	}
+	return os.SameFile(infoA, infoB), nil
+}
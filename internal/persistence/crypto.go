@@ -0,0 +1,283 @@
+package persistence
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/paths"
+)
+
+// Encryption modes accepted by NewCipher.
+const (
+	EncryptPassphrase = "passphrase" // Key derived from a passphrase the player types in
+	EncryptMachine    = "machine"    // Random key kept in a file only this user can read
+)
+
+// Layout of an encrypted file: encryptedMagic, a salt, an AES-GCM nonce, then
+// the sealed content. The key is derived from the secret and the salt, so
+// the same passphrase gives different keys for different installs.
+var encryptedMagic = []byte("PACMAN-ENC\x01")
+
+const (
+	saltSize             = 16
+	keySize              = 32 // AES-256
+	passphraseIterations = 600000
+)
+
+// ErrWrongKey is returned when an encrypted file can't be opened with the
+// configured passphrase or machine key, or has been tampered with.
+var ErrWrongKey = errors.New("wrong passphrase or key, or file was modified")
+
+// ErrEncrypted is returned when reading an encrypted file without a Cipher set.
+var ErrEncrypted = errors.New("file is encrypted, enable save encryption to read it")
+
+// ErrNotEncrypted is returned when reading an unencrypted file with a
+// Cipher set: once encryption is on, a plain file is one that was put
+// there behind the game's back. See MigrateToCipher.
+var ErrNotEncrypted = errors.New("file is not encrypted, but save encryption is on")
+
+// migratedMarker is the file MigrateToCipher records its migration in,
+// in the data directory.
+const migratedMarker = "encryption.migrated"
+
+// Cipher encrypts and decrypts save and high score files.
+type Cipher struct {
+	secret     []byte
+	iterations int
+
+	mu        sync.Mutex
+	writeSalt []byte
+	keys      map[string][]byte // Derived keys by salt; derivation is slow on purpose
+}
+
+// NewCipher creates a Cipher for one of the encryption modes. passphrase is
+// used by EncryptPassphrase, keyPath (created on first use) by EncryptMachine.
+func NewCipher(mode, passphrase, keyPath string) (*Cipher, error) {
+	switch mode {
+	case EncryptPassphrase:
+		if passphrase == "" {
+			return nil, errors.New("save encryption needs a passphrase")
+		}
+		return newCipher([]byte(passphrase), passphraseIterations)
+	case EncryptMachine:
+		key, err := loadMachineKey(keyPath)
+		if err != nil {
+			return nil, err
+		}
+		return newCipher(key, 1) // Already a random key, nothing to stretch
+	default:
+		return nil, fmt.Errorf("unknown encryption mode %q (want %q or %q)", mode, EncryptPassphrase, EncryptMachine)
+	}
+}
+
+func newCipher(secret []byte, iterations int) (*Cipher, error) {
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("could not generate salt: %w", err)
+	}
+	return &Cipher{secret: secret, iterations: iterations, writeSalt: salt, keys: make(map[string][]byte)}, nil
+}
+
+// Seal encrypts plain into the encrypted file layout.
+func (c *Cipher) Seal(plain []byte) ([]byte, error) {
+	aead, err := c.aead(c.writeSalt)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("could not generate nonce: %w", err)
+	}
+
+	out := append([]byte{}, encryptedMagic...)
+	out = append(out, c.writeSalt...)
+	out = append(out, nonce...)
+	return aead.Seal(out, nonce, plain, encryptedMagic), nil
+}
+
+// Open decrypts a file produced by Seal.
+func (c *Cipher) Open(data []byte) ([]byte, error) {
+	if !IsEncrypted(data) {
+		return nil, errors.New("file is not encrypted")
+	}
+	rest := data[len(encryptedMagic):]
+	if len(rest) < saltSize {
+		return nil, ErrWrongKey
+	}
+	salt, rest := rest[:saltSize], rest[saltSize:]
+	aead, err := c.aead(salt)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) < aead.NonceSize() {
+		return nil, ErrWrongKey
+	}
+	nonce, sealed := rest[:aead.NonceSize()], rest[aead.NonceSize():]
+	plain, err := aead.Open(nil, nonce, sealed, encryptedMagic)
+	if err != nil {
+		return nil, ErrWrongKey
+	}
+	return plain, nil
+}
+
+// aead returns the AES-GCM instance for the key derived with salt.
+func (c *Cipher) aead(salt []byte) (cipher.AEAD, error) {
+	c.mu.Lock()
+	key, ok := c.keys[string(salt)]
+	if !ok {
+		var err error
+		key, err = pbkdf2.Key(sha256.New, string(c.secret), salt, c.iterations, keySize)
+		if err != nil {
+			c.mu.Unlock()
+			return nil, fmt.Errorf("could not derive key: %w", err)
+		}
+		c.keys[string(salt)] = key
+	}
+	c.mu.Unlock()
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// IsEncrypted reports whether data is an encrypted file.
+func IsEncrypted(data []byte) bool {
+	return bytes.HasPrefix(data, encryptedMagic)
+}
+
+// loadMachineKey reads the machine key at path, creating it the first time.
+func loadMachineKey(path string) ([]byte, error) {
+	key, err := os.ReadFile(path)
+	if err == nil {
+		if len(key) != keySize {
+			return nil, fmt.Errorf("machine key %s is damaged (%d bytes)", path, len(key))
+		}
+		return key, nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("error reading machine key %s: %w", path, err)
+	}
+
+	key = make([]byte, keySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("could not generate machine key: %w", err)
+	}
+	// Only the current user may read it, that's what keeps other profiles out
+	if err := os.WriteFile(path, key, 0600); err != nil {
+		return nil, fmt.Errorf("error writing machine key %s: %w", path, err)
+	}
+	return key, nil
+}
+
+// activeCipher encrypts everything persistence writes when set.
+var activeCipher *Cipher
+
+// SetCipher makes persistence encrypt the saves and high scores it writes
+// from now on, and refuse to read unencrypted ones. Call MigrateToCipher
+// next to encrypt the files written before. nil turns encryption off.
+func SetCipher(c *Cipher) {
+	activeCipher = c
+}
+
+// MigrateToCipher encrypts the unencrypted saves, high scores, personal
+// bests and profiles in dataDir with the Cipher set, the first time
+// encryption is turned on. It records that it ran in dataDir, and never
+// runs again once it has: unencrypted files showing up later are refused.
+// A file it couldn't encrypt leaves the migration to be tried again.
+func MigrateToCipher(dataDir string) error {
+	if activeCipher == nil {
+		return nil
+	}
+	marker := filepath.Join(dataDir, migratedMarker)
+	if _, err := os.Stat(marker); err == nil {
+		return nil
+	}
+
+	var migrated []string
+	var errs []error
+	for _, dir := range []string{paths.SavesDir(dataDir), paths.HighScoresDir(dataDir), paths.RecordsDir(dataDir), paths.ProfilesDir(dataDir)} {
+		err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || !d.Type().IsRegular() {
+				return err
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				errs = append(errs, err)
+				return nil
+			}
+			if IsEncrypted(data) {
+				return nil
+			}
+			if err := writeFile(path, data); err != nil {
+				errs = append(errs, fmt.Errorf("error encrypting %s: %w", path, err))
+				return nil
+			}
+			migrated = append(migrated, path)
+			return nil
+		})
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("could not encrypt every existing file: %w", errors.Join(errs...))
+	}
+
+	record := fmt.Sprintf("Encrypted %d files on %s\n", len(migrated), time.Now().Format(time.RFC3339))
+	for _, path := range migrated {
+		record += path + "\n"
+	}
+	if err := os.WriteFile(marker, []byte(record), 0644); err != nil {
+		return fmt.Errorf("error recording the migration in %s: %w", marker, err)
+	}
+	log.Printf("Encrypted %d existing files", len(migrated))
+	return nil
+}
+
+// writeFile writes a save or high score file, encrypted if a Cipher is set.
+func writeFile(path string, data []byte) error {
+	if activeCipher != nil {
+		var err error
+		if data, err = activeCipher.Seal(data); err != nil {
+			return err
+		}
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// readFile reads a save or high score file, decrypting it if needed.
+// With a Cipher set, only encrypted files are read.
+func readFile(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return data, err
+	}
+	if !IsEncrypted(data) {
+		if activeCipher != nil {
+			return nil, fmt.Errorf("%s: %w", path, ErrNotEncrypted)
+		}
+		return data, nil
+	}
+	if activeCipher == nil {
+		return nil, fmt.Errorf("%s: %w", path, ErrEncrypted)
+	}
+	plain, err := activeCipher.Open(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return plain, nil
+}
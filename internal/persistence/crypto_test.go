@@ -0,0 +1,127 @@
+package persistence
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/paths"
+)
+
+// machineCipher returns a Cipher with a machine key of its own.
+func machineCipher(t *testing.T) *Cipher {
+	t.Helper()
+	c, err := NewCipher(EncryptMachine, "", filepath.Join(t.TempDir(), "machine.key"))
+	if err != nil {
+		t.Fatalf("NewCipher: %v", err)
+	}
+	return c
+}
+
+// useCipher sets c for the rest of the test.
+func useCipher(t *testing.T, c *Cipher) {
+	t.Helper()
+	SetCipher(c)
+	t.Cleanup(func() { SetCipher(nil) })
+}
+
+func TestSealOpen(t *testing.T) {
+	plain := []byte("1 0 7\n20 100 150 99 0 0 0 false 0 1 0 100\n")
+	c := machineCipher(t)
+	sealed, err := c.Seal(plain)
+	if err != nil {
+		t.Fatalf("Seal: %v", err)
+	}
+	if !IsEncrypted(sealed) || bytes.Contains(sealed, plain) {
+		t.Fatalf("sealed file isn't encrypted: %q", sealed)
+	}
+
+	got, err := c.Open(sealed)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if !bytes.Equal(got, plain) {
+		t.Errorf("opened %q, want %q", got, plain)
+	}
+
+	if _, err := machineCipher(t).Open(sealed); !errors.Is(err, ErrWrongKey) {
+		t.Errorf("opening with another key: err = %v, want ErrWrongKey", err)
+	}
+
+	for _, at := range []int{len(encryptedMagic), len(encryptedMagic) + saltSize, len(sealed) - 1} {
+		tampered := bytes.Clone(sealed)
+		tampered[at] ^= 1
+		if _, err := c.Open(tampered); !errors.Is(err, ErrWrongKey) {
+			t.Errorf("opening with byte %d flipped: err = %v, want ErrWrongKey", at, err)
+		}
+	}
+	if _, err := c.Open(sealed[:len(sealed)-1]); !errors.Is(err, ErrWrongKey) {
+		t.Errorf("opening truncated: err = %v, want ErrWrongKey", err)
+	}
+}
+
+func TestReadFileWithCipher(t *testing.T) {
+	dir := t.TempDir()
+	plainPath := filepath.Join(dir, "plain.json")
+	if err := os.WriteFile(plainPath, []byte("[]"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	useCipher(t, machineCipher(t))
+	if _, err := readFile(plainPath); !errors.Is(err, ErrNotEncrypted) {
+		t.Errorf("reading a plain file: err = %v, want ErrNotEncrypted", err)
+	}
+
+	sealedPath := filepath.Join(dir, "sealed.json")
+	if err := writeFile(sealedPath, []byte("[]")); err != nil {
+		t.Fatalf("writeFile: %v", err)
+	}
+	if got, err := readFile(sealedPath); err != nil || string(got) != "[]" {
+		t.Errorf("reading back = %q, %v, want []", got, err)
+	}
+
+	SetCipher(nil)
+	if _, err := readFile(sealedPath); !errors.Is(err, ErrEncrypted) {
+		t.Errorf("reading without a cipher: err = %v, want ErrEncrypted", err)
+	}
+}
+
+func TestMigrateToCipher(t *testing.T) {
+	dataDir := t.TempDir()
+	savePath := paths.SaveGamePath(dataDir, 0)
+	scorePath := paths.BoardHighScorePath(dataDir, "file_0123")
+	for _, path := range []string{savePath, scorePath} {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("plain"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	useCipher(t, machineCipher(t))
+	if err := MigrateToCipher(dataDir); err != nil {
+		t.Fatalf("MigrateToCipher: %v", err)
+	}
+	for _, path := range []string{savePath, scorePath} {
+		if got, err := readFile(path); err != nil || string(got) != "plain" {
+			t.Errorf("%s after the migration = %q, %v, want it encrypted", path, got, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dataDir, migratedMarker)); err != nil {
+		t.Fatalf("migration not recorded: %v", err)
+	}
+
+	// Only once: a plain file put there afterwards stays refused
+	if err := os.WriteFile(savePath, []byte("plain"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := MigrateToCipher(dataDir); err != nil {
+		t.Fatalf("MigrateToCipher again: %v", err)
+	}
+	if _, err := readFile(savePath); !errors.Is(err, ErrNotEncrypted) {
+		t.Errorf("reading a plain file after the migration: err = %v, want ErrNotEncrypted", err)
+	}
+}
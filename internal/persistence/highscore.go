@@ -1,67 +1,206 @@
 package persistence
 
 import (
+	"bytes"
 	"encoding/gob"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"log"
 	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/fileformat"
 	// Use your module path for model
 	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/model" // <--- IMPORT model
 	// NO LONGER import game here!
 )
 
+// High score files are JSON documents naming their schema and version, so a
+// file written by a different version of the game is recognised instead of
+// being misread:
+//
+//	{"schema": "catch-the-pacman/highscores", "version": 2, "scores": [{"name": "...", "score": 12}]}
+//
+// Version 1 was a gob-encoded []model.Score in a .gob file. Those are still
+// read, and LoadHighScores converts them on first load.
+const (
+	HighScoreSchema  = "catch-the-pacman/highscores"
+	HighScoreVersion = 2
+)
+
+// highScoreDocument is the on-disk form of a high score list. It has its own
+// entry type so changes to model.Score don't change the file format.
+type highScoreDocument struct {
+	Schema  string           `json:"schema"`
+	Version int              `json:"version"`
+	Scores  []highScoreEntry `json:"scores"`
+}
+
+type highScoreEntry struct {
+	Name        string         `json:"name"`
+	Score       int            `json:"score"`
+	SpriteScale float64        `json:"sprite_scale,omitempty"` // Only set for assisted runs
+	Misses      int            `json:"misses,omitempty"`       // Only set for runs with accuracy scoring
+	Handicap    *handicapEntry `json:"handicap,omitempty"`     // Only set for handicapped runs
+	Chain       int            `json:"chain,omitempty"`        // Not set for runs without chains
+	ChainBonus  int            `json:"chain_bonus,omitempty"`  // Bounces chains took off the score
+	ID          string         `json:"id,omitempty"`           // Not set in files from before entries had IDs
+	SetAt       int64          `json:"set_at,omitempty"`       // Unix milliseconds, not set in files from before that
+}
+
+type handicapEntry struct {
+	Slowdown         float64 `json:"slowdown,omitempty"`
+	BounceMultiplier float64 `json:"bounce_multiplier,omitempty"`
+}
+
+// EncodeHighScores returns the file content for a high score list.
+func EncodeHighScores(scores []model.Score) ([]byte, error) {
+	doc := highScoreDocument{Schema: HighScoreSchema, Version: HighScoreVersion, Scores: make([]highScoreEntry, len(scores))}
+	for i, sc := range scores {
+		doc.Scores[i] = highScoreEntry{Name: sc.Name, Score: sc.Score, Misses: sc.Misses, Chain: sc.Chain, ChainBonus: sc.ChainBonus, ID: sc.ID, SetAt: sc.SetAt}
+		if sc.Assisted() {
+			doc.Scores[i].SpriteScale = sc.SpriteScale
+		}
+		if sc.Handicap.Active() {
+			doc.Scores[i].Handicap = &handicapEntry{sc.Handicap.Slowdown, sc.Handicap.BounceMultiplier}
+		}
+	}
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// DecodeHighScores reads the content of a high score file, in the current
+// JSON format or the legacy gob one.
+func DecodeHighScores(data []byte) ([]model.Score, error) {
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		var doc highScoreDocument
+		if err := json.Unmarshal(trimmed, &doc); err != nil {
+			return nil, fmt.Errorf("invalid high score document: %w", err)
+		}
+		if doc.Schema != HighScoreSchema {
+			return nil, fmt.Errorf("not a high score document (schema %q)", doc.Schema)
+		}
+		if doc.Version > HighScoreVersion {
+			return nil, fmt.Errorf("%w: high score format version %d is newer than this game supports (%d)", fileformat.ErrUnsupportedVersion, doc.Version, HighScoreVersion)
+		}
+		scores := make([]model.Score, len(doc.Scores))
+		for i, e := range doc.Scores {
+			scores[i] = model.Score{Name: e.Name, Score: e.Score, SpriteScale: e.SpriteScale, Misses: e.Misses, Chain: e.Chain, ChainBonus: e.ChainBonus, ID: e.ID, SetAt: e.SetAt}
+			if e.Handicap != nil {
+				scores[i].Handicap = model.Handicap{Slowdown: e.Handicap.Slowdown, BounceMultiplier: e.Handicap.BounceMultiplier}
+			}
+		}
+		return scores, nil
+	}
+
+	// Legacy gob file
+	scores := []model.Score{}
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&scores); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("error decoding legacy high scores: %w", err)
+	}
+	return scores, nil
+}
+
+// LegacyHighScorePath returns where the gob file that preceded the JSON
+// high score file at path used to be kept.
+func LegacyHighScorePath(path string) string {
+	return strings.TrimSuffix(path, filepath.Ext(path)) + ".gob"
+}
+
 // SaveHighScores takes []model.Score
-func SaveHighScores(scores []model.Score, filepath string) error { // <--- Parameter uses model.Score
-	if err := os.MkdirAll("assets/highscores", 0755); err != nil {
+func SaveHighScores(scores []model.Score, path string) error { // <--- Parameter uses model.Score
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
 		return fmt.Errorf("could not create highscores directory: %w", err)
 	}
 
-	file, err := os.Create(filepath)
+	data, err := EncodeHighScores(scores)
 	if err != nil {
-		return fmt.Errorf("error creating high score file %s: %w", filepath, err)
+		return fmt.Errorf("error encoding high scores to %s: %w", path, err)
 	}
-	defer file.Close()
-
-	encoder := gob.NewEncoder(file)
-	// Encode the []model.Score slice
-	err = encoder.Encode(scores) // <--- Encode the slice directly
-	if err != nil {
-		return fmt.Errorf("error encoding high scores to %s: %w", filepath, err)
+	if err := writeFile(path, data); err != nil {
+		return fmt.Errorf("error writing high score file %s: %w", path, err)
 	}
-	log.Printf("High scores saved successfully to %s (%d entries)", filepath, len(scores))
+	log.Printf("High scores saved successfully to %s (%d entries)", path, len(scores))
 	return nil
 }
 
 // LoadHighScores returns []model.Score
-func LoadHighScores(filepath string) ([]model.Score, error) { // <--- Return type uses model.Score
-	file, err := os.Open(filepath)
-	if err != nil {
-		if os.IsNotExist(err) {
-			log.Printf("High score file %s not found. Returning empty list.", filepath)
-			return []model.Score{}, nil // <--- Return empty model.Score slice
+// If there is no file at path but a legacy gob file from an older version of
+// the game sits next to it, that one is converted to path (keeping it as
+// <file>.gob.bak) and loaded instead.
+func LoadHighScores(path string) ([]model.Score, error) { // <--- Return type uses model.Score
+	data, err := readFile(path)
+	if os.IsNotExist(err) {
+		if legacy := LegacyHighScorePath(path); legacy != path {
+			if _, statErr := os.Stat(legacy); statErr == nil {
+				return convertLegacyHighScores(legacy, path)
+			}
 		}
-		return nil, fmt.Errorf("error opening high score file %s: %w", filepath, err)
+		log.Printf("High score file %s not found. Returning empty list.", path)
+		return []model.Score{}, nil // <--- Return empty model.Score slice
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error opening high score file %s: %w", path, err)
 	}
-	defer file.Close()
 
-	var scores []model.Score // <--- USE model.Score
-	decoder := gob.NewDecoder(file)
-	err = decoder.Decode(&scores) // <--- Decode into model.Score slice
+	scores, err := DecodeHighScores(data)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding high scores from %s: %w", path, err)
+	}
+	log.Printf("High scores loaded successfully from %s (%d entries)", path, len(scores))
+	return scores, nil // <--- Return model.Score slice
+}
 
+// convertLegacyHighScores rewrites the gob file legacy as the JSON file path.
+func convertLegacyHighScores(legacy, path string) ([]model.Score, error) {
+	data, err := readFile(legacy)
+	if err != nil {
+		return nil, fmt.Errorf("error opening high score file %s: %w", legacy, err)
+	}
+	scores, err := DecodeHighScores(data)
 	if err != nil {
-		if errors.Is(err, io.EOF) {
-			log.Printf("Reached end of high score file %s (or file was empty).", filepath)
-			if scores == nil {
-				scores = []model.Score{} // <--- Ensure non-nil model.Score slice
+		return nil, fmt.Errorf("error decoding high scores from %s: %w", legacy, err)
+	}
+	if err := SaveHighScores(scores, path); err != nil {
+		// Still usable, conversion is retried on the next load
+		log.Printf("Warning: could not convert %s to %s: %v", legacy, path, err)
+		return scores, nil
+	}
+	if err := os.Rename(legacy, legacy+".bak"); err != nil {
+		log.Printf("Warning: could not move converted %s aside: %v", legacy, err)
+	}
+	log.Printf("Converted legacy high scores %s to %s (%d entries)", legacy, path, len(scores))
+	return scores, nil
+}
+
+// MergeHighScores combines two high score lists (e.g. from two computers)
+// into one, best first. The same entry in both, or entries from before IDs
+// with the same name and score, are only kept once, and the list is trimmed
+// to model.MaxHighScores. Ties sort as model.ByScore has them, entries from
+// before IDs from a before those from b.
+func MergeHighScores(a, b []model.Score) []model.Score {
+	seen := make(map[model.Score]bool, len(a)+len(b))
+	merged := make([]model.Score, 0, len(a)+len(b))
+	for _, list := range [][]model.Score{a, b} {
+		for _, sc := range list {
+			if seen[sc] {
+				continue
 			}
-			return scores, nil // <--- Return model.Score slice
+			seen[sc] = true
+			merged = append(merged, sc)
 		}
-		return nil, fmt.Errorf("error decoding high scores from %s: %w", filepath, err)
 	}
 
-	log.Printf("High scores loaded successfully from %s (%d entries)", filepath, len(scores))
-	return scores, nil // <--- Return model.Score slice
+	sort.Stable(model.ByScore(merged))
+	if len(merged) > model.MaxHighScores {
+		merged = merged[:model.MaxHighScores]
+	}
+	return merged
 }
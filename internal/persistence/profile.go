@@ -0,0 +1,144 @@
+package persistence
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/fileformat"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/model"
+)
+
+// Profile files hold the settings of one player profile:
+//
+//	{"schema": "catch-the-pacman/profile", "version": 1, "name": "default",
+//	 "handicap": {"slowdown": 0.8, "bounce_multiplier": 0.5}, "rivals": ["ANA"],
+//	 "quick_resume": true}
+const (
+	ProfileSchema  = "catch-the-pacman/profile"
+	ProfileVersion = 1
+)
+
+// Profile is the settings of a player profile.
+type Profile struct {
+	path     string
+	Name     string
+	Handicap model.Handicap
+	Rivals   []string // Hall of Fame names the player races, see SetRival
+	// QuickResume starts the game in the most recent save, skipping the
+	// menu, see SetQuickResume.
+	QuickResume bool
+}
+
+type profileDocument struct {
+	Schema   string         `json:"schema"`
+	Version  int            `json:"version"`
+	Name     string         `json:"name"`
+	Handicap *handicapEntry `json:"handicap,omitempty"`
+	Rivals   []string       `json:"rivals,omitempty"`
+
+	QuickResume bool `json:"quick_resume,omitempty"`
+}
+
+// LoadProfile reads the profile at path, with default settings if there is
+// no file yet.
+func LoadProfile(path, name string) (*Profile, error) {
+	p := &Profile{path: path, Name: name}
+	data, err := readFile(path)
+	if os.IsNotExist(err) {
+		return p, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading profile %s: %w", path, err)
+	}
+
+	var doc profileDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("invalid profile %s: %w", path, err)
+	}
+	if doc.Schema != ProfileSchema {
+		return nil, fmt.Errorf("%s is not a profile file (schema %q)", path, doc.Schema)
+	}
+	if doc.Version > ProfileVersion {
+		return nil, fmt.Errorf("%w: profile format version %d is newer than this game supports (%d)", fileformat.ErrUnsupportedVersion, doc.Version, ProfileVersion)
+	}
+	if doc.Handicap != nil {
+		p.Handicap = model.Handicap{Slowdown: doc.Handicap.Slowdown, BounceMultiplier: doc.Handicap.BounceMultiplier}
+		if err := p.Handicap.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid handicap in profile %s: %w", path, err)
+		}
+	}
+	p.Rivals, p.QuickResume = doc.Rivals, doc.QuickResume
+	return p, nil
+}
+
+// IsRival reports whether name is marked as a rival.
+func (p *Profile) IsRival(name string) bool {
+	return slices.Contains(p.Rivals, name)
+}
+
+// SetRival marks name as a rival or not, and saves the profile if that
+// changed it.
+func (p *Profile) SetRival(name string, rival bool) error {
+	if p.IsRival(name) == rival {
+		return nil
+	}
+	if rival {
+		p.Rivals = append(p.Rivals, name)
+	} else {
+		p.Rivals = slices.DeleteFunc(p.Rivals, func(n string) bool { return n == name })
+	}
+	return p.Save()
+}
+
+// SetQuickResume turns QuickResume on or off, and saves the profile if that
+// changed it.
+func (p *Profile) SetQuickResume(on bool) error {
+	if p.QuickResume == on {
+		return nil
+	}
+	p.QuickResume = on
+	return p.Save()
+}
+
+// UpdateHandicap changes the handicap's slowdown and bounce multiplier to
+// the ones given, leaving those that are 0 as they are, and saves the
+// profile if that changed it.
+func (p *Profile) UpdateHandicap(slowdown, bounceMultiplier float64) error {
+	h := p.Handicap
+	if slowdown != 0 {
+		h.Slowdown = slowdown
+	}
+	if bounceMultiplier != 0 {
+		h.BounceMultiplier = bounceMultiplier
+	}
+	if h == p.Handicap {
+		return nil
+	}
+	if err := h.Validate(); err != nil {
+		return err
+	}
+	p.Handicap = h
+	return p.Save()
+}
+
+// Save writes the profile to its file.
+func (p *Profile) Save() error {
+	doc := profileDocument{Schema: ProfileSchema, Version: ProfileVersion, Name: p.Name, Rivals: p.Rivals, QuickResume: p.QuickResume}
+	if p.Handicap.Active() {
+		doc.Handicap = &handicapEntry{p.Handicap.Slowdown, p.Handicap.BounceMultiplier}
+	}
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(p.path), 0755); err != nil {
+		return fmt.Errorf("could not create profiles directory: %w", err)
+	}
+	if err := writeFile(p.path, append(data, '\n')); err != nil {
+		return fmt.Errorf("error writing profile %s: %w", p.path, err)
+	}
+	return nil
+}
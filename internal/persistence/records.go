@@ -0,0 +1,156 @@
+package persistence
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/fileformat"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/model"
+)
+
+// Personal best files hold one profile's best run of every level, apart
+// from the shared Hall of Fame, and the history of its last runs:
+//
+//	{"schema": "catch-the-pacman/records", "version": 1, "profile": "default",
+//	 "levels": {"0": {"bounces": 5, "duration_ms": 42000, "date": "...", "improvement": 3}},
+//	 "runs": [{"level": 0, "bounces": 5, "duration_ms": 42000, "date": "..."}]}
+const (
+	RecordsSchema  = "catch-the-pacman/records"
+	RecordsVersion = 1
+)
+
+// MaxRuns is how many runs the history keeps. The oldest are dropped first.
+const MaxRuns = 1000
+
+// PersonalBest is a profile's best run of a level.
+type PersonalBest struct {
+	Bounces     int
+	Duration    time.Duration
+	Date        time.Time
+	Improvement int // Bounces fewer than the personal best it replaced, 0 for the first one
+}
+
+// Better reports whether pb beats other: fewer bounces, or as many in less time.
+func (pb PersonalBest) Better(other PersonalBest) bool {
+	if pb.Bounces != other.Bounces {
+		return pb.Bounces < other.Bounces
+	}
+	return pb.Duration < other.Duration
+}
+
+// Records are a profile's personal bests and run history, kept in their own file.
+type Records struct {
+	path    string
+	Profile string
+	Levels  map[int]PersonalBest
+	Runs    []model.Run // Oldest first
+}
+
+type recordsDocument struct {
+	Schema  string                  `json:"schema"`
+	Version int                     `json:"version"`
+	Profile string                  `json:"profile"`
+	Levels  map[string]recordsEntry `json:"levels"`
+	Runs    []runEntry              `json:"runs,omitempty"`
+}
+
+type recordsEntry struct {
+	Bounces     int       `json:"bounces"`
+	DurationMs  int64     `json:"duration_ms"`
+	Date        time.Time `json:"date"`
+	Improvement int       `json:"improvement,omitempty"`
+}
+
+type runEntry struct {
+	Level      int       `json:"level"`
+	Bounces    int       `json:"bounces"`
+	Failed     bool      `json:"failed,omitempty"`
+	DurationMs int64     `json:"duration_ms"`
+	Date       time.Time `json:"date"`
+}
+
+// LoadRecords reads the personal bests at path, starting empty if there is
+// no file yet.
+func LoadRecords(path, profile string) (*Records, error) {
+	r := &Records{path: path, Profile: profile, Levels: map[int]PersonalBest{}}
+	data, err := readFile(path)
+	if os.IsNotExist(err) {
+		return r, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading personal bests %s: %w", path, err)
+	}
+
+	var doc recordsDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("invalid personal bests %s: %w", path, err)
+	}
+	if doc.Schema != RecordsSchema {
+		return nil, fmt.Errorf("%s is not a personal bests file (schema %q)", path, doc.Schema)
+	}
+	if doc.Version > RecordsVersion {
+		return nil, fmt.Errorf("%w: personal bests format version %d is newer than this game supports (%d)", fileformat.ErrUnsupportedVersion, doc.Version, RecordsVersion)
+	}
+	for key, e := range doc.Levels {
+		level, err := strconv.Atoi(key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid level %q in personal bests %s", key, path)
+		}
+		r.Levels[level] = PersonalBest{e.Bounces, time.Duration(e.DurationMs) * time.Millisecond, e.Date, e.Improvement}
+	}
+	for _, e := range doc.Runs {
+		r.Runs = append(r.Runs, model.Run{Level: e.Level, Bounces: e.Bounces, Failed: e.Failed, Duration: time.Duration(e.DurationMs) * time.Millisecond, Date: e.Date})
+	}
+	return r, nil
+}
+
+// Record keeps run as the level's personal best if it beats the current
+// one, saving the records. It returns the personal best it replaced, and
+// whether there was one.
+func (r *Records) Record(level int, run PersonalBest) (improved bool, previous PersonalBest, hadPrevious bool, err error) {
+	previous, hadPrevious = r.Levels[level]
+	if hadPrevious && !run.Better(previous) {
+		return false, previous, true, nil
+	}
+	run.Improvement = 0
+	if hadPrevious {
+		run.Improvement = previous.Bounces - run.Bounces
+	}
+	r.Levels[level] = run
+	return true, previous, hadPrevious, r.Save()
+}
+
+// AddRun adds a finished run to the history, saving the records.
+func (r *Records) AddRun(run model.Run) error {
+	r.Runs = append(r.Runs, run)
+	if len(r.Runs) > MaxRuns {
+		r.Runs = r.Runs[len(r.Runs)-MaxRuns:]
+	}
+	return r.Save()
+}
+
+// Save writes the records to their file.
+func (r *Records) Save() error {
+	doc := recordsDocument{Schema: RecordsSchema, Version: RecordsVersion, Profile: r.Profile, Levels: map[string]recordsEntry{}}
+	for level, pb := range r.Levels {
+		doc.Levels[strconv.Itoa(level)] = recordsEntry{pb.Bounces, pb.Duration.Milliseconds(), pb.Date, pb.Improvement}
+	}
+	for _, run := range r.Runs {
+		doc.Runs = append(doc.Runs, runEntry{run.Level, run.Bounces, run.Failed, run.Duration.Milliseconds(), run.Date})
+	}
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0755); err != nil {
+		return fmt.Errorf("could not create records directory: %w", err)
+	}
+	if err := writeFile(r.path, append(data, '\n')); err != nil {
+		return fmt.Errorf("error writing personal bests %s: %w", r.path, err)
+	}
+	return nil
+}
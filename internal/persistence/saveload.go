@@ -2,34 +2,48 @@ package persistence
 
 import (
 	"bufio"
+	"bytes"
+	"errors"
 	"fmt"
 	"log"
+	"math"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/fileformat"
 	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/game" // Adjust path
 )
 
-// SaveGame writes the current state of the game to a text file.
-func SaveGame(g *game.Game, filepath string) error {
+// SaveFormatVersion is the version of the save file format written and read by this game (see package fileformat).
+const SaveFormatVersion = 1
+
+// metaSeed is the comment line holding the seed of the saved run's
+// randomness, which older versions of the game skip. A loaded save draws
+// from it afresh.
+const metaSeed = "# seed:"
+
+// SaveGame writes a snapshot of a game, taken by Game.RequestSaveGame, to a
+// text file.
+func SaveGame(path string, level, totalBounces int, seed uint64, pacmanData []game.PacmanSaveData) error {
 	// Ensure the saves directory exists
-	if err := os.MkdirAll("assets/saves", 0755); err != nil {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
 		return fmt.Errorf("could not create saves directory: %w", err)
 	}
 
-	// Use the game's thread-safe method to get data
-	level, totalBounces, pacmanData := g.GetDataForSave()
+	var buf bytes.Buffer
+	writer := bufio.NewWriter(&buf)
 
-	file, err := os.Create(filepath)
+	// Write header: Format version, Level and Total Bounces
+	_, err := fmt.Fprintln(writer, fileformat.Header(fileformat.KindSave, SaveFormatVersion))
 	if err != nil {
-		return fmt.Errorf("error creating save file %s: %w", filepath, err)
+		return fmt.Errorf("error writing format header to save file: %w", err)
+	}
+	_, err = fmt.Fprintf(writer, "%s %d\n", metaSeed, seed)
+	if err != nil {
+		return fmt.Errorf("error writing seed to save file: %w", err)
 	}
-	defer file.Close()
-
-	writer := bufio.NewWriter(file)
-
-	// Write header: Level and Total Bounces
 	_, err = fmt.Fprintf(writer, "%d\n", level)
 	if err != nil {
 		return fmt.Errorf("error writing level to save file: %w", err)
@@ -41,16 +55,26 @@ func SaveGame(g *game.Game, filepath string) error {
 
 	// Write each Pacman's state
 	for _, pData := range pacmanData {
-		// Format: diameter<tab>posX<tab>posY<tab>waitTimeMs<tab>direction<tab>subDirection<tab>bounces<tab>isStopped
-		line := fmt.Sprintf("%.2f\t%.2f\t%.2f\t%d\t%c\t%d\t%d\t%t\n",
-			pData.Diameter, // Save diameter
-			pData.PosX,
-			pData.PosY,
+		// Format: diameter<tab>posX<tab>posY<tab>waitTimeMs<tab>direction<tab>subDirection<tab>bounces<tab>isStopped<tab>angle<tab>dirX<tab>dirY<tab>speed
+		// The angle is the heading; direction and sub-direction are the
+		// nearest axis, for games from before Pacmans could move at an angle.
+		// The heading's components follow, as the angle doesn't give them
+		// back exactly. Numbers are written in full so a loaded game goes
+		// on exactly as the saved one would have.
+		direction, subDirection := game.HeadingAxis(pData.DirX, pData.DirY)
+		line := fmt.Sprintf("%s\t%s\t%s\t%d\t%c\t%d\t%d\t%t\t%s\t%s\t%s\t%s\n",
+			formatFloat(pData.Diameter), // Save diameter
+			formatFloat(pData.PosX),
+			formatFloat(pData.PosY),
 			pData.WaitTimeMs,
-			pData.Direction,
-			pData.SubDirection, // Save sub-direction
+			direction,
+			subDirection, // Save sub-direction
 			pData.Bounces,
 			pData.IsStopped,
+			formatFloat(game.HeadingAngle(pData.DirX, pData.DirY)),
+			formatFloat(pData.DirX),
+			formatFloat(pData.DirY),
+			formatFloat(pData.Speed),
 		)
 		_, err = writer.WriteString(line)
 		if err != nil {
@@ -62,29 +86,122 @@ func SaveGame(g *game.Game, filepath string) error {
 	if err != nil {
 		return fmt.Errorf("error flushing save file buffer: %w", err)
 	}
+	if err := writeFile(path, buf.Bytes()); err != nil {
+		return fmt.Errorf("error creating save file %s: %w", path, err)
+	}
 
-	log.Printf("Game state saved to %s", filepath)
+	log.Printf("Game state saved to %s", path)
 	return nil
 }
 
+// formatFloat writes a number of a save in as few digits as read back exactly.
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// Defaults for the fields of a Pac-Man row that can't be recovered.
+const (
+	defaultWaitTimeMs = 80
+	minRecoverFields  = 3 // Diameter and position are needed to salvage a row
+)
+
+// Errors LoadGame and RecoverGame return, wrapped with the file's path, so
+// callers can tell "no save yet" from "your save is broken" with errors.Is.
+// A save written by a newer game fails with fileformat.ErrUnsupportedVersion.
+var (
+	ErrSaveNotFound = errors.New("no save file")
+	ErrCorruptSave  = errors.New("save file is corrupted")
+)
+
+// DamagedSaveError is returned by LoadGame when a save file has lines it
+// can't read. RecoverGame can load what's left of it. It counts as
+// ErrCorruptSave.
+type DamagedSaveError struct {
+	Path     string
+	Problems []string // One entry per damaged line or value
+}
+
+func (e *DamagedSaveError) Error() string {
+	return fmt.Sprintf("save file %s is damaged (%d problems, first: %s)", e.Path, len(e.Problems), e.Problems[0])
+}
+
+func (e *DamagedSaveError) Unwrap() error {
+	return ErrCorruptSave
+}
+
 // LoadGame reads a game state from a text file.
 // Returns a *partial* game object containing loaded state.
+// A file with damaged lines is refused with a *DamagedSaveError rather than
+// loaded with Pacmans or bounces missing.
 func LoadGame(filepath string) (*game.Game, error) {
-	file, err := os.Open(filepath)
+	loadedGame, problems, err := readSave(filepath)
+	if err != nil {
+		return nil, err
+	}
+	if len(problems) > 0 {
+		for _, problem := range problems {
+			log.Printf("Warning: %s: %s", filepath, problem)
+		}
+		return nil, &DamagedSaveError{Path: filepath, Problems: problems}
+	}
+
+	log.Printf("Loaded game state from %s: Level %d, Bounces %d, %d Pacmans.", filepath, loadedGame.Level, loadedGame.TotalBounces, len(loadedGame.Pacmans))
+	return loadedGame, nil
+}
+
+// RecoverGame loads as much as possible from a damaged save file. It returns
+// the salvaged state along with what had to be dropped or guessed, and only
+// fails if not even the level can be worked out.
+func RecoverGame(filepath string) (*game.Game, []string, error) {
+	loadedGame, problems, err := readSave(filepath)
+	if err != nil {
+		return nil, nil, err
+	}
+	log.Printf("Recovered game state from %s: Level %d, Bounces %d, %d Pacmans (%d problems).",
+		filepath, loadedGame.Level, loadedGame.TotalBounces, len(loadedGame.Pacmans), len(problems))
+	return loadedGame, problems, nil
+}
+
+// RepairSave rewrites a damaged save file with what RecoverGame salvages
+// from it, keeping the original as <file>.damaged. It returns the problems
+// found; a file without any is left untouched.
+func RepairSave(filepath string) ([]string, error) {
+	recovered, problems, err := RecoverGame(filepath)
+	if err != nil || len(problems) == 0 {
+		return problems, err
+	}
+	if err := os.Rename(filepath, filepath+".damaged"); err != nil {
+		return problems, fmt.Errorf("error keeping damaged save %s: %w", filepath, err)
+	}
+	level, totalBounces, pacmans := recovered.GetDataForSave()
+	if err := SaveGame(filepath, level, totalBounces, recovered.Seed, pacmans); err != nil {
+		return problems, err
+	}
+	return problems, nil
+}
+
+// readSave parses a save file, salvaging what it can. Every line that had to
+// be dropped or patched up is described in problems.
+func readSave(filepath string) (*game.Game, []string, error) {
+	data, err := readFile(filepath)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return nil, fmt.Errorf("save file '%s' not found", filepath)
+			return nil, nil, fmt.Errorf("save file '%s': %w", filepath, ErrSaveNotFound)
 		}
-		return nil, fmt.Errorf("error opening save file %s: %w", filepath, err)
+		return nil, nil, fmt.Errorf("error opening save file %s: %w", filepath, err)
 	}
-	defer file.Close()
 
-	scanner := bufio.NewScanner(file)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
 	lineNum := 0
 	level := -1
 	totalBounces := -1
+	var seed uint64 // The level's own, for saves from before seeds
 	pacmans := []*game.Pacman{}
 	idCounter := 0
+	var problems []string
+	problemf := func(format string, args ...any) {
+		problems = append(problems, fmt.Sprintf("line %d: ", lineNum)+fmt.Sprintf(format, args...))
+	}
 
 	for scanner.Scan() {
 		lineNum++
@@ -92,100 +209,208 @@ func LoadGame(filepath string) (*game.Game, error) {
 
 		// Skip potential blank lines or comments if any were accidentally saved
 		if line == "" || strings.HasPrefix(line, "#") {
+			if err := fileformat.Check(line, fileformat.KindSave, SaveFormatVersion); err != nil {
+				if !errors.Is(err, fileformat.ErrUnsupportedVersion) {
+					err = fmt.Errorf("%w: %w", ErrCorruptSave, err)
+				}
+				return nil, nil, fmt.Errorf("save file %s: %w", filepath, err)
+			}
+			if value, ok := strings.CutPrefix(line, metaSeed); ok {
+				if seed, err = strconv.ParseUint(strings.TrimSpace(value), 10, 64); err != nil {
+					problemf("seed unreadable ('%s'), using the level's own", strings.TrimSpace(value))
+					seed = 0
+				}
+			}
 			continue
 		}
+		isRow := strings.Contains(line, "\t")
 
 		// First non-blank line is the level
 		if level == -1 {
 			levelVal, err := strconv.Atoi(line)
-			if err != nil {
-				return nil, fmt.Errorf("line %d: expected level number, got '%s': %w", lineNum, line, err)
+			if err == nil && levelVal >= 0 {
+				level = levelVal
+				continue
+			}
+			level = levelFromPath(filepath)
+			if level < 0 {
+				return nil, nil, fmt.Errorf("%w: line %d: expected level number, got '%s'", ErrCorruptSave, lineNum, line)
+			}
+			problemf("level number unreadable ('%s'), assuming level %d from the file name", line, level)
+			if !isRow {
+				continue
 			}
-			level = levelVal
-			continue
 		}
 
 		// Second non-blank line is total bounces
-		if totalBounces == -1 {
+		if totalBounces == -1 && !isRow {
 			bouncesVal, err := strconv.Atoi(line)
-			if err != nil {
-				return nil, fmt.Errorf("line %d: expected total bounces number, got '%s': %w", lineNum, line, err)
+			if err != nil || bouncesVal < 0 {
+				problemf("total bounces unreadable ('%s')", line)
+				totalBounces = -2 // Worked out from the Pacmans below
+			} else {
+				totalBounces = bouncesVal
 			}
-			totalBounces = bouncesVal
 			continue
 		}
+		if totalBounces == -1 {
+			problemf("total bounces missing")
+			totalBounces = -2
+		}
 
 		// Subsequent lines are Pac-Man definitions
-		parts := strings.Split(line, "\t")
-		// Expected format: diameter, posX, posY, waitTimeMs, direction, subDirection, bounces, isStopped (8 fields)
-		if len(parts) < 8 {
-			log.Printf("Warning line %d: Invalid Pac-Man save data in %s. Expected 8 tab-separated fields, got %d. Skipping line.", lineNum, filepath, len(parts))
-			continue
+		pacman, ok := parsePacmanRow(idCounter, strings.Split(line, "\t"), problemf)
+		if ok {
+			pacmans = append(pacmans, pacman)
+			idCounter++
 		}
+	}
 
-		diameter, errDia := strconv.ParseFloat(parts[0], 64)
-		posX, errX := strconv.ParseFloat(parts[1], 64)
-		posY, errY := strconv.ParseFloat(parts[2], 64)
-		waitTimeMs, errWait := strconv.Atoi(parts[3])
-		directionStr := parts[4]
-		subDirection, errSubDir := strconv.Atoi(parts[5])
-		bounces, errBounce := strconv.Atoi(parts[6])
-		isStoppedStr := strings.ToLower(parts[7]) // Case-insensitive boolean
+	if err := scanner.Err(); err != nil {
+		// Keep what was read before the error, it's usually a truncated file
+		problems = append(problems, fmt.Sprintf("file unreadable after line %d: %v", lineNum, err))
+	}
 
-		if errDia != nil || errX != nil || errY != nil || errWait != nil || errSubDir != nil || errBounce != nil {
-			log.Printf("Warning line %d: Error parsing values for saved Pac-Man in %s. Skipping line. Errors: %v,%v,%v,%v,%v,%v",
-				lineNum, filepath, errDia, errX, errY, errWait, errSubDir, errBounce)
-			continue
+	if level == -1 {
+		level = levelFromPath(filepath)
+		if level < 0 {
+			return nil, nil, fmt.Errorf("%w: save file %s did not contain valid level or bounce data", ErrCorruptSave, filepath)
+		}
+		problems = append(problems, fmt.Sprintf("file is empty, assuming level %d from the file name", level))
+	}
+	if totalBounces < 0 {
+		if totalBounces == -1 {
+			problems = append(problems, "total bounces missing")
 		}
+		// Each Pacman counts its own bounces, so their sum is a lower bound
+		totalBounces = 0
+		for _, p := range pacmans {
+			totalBounces += p.Bounces
+		}
+		problems = append(problems, fmt.Sprintf("total bounces rebuilt from the Pacmans as %d", totalBounces))
+	}
 
-		var direction rune
-		if len(directionStr) > 0 {
-			d := strings.ToUpper(directionStr)[0]
-			if d == game.DirHorizontal || d == game.DirVertical {
-				direction = rune(d)
-			} else {
-				log.Printf("Warning line %d: Invalid direction '%s' for loaded Pac-Man in %s. Defaulting to Horizontal.", lineNum, directionStr, filepath)
-				direction = game.DirHorizontal // Default on load error?
-			}
-		} else {
-			log.Printf("Warning line %d: Missing direction for loaded Pac-Man in %s. Defaulting to Horizontal.", lineNum, filepath)
-			direction = game.DirHorizontal
+	// Return a *partial* Game struct containing the loaded state
+	loadedGame := &game.Game{
+		Level:        level,
+		TotalBounces: totalBounces,
+		Seed:         seed,
+		Pacmans:      pacmans,
+	}
+	return loadedGame, problems, nil
+}
+
+// parsePacmanRow reads one saved Pac-Man, reporting damaged values through
+// problemf. Rows that are cut short keep their position and get default
+// values for the rest; ok is false if not even that is left.
+func parsePacmanRow(id int, parts []string, problemf func(format string, args ...any)) (pacman *game.Pacman, ok bool) {
+	// Expected format: diameter, posX, posY, waitTimeMs, direction, subDirection, bounces, isStopped (8 fields),
+	// then the angle and the heading's components, missing from saves written before
+	// Pacmans could move at an angle or were saved exactly, and the speed, missing
+	// from saves written before they collided elastically
+	var values [3]float64
+	for i := 0; i < minRecoverFields; i++ {
+		var err error
+		if i < len(parts) {
+			values[i], err = strconv.ParseFloat(parts[i], 64)
+		}
+		if i >= len(parts) || err != nil || !finite(values[i]) {
+			problemf("Pac-Man unreadable, dropped")
+			return nil, false
+		}
+	}
+	diameter, posX, posY := values[0], values[1], values[2]
+	if diameter <= 0 {
+		problemf("Pac-Man has invalid diameter %v, dropped", diameter)
+		return nil, false
+	}
+	if len(parts) < 8 {
+		problemf("Pac-Man cut short (%d of 8 fields), missing values set to defaults", len(parts))
+	}
+	field := func(i int) string {
+		if i < len(parts) {
+			return parts[i]
 		}
+		return ""
+	}
 
-		if subDirection != 1 && subDirection != -1 {
-			log.Printf("Warning line %d: Invalid sub-direction '%d' for loaded Pac-Man in %s. Defaulting to 1.", lineNum, subDirection, filepath)
-			subDirection = 1
+	waitTimeMs, err := strconv.Atoi(field(3))
+	if err != nil || waitTimeMs <= 0 {
+		if field(3) != "" {
+			problemf("invalid wait time '%s', using %d", field(3), defaultWaitTimeMs)
 		}
+		waitTimeMs = defaultWaitTimeMs
+	}
 
-		isStopped := (isStoppedStr == "true" || isStoppedStr == "1")
+	direction := rune(game.DirHorizontal)
+	switch d := strings.ToUpper(field(4)); {
+	case d == string(game.DirHorizontal) || d == string(game.DirVertical):
+		direction = rune(d[0])
+	case d != "":
+		problemf("invalid direction '%s', using horizontal", field(4))
+	}
 
-		radius := diameter / 2.0
-		if radius <= 0 {
-			log.Printf("Warning line %d: Invalid diameter/radius (<=0) for loaded Pac-Man in %s. Skipping.", lineNum, filepath)
-			continue
+	subDirection, err := strconv.Atoi(field(5))
+	if err != nil || (subDirection != 1 && subDirection != -1) {
+		if field(5) != "" {
+			problemf("invalid sub-direction '%s', using 1", field(5))
 		}
-
-		pacman := game.NewPacman(idCounter, radius, posX, posY, direction, subDirection, waitTimeMs, bounces, isStopped)
-		pacmans = append(pacmans, pacman)
-		idCounter++
+		subDirection = 1
 	}
 
-	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("error reading save file %s: %w", filepath, err)
+	bounces, err := strconv.Atoi(field(6))
+	if err != nil || bounces < 0 {
+		if field(6) != "" {
+			problemf("invalid bounce count '%s', using 0", field(6))
+		}
+		bounces = 0
 	}
 
-	if level == -1 || totalBounces == -1 {
-		return nil, fmt.Errorf("save file %s did not contain valid level or bounce data", filepath)
+	isStoppedStr := strings.ToLower(field(7)) // Case-insensitive boolean
+	isStopped := isStoppedStr == "true" || isStoppedStr == "1"
+
+	dirX, dirY := game.AxisHeading(direction, subDirection)
+	if field(8) != "" {
+		if angle, err := strconv.ParseFloat(field(8), 64); err != nil || !finite(angle) {
+			problemf("invalid angle '%s', using the direction", field(8))
+		} else {
+			dirX, dirY = game.AngleHeading(angle)
+		}
 	}
 
-	// Return a *partial* Game struct containing the loaded state
-	loadedGame := &game.Game{
-		Level:        level,
-		TotalBounces: totalBounces,
-		Pacmans:      pacmans,
+	pacman = game.NewPacman(id, diameter/2.0, posX, posY, dirX, dirY, waitTimeMs, bounces, isStopped)
+	if field(9) != "" || field(10) != "" {
+		headX, errX := strconv.ParseFloat(field(9), 64)
+		headY, errY := strconv.ParseFloat(field(10), 64)
+		// A heading is a unit vector, but for rounding
+		if errX != nil || errY != nil || !finite(headX) || !finite(headY) || math.Abs(math.Hypot(headX, headY)-1) > 1e-9 {
+			problemf("invalid heading '%s' '%s', using the angle", field(9), field(10))
+		} else {
+			pacman.DirX, pacman.DirY = headX, headY
+		}
+	}
+	if field(11) != "" {
+		if speed, err := strconv.ParseFloat(field(11), 64); err != nil || speed < 0 || !finite(speed) {
+			problemf("invalid speed '%s', using the wait time's", field(11))
+		} else {
+			pacman.Speed = speed
+		}
 	}
+	return pacman, true
+}
 
-	log.Printf("Loaded game state from %s: Level %d, Bounces %d, %d Pacmans.", filepath, level, totalBounces, len(pacmans))
+// finite reports whether f is a number, neither NaN nor infinite.
+func finite(f float64) bool {
+	return !math.IsNaN(f) && !math.IsInf(f, 0)
+}
 
-	return loadedGame, nil
+// levelFromPath works out the level of a save from its standard file name
+// (savegame_<level>.txt), or returns -1.
+func levelFromPath(path string) int {
+	var level int
+	name := filepath.Base(path)
+	if _, err := fmt.Sscanf(name, "savegame_%d.txt", &level); err != nil || name != fmt.Sprintf("savegame_%d.txt", level) {
+		return -1
+	}
+	return level
 }
@@ -0,0 +1,68 @@
+package persistence
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/game"
+)
+
+// kept returns what a save keeps of a loaded game.
+func kept(g *game.Game) *game.Game {
+	return &game.Game{Level: g.Level, TotalBounces: g.TotalBounces, Seed: g.Seed, Pacmans: g.Pacmans}
+}
+
+// checkRoundTrip saves g, as loaded from a file that parsed, and checks
+// the save loads back to an identical game.
+func checkRoundTrip(t *testing.T, g *game.Game) {
+	t.Helper()
+	level, totalBounces, pacmans := g.GetDataForSave()
+	path := filepath.Join(t.TempDir(), "saves", "savegame_0.txt")
+	if err := SaveGame(path, level, totalBounces, g.Seed, pacmans); err != nil {
+		t.Fatalf("SaveGame: %v", err)
+	}
+	loaded, err := LoadGame(path)
+	if err != nil {
+		t.Fatalf("saved game doesn't load back: %v", err)
+	}
+	if !reflect.DeepEqual(kept(loaded), kept(g)) {
+		data, _ := os.ReadFile(path)
+		_, _, loadedPacmans := loaded.GetDataForSave()
+		t.Fatalf("saved game loads back different:\nlevel %d, %d bounces, seed %d: %+v\nwas level %d, %d bounces, seed %d: %+v\nsave:\n%s",
+			loaded.Level, loaded.TotalBounces, loaded.Seed, loadedPacmans, level, totalBounces, g.Seed, pacmans, data)
+	}
+}
+
+func FuzzLoadGame(f *testing.F) {
+	bundled, err := os.ReadFile(filepath.Join("..", "..", "assets", "saves", "savegame_0.txt"))
+	if err != nil {
+		f.Fatal(err)
+	}
+	f.Add(bundled)
+	f.Add([]byte("# format: save 1\n# seed: 42\n1\n7\n40\t100.125\t200\t80\tH\t-1\t3\tfalse\t180\t-1\t0\t75.5\n30\t50\t60\t100\tV\t1\t4\ttrue\t45\t0.7071067811865476\t0.7071067811865475\t60\n"))
+	f.Add([]byte("2\n0\n40\t1\t2\n"))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		path := filepath.Join(t.TempDir(), "savegame_0.txt")
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			t.Fatal(err)
+		}
+		g, err := LoadGame(path)
+		if err != nil {
+			return
+		}
+		checkRoundTrip(t, g)
+	})
+}
+
+func TestSaveGameRoundTrip(t *testing.T) {
+	// Pacmans partway through a run, at positions and speeds no short
+	// decimal holds and a heading off every axis
+	a := game.NewPacman(0, 20, 123.456789012345, 98.7654321, 3, 4, 80, 5, false)
+	a.Speed = 71.23456789
+	b := game.NewPacman(1, 15, 1.0/3, 2.0/3, 0, -1, 100, 0, true)
+	g := &game.Game{Level: 2, TotalBounces: 17, Seed: 1<<64 - 1, Pacmans: []*game.Pacman{a, b}}
+	checkRoundTrip(t, g)
+}
@@ -0,0 +1,157 @@
+package persistence
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/png"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/fileformat"
+)
+
+// Save slot metadata files sit next to their save, named after it, so the
+// save browser can show a save without loading it:
+//
+//	{"schema": "catch-the-pacman/slot", "version": 1, "level": 0, "bounces": 12,
+//	 "elapsed_ms": 42000, "saved": "...", "thumbnail": "<base64 PNG>"}
+const (
+	SlotSchema  = "catch-the-pacman/slot"
+	SlotVersion = 1
+)
+
+// SaveSlot is a saved game as the save browser shows it.
+type SaveSlot struct {
+	Path      string // The save file
+	Level     int
+	Bounces   int
+	Elapsed   time.Duration // Game time played when it was saved
+	Saved     time.Time
+	Thumbnail image.Image // The frame shown when it was saved, nil if none was captured
+}
+
+type slotDocument struct {
+	Schema    string    `json:"schema"`
+	Version   int       `json:"version"`
+	Level     int       `json:"level"`
+	Bounces   int       `json:"bounces"`
+	ElapsedMs int64     `json:"elapsed_ms"`
+	Saved     time.Time `json:"saved"`
+	Thumbnail []byte    `json:"thumbnail,omitempty"` // PNG
+}
+
+// SlotMetaPath returns the metadata file of the save at savePath.
+func SlotMetaPath(savePath string) string {
+	return strings.TrimSuffix(savePath, filepath.Ext(savePath)) + ".meta.json"
+}
+
+// SaveSlotMeta writes the metadata file of slot's save.
+func SaveSlotMeta(slot SaveSlot) error {
+	doc := slotDocument{Schema: SlotSchema, Version: SlotVersion, Level: slot.Level, Bounces: slot.Bounces,
+		ElapsedMs: slot.Elapsed.Milliseconds(), Saved: slot.Saved}
+	if slot.Thumbnail != nil {
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, slot.Thumbnail); err != nil {
+			return fmt.Errorf("error encoding thumbnail of %s: %w", slot.Path, err)
+		}
+		doc.Thumbnail = buf.Bytes()
+	}
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	path := SlotMetaPath(slot.Path)
+	if err := writeFile(path, append(data, '\n')); err != nil {
+		return fmt.Errorf("error writing save slot metadata %s: %w", path, err)
+	}
+	return nil
+}
+
+// LoadSaveSlot reads what the metadata file of the save at savePath tells
+// about it. Saves from before metadata files, or whose file can't be read,
+// are described from the save itself, without elapsed time or thumbnail.
+func LoadSaveSlot(savePath string) (SaveSlot, error) {
+	info, err := os.Stat(savePath)
+	if err != nil {
+		return SaveSlot{}, err
+	}
+	slot, err := loadSlotMeta(savePath)
+	if err == nil {
+		return slot, nil
+	}
+	if !os.IsNotExist(err) {
+		log.Printf("Ignoring save slot metadata: %v", err)
+	}
+
+	slot = SaveSlot{Path: savePath, Level: levelFromPath(savePath), Saved: info.ModTime()}
+	if g, err := LoadGame(savePath); err == nil {
+		slot.Level, slot.Bounces = g.Level, g.TotalBounces
+	}
+	return slot, nil
+}
+
+// loadSlotMeta reads the metadata file of the save at savePath.
+func loadSlotMeta(savePath string) (SaveSlot, error) {
+	path := SlotMetaPath(savePath)
+	data, err := readFile(path)
+	if err != nil {
+		return SaveSlot{}, err
+	}
+	var doc slotDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return SaveSlot{}, fmt.Errorf("invalid save slot metadata %s: %w", path, err)
+	}
+	if doc.Schema != SlotSchema {
+		return SaveSlot{}, fmt.Errorf("%s is not a save slot metadata file (schema %q)", path, doc.Schema)
+	}
+	if doc.Version > SlotVersion {
+		return SaveSlot{}, fmt.Errorf("%w: save slot metadata version %d is newer than this game supports (%d)", fileformat.ErrUnsupportedVersion, doc.Version, SlotVersion)
+	}
+	slot := SaveSlot{Path: savePath, Level: doc.Level, Bounces: doc.Bounces,
+		Elapsed: time.Duration(doc.ElapsedMs) * time.Millisecond, Saved: doc.Saved}
+	if len(doc.Thumbnail) > 0 {
+		if slot.Thumbnail, err = png.Decode(bytes.NewReader(doc.Thumbnail)); err != nil {
+			log.Printf("Ignoring thumbnail of %s: %v", savePath, err)
+		}
+	}
+	return slot, nil
+}
+
+// ListSaveSlots describes the saves of the standard levels in dir, level by
+// level. A missing directory has none.
+func ListSaveSlots(dir string) ([]SaveSlot, error) {
+	saves, err := filepath.Glob(filepath.Join(dir, "savegame_*.txt"))
+	if err != nil {
+		return nil, err
+	}
+	var slots []SaveSlot
+	for _, path := range saves {
+		if levelFromPath(path) < 0 {
+			continue
+		}
+		slot, err := LoadSaveSlot(path)
+		if err != nil {
+			return nil, err
+		}
+		slots = append(slots, slot)
+	}
+	slices.SortFunc(slots, func(a, b SaveSlot) int { return cmp.Compare(levelFromPath(a.Path), levelFromPath(b.Path)) })
+	return slots, nil
+}
+
+// LatestSaveSlot returns the save of the standard levels in dir saved last,
+// autosaves included. It reports false if there's none.
+func LatestSaveSlot(dir string) (SaveSlot, bool, error) {
+	slots, err := ListSaveSlots(dir)
+	if err != nil || len(slots) == 0 {
+		return SaveSlot{}, false, err
+	}
+	return slices.MaxFunc(slots, func(a, b SaveSlot) int { return a.Saved.Compare(b.Saved) }), true, nil
+}
@@ -0,0 +1,8 @@
+//go:build !steam
+
+package platform
+
+// New returns the platform this binary was built for.
+func New() (Platform, error) {
+	return Stub{}, nil
+}
@@ -0,0 +1,16 @@
+// Package platform mirrors achievement unlocks to a storefront's online
+// services. Scores go to the game's own score server, see package
+// leaderboard, not to the storefront's leaderboards. The implementation is
+// picked at build time: the default build only logs, building with
+// -tags steam talks to Steamworks.
+package platform
+
+// Platform is a storefront's achievements service.
+type Platform interface {
+	// Name identifies the platform in logs.
+	Name() string
+	// UnlockAchievement marks an achievement as unlocked. Unlocking twice is harmless.
+	UnlockAchievement(id string) error
+	// Close releases the platform's resources.
+	Close() error
+}
@@ -0,0 +1,54 @@
+//go:build steam
+
+package platform
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+
+	"github.com/hajimehoshi/go-steamworks"
+)
+
+// steamAppIDEnv overrides the Steam app ID, e.g. 480 (Spacewar) for testing.
+const steamAppIDEnv = "PACMAN_STEAM_APP_ID"
+
+// Steam mirrors achievements to Steamworks. Achievement IDs are used as the
+// API names configured in the Steamworks partner site.
+type Steam struct{}
+
+// New returns the platform this binary was built for.
+func New() (Platform, error) {
+	appID, err := strconv.ParseUint(os.Getenv(steamAppIDEnv), 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be set to the Steam app ID: %w", steamAppIDEnv, err)
+	}
+	if steamworks.RestartAppIfNecessary(uint32(appID)) {
+		// Steam is relaunching the game through the client
+		os.Exit(0)
+	}
+	if err := steamworks.Init(); err != nil {
+		return nil, fmt.Errorf("could not initialize Steamworks: %w", err)
+	}
+	log.Printf("Steamworks initialized for app %d.", appID)
+	return Steam{}, nil
+}
+
+func (Steam) Name() string { return "steam" }
+
+func (Steam) UnlockAchievement(id string) error {
+	stats := steamworks.SteamUserStats()
+	if achieved, ok := stats.GetAchievement(id); ok && achieved {
+		return nil
+	}
+	if !stats.SetAchievement(id) {
+		return fmt.Errorf("steam rejected achievement %s", id)
+	}
+	if !stats.StoreStats() {
+		return fmt.Errorf("could not store steam stats after unlocking %s", id)
+	}
+	return nil
+}
+
+func (Steam) Close() error { return nil }
@@ -0,0 +1,114 @@
+// Package scoreapi defines the versioned JSON API spoken between the game's
+// leaderboard client and the score server.
+//
+//	GET  /v1/levels/{level}/scores?offset=0&limit=10  one page of a leaderboard
+//	     &assisted=false                               only unassisted runs (true: only assisted ones)
+//	POST /v1/sessions                                  start a run, getting the session token its score is submitted with
+//	POST /v1/scores                                    submit a score (optionally signed)
+//
+// Every non-2xx reply carries an ErrorResponse body.
+package scoreapi
+
+import (
+	"time"
+
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/model"
+)
+
+// Version is the path prefix of the current API version.
+const Version = "/v1"
+
+// SessionLifetime is how long a session token can be submitted with after
+// the run started.
+const SessionLifetime = 2 * time.Hour
+
+// Pagination limits for leaderboard queries.
+const (
+	DefaultPageSize = 10
+	MaxPageSize     = 100
+)
+
+// Entry is one leaderboard row.
+type Entry struct {
+	Rank        int       `json:"rank"`
+	Name        string    `json:"name"`
+	Score       int       `json:"score"`
+	SpriteScale float64   `json:"sprite_scale,omitempty"` // Set for runs played with enlarged Pacmans
+	Misses      int       `json:"misses,omitempty"`       // Missed clicks included in Score, for runs with accuracy scoring
+	Handicap    *Handicap `json:"handicap,omitempty"`     // Set for runs played with a handicap
+	Chain       int       `json:"chain,omitempty"`        // Highest chain multiplier, for levels scoring chains
+	ChainBonus  int       `json:"chain_bonus,omitempty"`  // Bounces chains took off Score
+}
+
+// Handicap is the handicap a run was played with, see model.Handicap.
+type Handicap struct {
+	Slowdown         float64 `json:"slowdown,omitempty"`
+	BounceMultiplier float64 `json:"bounce_multiplier,omitempty"`
+}
+
+// NewHandicap returns the API form of a handicap, nil if there is none.
+func NewHandicap(h model.Handicap) *Handicap {
+	if !h.Active() {
+		return nil
+	}
+	return &Handicap{h.Slowdown, h.BounceMultiplier}
+}
+
+// Model returns the handicap as a model.Handicap; a nil one is no handicap.
+func (h *Handicap) Model() model.Handicap {
+	if h == nil {
+		return model.Handicap{}
+	}
+	return model.Handicap{Slowdown: h.Slowdown, BounceMultiplier: h.BounceMultiplier}
+}
+
+// ScoresPage is the reply to a leaderboard query.
+type ScoresPage struct {
+	Level      int     `json:"level"`
+	Total      int     `json:"total"`  // Number of entries on the whole leaderboard
+	Offset     int     `json:"offset"` // Index of the first entry of this page
+	Scores     []Entry `json:"scores"`
+	NextOffset *int    `json:"next_offset,omitempty"` // Offset of the next page, absent on the last one
+}
+
+// SubmitRequest is the body of a score submission.
+type SubmitRequest struct {
+	Level       int       `json:"level"`
+	Name        string    `json:"name"`
+	Score       int       `json:"score"`
+	SpriteScale float64   `json:"sprite_scale,omitempty"` // Accessibility sprite scale, omitted for unassisted runs
+	Misses      int       `json:"misses,omitempty"`       // Missed clicks included in Score, for runs with accuracy scoring
+	Handicap    *Handicap `json:"handicap,omitempty"`     // Handicap the run was played with, omitted for none
+	Chain       int       `json:"chain,omitempty"`        // Highest chain multiplier, omitted for levels without chains
+	ChainBonus  int       `json:"chain_bonus,omitempty"`  // Bounces chains took off Score
+
+	// The run behind the score, for the server to check it's plausible.
+	// Omitted by clients that didn't start a session.
+	Session string  `json:"session,omitempty"`  // Token of the SessionResponse the run started with
+	RunHash string  `json:"run_hash,omitempty"` // See HashRun
+	Elapsed float64 `json:"elapsed,omitempty"`  // Seconds the run lasted in game time
+	Catches int     `json:"catches,omitempty"`
+}
+
+// SessionRequest starts a run of a level.
+type SessionRequest struct {
+	Level int `json:"level"`
+}
+
+// SessionResponse carries the token a run's score is submitted with. The
+// server signs it, and it can be used once, within SessionLifetime.
+type SessionResponse struct {
+	Token string `json:"token"`
+}
+
+// SubmitResponse tells the client whether its score made the leaderboard,
+// and where it landed if it did.
+type SubmitResponse struct {
+	Added bool `json:"added"`
+	Rank  int  `json:"rank,omitempty"`
+}
+
+// ErrorResponse is the body of every non-2xx reply.
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
@@ -0,0 +1,72 @@
+package scoreapi
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Headers carrying a request signature.
+const (
+	HeaderTimestamp = "X-Score-Timestamp"
+	HeaderSignature = "X-Score-Signature"
+)
+
+// MaxClockSkew is how far a signed request's timestamp may be from the server's clock.
+const MaxClockSkew = 5 * time.Minute
+
+var (
+	ErrMissingSignature = errors.New("request is not signed")
+	ErrStaleSignature   = errors.New("request timestamp is too far from server time")
+	ErrBadSignature     = errors.New("request signature does not match")
+)
+
+// Sign computes the signature of a request: a hex HMAC-SHA256, keyed with the
+// shared secret, over the method, path, unix timestamp and body.
+func Sign(secret []byte, method, path string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(method + "\n" + path + "\n" + strconv.FormatInt(timestamp, 10) + "\n"))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the timestamp and signature header values of a request.
+func Verify(secret []byte, method, path, timestampHeader, signatureHeader string, body []byte, now time.Time) error {
+	if timestampHeader == "" || signatureHeader == "" {
+		return ErrMissingSignature
+	}
+	timestamp, err := strconv.ParseInt(timestampHeader, 10, 64)
+	if err != nil {
+		return ErrBadSignature
+	}
+	if skew := now.Sub(time.Unix(timestamp, 0)); skew > MaxClockSkew || skew < -MaxClockSkew {
+		return ErrStaleSignature
+	}
+
+	got, err := hex.DecodeString(signatureHeader)
+	if err != nil {
+		return ErrBadSignature
+	}
+	want, _ := hex.DecodeString(Sign(secret, method, path, timestamp, body))
+	if !hmac.Equal(got, want) {
+		return ErrBadSignature
+	}
+	return nil
+}
+
+// HashRun commits a submission to the run behind it: a hex SHA-256 over the
+// session token, the level's layout hash and every click of the run, in
+// order. The server logs it with the score, so a suspicious entry can later
+// be checked against the player's replay.
+func HashRun(session, layout string, clicks [][2]float64) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\n%s\n", session, layout)
+	for _, c := range clicks {
+		fmt.Fprintf(h, "%g %g\n", c[0], c[1])
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
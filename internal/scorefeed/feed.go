@@ -0,0 +1,178 @@
+// Package scorefeed renders high score files into a static HTML page and a
+// JSON feed, so the leaderboards can be embedded on a website.
+package scorefeed
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/model"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/persistence"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/scoreapi"
+)
+
+// Names of the generated files.
+const (
+	HTMLFile = "index.html"
+	JSONFile = "scores.json"
+)
+
+//go:embed feed.html
+var pageSource string
+
+var page = template.Must(template.New(HTMLFile).Parse(pageSource))
+
+// Feed is the content of the JSON feed.
+type Feed struct {
+	Generated time.Time `json:"generated"`
+	Levels    []Level   `json:"levels"`
+}
+
+// Level is the leaderboard of one level, best score first.
+type Level struct {
+	Level  int              `json:"level"`
+	Scores []scoreapi.Entry `json:"scores"`
+}
+
+// Collect reads every high score file (highscores_<level>.json, or a legacy
+// .gob one) in dir, lowest level first.
+func Collect(dir string) (Feed, error) {
+	files, err := scoreFiles(dir)
+	if err != nil {
+		return Feed{}, err
+	}
+	levels := make(map[int][]model.Score, len(files))
+	for _, f := range files {
+		if levels[f.level], err = persistence.LoadHighScores(f.path); err != nil {
+			return Feed{}, err
+		}
+	}
+	return Build(levels), nil
+}
+
+// Build makes the feed of the leaderboards of levels, best score first,
+// lowest level first.
+func Build(levels map[int][]model.Score) Feed {
+	feed := Feed{Generated: time.Now().UTC(), Levels: []Level{}}
+	for _, number := range slices.Sorted(maps.Keys(levels)) {
+		scores := levels[number]
+		level := Level{Level: number, Scores: make([]scoreapi.Entry, len(scores))}
+		for i, sc := range scores {
+			level.Scores[i] = scoreapi.Entry{Rank: i + 1, Name: sc.Name, Score: sc.Score, Misses: sc.Misses, Handicap: scoreapi.NewHandicap(sc.Handicap),
+				Chain: sc.Chain, ChainBonus: sc.ChainBonus}
+			if sc.Assisted() {
+				level.Scores[i].SpriteScale = sc.SpriteScale
+			}
+		}
+		feed.Levels = append(feed.Levels, level)
+	}
+	return feed
+}
+
+// Write renders feed into outDir as HTMLFile and JSONFile. Each file is
+// replaced in one step, so a web server never hands out a half-written one.
+func Write(feed Feed, outDir string) error {
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		return fmt.Errorf("could not create feed directory %s: %w", outDir, err)
+	}
+
+	err := writeFile(filepath.Join(outDir, JSONFile), func(f *os.File) error {
+		enc := json.NewEncoder(f)
+		enc.SetIndent("", "  ")
+		return enc.Encode(feed)
+	})
+	if err != nil {
+		return err
+	}
+	return writeFile(filepath.Join(outDir, HTMLFile), func(f *os.File) error {
+		return page.Execute(f, feed)
+	})
+}
+
+// Generate collects the scores in dir and writes the feed into outDir.
+func Generate(dir, outDir string) error {
+	feed, err := Collect(dir)
+	if err != nil {
+		return err
+	}
+	return Write(feed, outDir)
+}
+
+// writeFile writes path through a temporary file renamed into place.
+func writeFile(path string, write func(f *os.File) error) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("error creating %s: %w", path, err)
+	}
+	defer os.Remove(tmp.Name()) // No-op once renamed
+
+	if err := write(tmp); err != nil {
+		tmp.Close()
+		return fmt.Errorf("error writing %s: %w", path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("error writing %s: %w", path, err)
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return fmt.Errorf("error writing %s: %w", path, err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("error replacing %s: %w", path, err)
+	}
+	return nil
+}
+
+type scoreFile struct {
+	level   int
+	path    string
+	modTime time.Time
+}
+
+// scoreFiles lists the high score files in dir, sorted by level.
+func scoreFiles(dir string) ([]scoreFile, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error reading score directory %s: %w", dir, err)
+	}
+
+	byLevel := make(map[int]scoreFile)
+	for _, e := range entries {
+		var level int
+		if e.IsDir() {
+			continue
+		}
+		ext := filepath.Ext(e.Name())
+		if ext != ".json" && ext != ".gob" {
+			continue
+		}
+		if _, err := fmt.Sscanf(e.Name(), "highscores_%d"+ext, &level); err != nil || e.Name() != fmt.Sprintf("highscores_%d%s", level, ext) {
+			continue
+		}
+		if _, found := byLevel[level]; found && ext == ".gob" {
+			continue // Legacy file already converted to JSON
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue // Removed while listing
+		}
+		byLevel[level] = scoreFile{level: level, path: filepath.Join(dir, e.Name()), modTime: info.ModTime()}
+	}
+
+	files := make([]scoreFile, 0, len(byLevel))
+	for _, f := range byLevel {
+		files = append(files, f)
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].level < files[j].level })
+	return files, nil
+}
@@ -0,0 +1,88 @@
+package scorefeed
+
+import (
+	"log"
+	"time"
+)
+
+// Watch regenerates the feed of dir into outDir right away and then whenever
+// a high score file is added, changed or removed, checking every interval
+// until stop is closed.
+func Watch(dir, outDir string, interval time.Duration, stop <-chan struct{}) {
+	var last string
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		state, err := dirState(dir)
+		if err != nil {
+			log.Printf("Warning: could not check %s for score changes: %v", dir, err)
+		} else if state != last {
+			if err := Generate(dir, outDir); err != nil {
+				log.Printf("Warning: could not regenerate score feed: %v", err)
+			} else {
+				last = state
+				log.Printf("Score feed regenerated in %s", outDir)
+			}
+		}
+
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Regenerator rebuilds the feed on request, coalescing requests made while a
+// rebuild is running. It suits change callbacks that must return quickly.
+type Regenerator struct {
+	collect func() (Feed, error) // Gathers the scores of the feed
+	outDir  string
+	pending chan struct{}
+}
+
+// NewRegenerator starts a Regenerator writing the feed collect gathers into
+// outDir, and builds the feed once.
+func NewRegenerator(collect func() (Feed, error), outDir string) *Regenerator {
+	r := &Regenerator{collect: collect, outDir: outDir, pending: make(chan struct{}, 1)}
+	r.Request()
+	go r.run()
+	return r
+}
+
+// Request asks for the feed to be rebuilt. It never blocks.
+func (r *Regenerator) Request() {
+	select {
+	case r.pending <- struct{}{}:
+	default: // A rebuild is already queued and will see this change too
+	}
+}
+
+func (r *Regenerator) run() {
+	for range r.pending {
+		feed, err := r.collect()
+		if err == nil {
+			err = Write(feed, r.outDir)
+		}
+		if err != nil {
+			log.Printf("Warning: could not regenerate score feed: %v", err)
+		}
+	}
+}
+
+// dirState summarises the high score files of dir; it changes whenever one of
+// them does.
+func dirState(dir string) (string, error) {
+	files, err := scoreFiles(dir)
+	if err != nil {
+		return "", err
+	}
+	var state []byte
+	for _, f := range files {
+		state = append(state, f.path...)
+		state = append(state, f.modTime.String()...)
+		state = append(state, 0)
+	}
+	return string(state), nil
+}
@@ -0,0 +1,72 @@
+package scoreserver
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Reasons a submission is rejected, used as the "reason" label of the rejections counter.
+const (
+	rejectRateLimited  = "rate_limited"
+	rejectTooLarge     = "too_large"
+	rejectBadSignature = "bad_signature"
+	rejectInvalid      = "invalid"
+	rejectBadSession   = "bad_session"
+	rejectImplausible  = "implausible"
+)
+
+// metrics holds the Prometheus instruments of a Server. Each Server has its
+// own registry, so several can live in one process.
+type metrics struct {
+	registry    *prometheus.Registry
+	submissions *prometheus.CounterVec
+	rejections  *prometheus.CounterVec
+	duration    *prometheus.HistogramVec
+}
+
+func newMetrics() *metrics {
+	m := &metrics{
+		registry: prometheus.NewRegistry(),
+		submissions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "scoreserver_submissions_total",
+			Help: "Accepted score submissions, by whether they made the leaderboard.",
+		}, []string{"result"}),
+		rejections: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "scoreserver_rejections_total",
+			Help: "Rejected score submissions, by reason.",
+		}, []string{"reason"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "scoreserver_request_duration_seconds",
+			Help:    "Time taken to answer API requests, by route and status code.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"route", "code"}),
+	}
+	m.registry.MustRegister(
+		m.submissions,
+		m.rejections,
+		m.duration,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	// Show every reason from the start so rates work before the first rejection
+	for _, reason := range []string{rejectRateLimited, rejectTooLarge, rejectBadSignature, rejectInvalid, rejectBadSession, rejectImplausible} {
+		m.rejections.WithLabelValues(reason)
+	}
+	for _, result := range []string{"ranked", "unranked"} {
+		m.submissions.WithLabelValues(result)
+	}
+	return m
+}
+
+// instrument records the latency of a route's requests.
+func (m *metrics) instrument(route string, next http.HandlerFunc) http.Handler {
+	return promhttp.InstrumentHandlerDuration(m.duration.MustCurryWith(prometheus.Labels{"route": route}), next)
+}
+
+// handler serves the metrics in the Prometheus text format.
+func (m *metrics) handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
@@ -0,0 +1,68 @@
+package scoreserver
+
+import (
+	"sync"
+	"time"
+)
+
+// rateLimiter is a per-client token bucket: every client may burst up to
+// `burst` requests and then gets one more every `interval`.
+type rateLimiter struct {
+	interval time.Duration
+	burst    float64
+
+	mu      sync.Mutex
+	clients map[string]*bucket
+}
+
+type bucket struct {
+	tokens   float64
+	lastSeen time.Time
+}
+
+func newRateLimiter(interval time.Duration, burst int) *rateLimiter {
+	return &rateLimiter{
+		interval: interval,
+		burst:    float64(burst),
+		clients:  make(map[string]*bucket),
+	}
+}
+
+// Allow reports whether the client may make a request now, consuming a token if so.
+func (rl *rateLimiter) Allow(client string) bool {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	now := time.Now()
+	b, ok := rl.clients[client]
+	if !ok {
+		b = &bucket{tokens: rl.burst}
+		rl.clients[client] = b
+	} else {
+		b.tokens += now.Sub(b.lastSeen).Seconds() / rl.interval.Seconds()
+		if b.tokens > rl.burst {
+			b.tokens = rl.burst
+		}
+	}
+	b.lastSeen = now
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// cleanup forgets clients whose bucket has been full for a while, so the map
+// doesn't grow forever on a long-running server.
+func (rl *rateLimiter) cleanup() {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	idle := rl.interval * time.Duration(rl.burst+1)
+	for client, b := range rl.clients {
+		if time.Since(b.lastSeen) > idle {
+			delete(rl.clients, client)
+		}
+	}
+}
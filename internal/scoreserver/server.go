@@ -0,0 +1,375 @@
+package scoreserver
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/model"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/scoreapi"
+)
+
+// Limits applied to submitted scores.
+const (
+	MaxNameLength = 15 // Same limit as the in-game name entry
+	MaxLevel      = 99
+	maxBodyBytes  = 1 << 10
+)
+
+// Config holds the server settings.
+type Config struct {
+	AdminToken     string        // Bearer token for the /admin API; empty disables it
+	SigningSecret  []byte        // Shared secret submissions must be signed with; empty accepts unsigned ones
+	SubmitInterval time.Duration // Time for a client to earn one more submission
+	SubmitBurst    int           // Submissions a client may make in a row
+
+	// Only accept scores of runs started with a session, checked for being
+	// plausible. Otherwise a session is only checked when one is given.
+	RequireSessions bool
+}
+
+// Server serves the leaderboard HTTP API (see package scoreapi) on top of a Store,
+// plus an admin API for moderation:
+//
+//	GET    /v1/admin/levels/{level}/scores         paginated like the public query
+//	DELETE /v1/admin/levels/{level}/scores/{rank}  remove a score
+//
+// Prometheus metrics for operators are served at GET /metrics.
+type Server struct {
+	store    *Store
+	config   Config
+	limiter  *rateLimiter
+	sessions *sessions
+	metrics  *metrics
+	mux      *http.ServeMux
+}
+
+// NewServer creates the HTTP handler for a store.
+func NewServer(store *Store, config Config) *Server {
+	s := &Server{
+		store:    store,
+		config:   config,
+		limiter:  newRateLimiter(config.SubmitInterval, config.SubmitBurst),
+		sessions: newSessions(),
+		metrics:  newMetrics(),
+		mux:      http.NewServeMux(),
+	}
+
+	s.mux.Handle("GET "+scoreapi.Version+"/levels/{level}/scores", s.metrics.instrument("list_scores", s.handleListScores))
+	s.mux.Handle("POST "+scoreapi.Version+"/sessions", s.metrics.instrument("start_session", s.handleStartSession))
+	s.mux.Handle("POST "+scoreapi.Version+"/scores", s.metrics.instrument("submit_score", s.handleSubmitScore))
+	s.mux.Handle("GET "+scoreapi.Version+"/admin/levels/{level}/scores", s.metrics.instrument("admin_list_scores", s.requireAdmin(s.handleListScores)))
+	s.mux.Handle("DELETE "+scoreapi.Version+"/admin/levels/{level}/scores/{rank}", s.metrics.instrument("admin_delete_score", s.requireAdmin(s.handleDeleteScore)))
+	s.mux.Handle("GET /metrics", s.metrics.handler())
+	return s
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// CleanupLoop periodically drops idle rate limiter entries and expired
+// sessions until stop is closed.
+func (s *Server) CleanupLoop(stop <-chan struct{}) {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+	for {
+		select {
+		case now := <-ticker.C:
+			s.limiter.cleanup()
+			s.sessions.cleanup(now)
+		case <-stop:
+			return
+		}
+	}
+}
+
+func (s *Server) handleListScores(w http.ResponseWriter, r *http.Request) {
+	level, ok := parseLevel(w, r)
+	if !ok {
+		return
+	}
+	offset, limit, ok := parsePage(w, r)
+	if !ok {
+		return
+	}
+	assisted, filter, ok := parseAssisted(w, r)
+	if !ok {
+		return
+	}
+	scores, err := s.store.Scores(level)
+	if err != nil {
+		log.Printf("Error reading scores for level %d: %v", level, err)
+		writeError(w, http.StatusInternalServerError, "could not read scores")
+		return
+	}
+	if filter {
+		scores = slices.DeleteFunc(slices.Clone(scores), func(sc model.Score) bool { return sc.Assisted() != assisted })
+	}
+
+	page := scoreapi.ScoresPage{Level: level, Total: len(scores), Offset: offset, Scores: []scoreapi.Entry{}}
+	for i := offset; i < len(scores) && i < offset+limit; i++ {
+		entry := scoreapi.Entry{Rank: i + 1, Name: scores[i].Name, Score: scores[i].Score, Misses: scores[i].Misses, Handicap: scoreapi.NewHandicap(scores[i].Handicap),
+			Chain: scores[i].Chain, ChainBonus: scores[i].ChainBonus}
+		if scores[i].Assisted() {
+			entry.SpriteScale = scores[i].SpriteScale
+		}
+		page.Scores = append(page.Scores, entry)
+	}
+	if next := offset + limit; next < len(scores) {
+		page.NextOffset = &next
+	}
+	writeJSON(w, http.StatusOK, page)
+}
+
+func (s *Server) handleStartSession(w http.ResponseWriter, r *http.Request) {
+	var req scoreapi.SessionRequest
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+	if req.Level < 0 || req.Level > MaxLevel {
+		writeError(w, http.StatusBadRequest, "invalid level")
+		return
+	}
+	writeJSON(w, http.StatusOK, scoreapi.SessionResponse{Token: s.sessions.issue(req.Level, time.Now())})
+}
+
+func (s *Server) handleSubmitScore(w http.ResponseWriter, r *http.Request) {
+	client := clientIP(r)
+	if !s.limiter.Allow(client) {
+		s.metrics.rejections.WithLabelValues(rejectRateLimited).Inc()
+		w.Header().Set("Retry-After", strconv.Itoa(int(s.config.SubmitInterval.Seconds()+0.5)))
+		writeError(w, http.StatusTooManyRequests, "too many submissions, slow down")
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+	if err != nil {
+		s.metrics.rejections.WithLabelValues(rejectTooLarge).Inc()
+		writeError(w, http.StatusRequestEntityTooLarge, "request body too large")
+		return
+	}
+	if len(s.config.SigningSecret) > 0 {
+		err := scoreapi.Verify(s.config.SigningSecret, r.Method, r.URL.Path,
+			r.Header.Get(scoreapi.HeaderTimestamp), r.Header.Get(scoreapi.HeaderSignature), body, time.Now())
+		if err != nil {
+			s.metrics.rejections.WithLabelValues(rejectBadSignature).Inc()
+			log.Printf("Rejected submission from %s: %v", client, err)
+			writeError(w, http.StatusUnauthorized, err.Error())
+			return
+		}
+	}
+
+	var req scoreapi.SubmitRequest
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		s.metrics.rejections.WithLabelValues(rejectInvalid).Inc()
+		writeError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+	if msg := validateSubmission(&req); msg != "" {
+		s.metrics.rejections.WithLabelValues(rejectInvalid).Inc()
+		writeError(w, http.StatusBadRequest, msg)
+		return
+	}
+	if req.Session != "" || s.config.RequireSessions {
+		if !s.checkSession(w, client, &req) {
+			return
+		}
+	}
+
+	rank, err := s.store.Add(req.Level, model.Score{Name: req.Name, Score: req.Score, SpriteScale: req.SpriteScale, Misses: req.Misses, Handicap: req.Handicap.Model(),
+		Chain: req.Chain, ChainBonus: req.ChainBonus})
+	if err != nil {
+		log.Printf("Error saving score for level %d: %v", req.Level, err)
+		writeError(w, http.StatusInternalServerError, "could not save score")
+		return
+	}
+	if rank > 0 {
+		s.metrics.submissions.WithLabelValues("ranked").Inc()
+	} else {
+		s.metrics.submissions.WithLabelValues("unranked").Inc()
+	}
+	log.Printf("Score submitted from %s for level %d: %s - %d (rank: %d, run hash: %s)", client, req.Level, req.Name, req.Score, rank, req.RunHash)
+	writeJSON(w, http.StatusOK, scoreapi.SubmitResponse{Added: rank > 0, Rank: rank})
+}
+
+func (s *Server) handleDeleteScore(w http.ResponseWriter, r *http.Request) {
+	level, ok := parseLevel(w, r)
+	if !ok {
+		return
+	}
+	rank, err := strconv.Atoi(r.PathValue("rank"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "rank must be a number")
+		return
+	}
+
+	removed, err := s.store.Delete(level, rank)
+	if errors.Is(err, ErrNoSuchScore) {
+		writeError(w, http.StatusNotFound, "no score at that rank")
+		return
+	}
+	if err != nil {
+		log.Printf("Error deleting score %d of level %d: %v", rank, level, err)
+		writeError(w, http.StatusInternalServerError, "could not delete score")
+		return
+	}
+	log.Printf("Admin removed score %d of level %d: %s - %d", rank, level, removed.Name, removed.Score)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// checkSession redeems the session a submission's run was started with and
+// checks the run is plausible, replying with an error if not.
+func (s *Server) checkSession(w http.ResponseWriter, client string, req *scoreapi.SubmitRequest) bool {
+	now := time.Now()
+	if req.Session == "" {
+		s.metrics.rejections.WithLabelValues(rejectBadSession).Inc()
+		writeError(w, http.StatusUnauthorized, "runs must be started with a session")
+		return false
+	}
+	issued, err := s.sessions.redeem(req.Session, req.Level, now)
+	if err != nil {
+		s.metrics.rejections.WithLabelValues(rejectBadSession).Inc()
+		log.Printf("Rejected submission from %s: %v", client, err)
+		writeError(w, http.StatusUnauthorized, err.Error())
+		return false
+	}
+	if msg := checkRun(req, issued, now); msg != "" {
+		s.metrics.rejections.WithLabelValues(rejectImplausible).Inc()
+		log.Printf("Rejected implausible submission from %s for level %d: %s", client, req.Level, msg)
+		writeError(w, http.StatusUnprocessableEntity, msg)
+		return false
+	}
+	return true
+}
+
+// validateSubmission checks a submission, filling in the default name.
+// Returns the reason it's invalid, or "" if it's fine.
+func validateSubmission(req *scoreapi.SubmitRequest) string {
+	if req.Level < 0 || req.Level > MaxLevel {
+		return "invalid level"
+	}
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		req.Name = "Anonymous" // Same default as the game
+	}
+	if len([]rune(req.Name)) > MaxNameLength {
+		return "name is too long"
+	}
+	if req.Score < 0 {
+		return "score must not be negative"
+	}
+	if req.SpriteScale != 0 && !slices.Contains(model.SpriteScales, req.SpriteScale) {
+		return "invalid sprite scale"
+	}
+	if req.Misses < 0 || req.Misses*model.MissPenalty > req.Score {
+		return "misses don't add up with the score"
+	}
+	if err := req.Handicap.Model().Validate(); err != nil {
+		return "invalid handicap"
+	}
+	if req.Chain < 0 || req.ChainBonus < 0 || (req.Chain == 0 && req.ChainBonus > 0) {
+		return "invalid chain"
+	}
+	return ""
+}
+
+// requireAdmin only lets requests carrying the admin bearer token through.
+func (s *Server) requireAdmin(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if s.config.AdminToken == "" {
+			writeError(w, http.StatusNotFound, "admin API is disabled")
+			return
+		}
+		token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !found || subtle.ConstantTimeCompare([]byte(token), []byte(s.config.AdminToken)) != 1 {
+			writeError(w, http.StatusUnauthorized, "invalid admin token")
+			return
+		}
+		next(w, r)
+	}
+}
+
+// parseLevel reads the {level} path parameter, replying with an error if it's invalid.
+func parseLevel(w http.ResponseWriter, r *http.Request) (int, bool) {
+	level, err := strconv.Atoi(r.PathValue("level"))
+	if err != nil || level < 0 || level > MaxLevel {
+		writeError(w, http.StatusBadRequest, "invalid level")
+		return 0, false
+	}
+	return level, true
+}
+
+// parsePage reads the offset and limit query parameters, replying with an error if they're invalid.
+func parsePage(w http.ResponseWriter, r *http.Request) (offset, limit int, ok bool) {
+	offset, limit = 0, scoreapi.DefaultPageSize
+	query := r.URL.Query()
+	if v := query.Get("offset"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			writeError(w, http.StatusBadRequest, "invalid offset")
+			return 0, 0, false
+		}
+		offset = n
+	}
+	if v := query.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > scoreapi.MaxPageSize {
+			writeError(w, http.StatusBadRequest, "invalid limit")
+			return 0, 0, false
+		}
+		limit = n
+	}
+	return offset, limit, true
+}
+
+// parseAssisted reads the assisted query parameter, replying with an error if it's invalid.
+// filter is false when the parameter is absent and every run should be listed.
+func parseAssisted(w http.ResponseWriter, r *http.Request) (assisted, filter, ok bool) {
+	v := r.URL.Query().Get("assisted")
+	if v == "" {
+		return false, false, true
+	}
+	assisted, err := strconv.ParseBool(v)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid assisted filter")
+		return false, false, false
+	}
+	return assisted, true, true
+}
+
+// clientIP identifies the caller for rate limiting.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("Error writing response: %v", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, scoreapi.ErrorResponse{Error: msg})
+}
@@ -0,0 +1,129 @@
+package scoreserver
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/scoreapi"
+)
+
+// MaxCatchRate is the most catches per second of game time a submitted run
+// may claim. Even a lucky human can't click faster for a whole run.
+const MaxCatchRate = 8
+
+var (
+	ErrBadSession     = errors.New("invalid session token")
+	ErrExpiredSession = errors.New("session has expired")
+	ErrSessionUsed    = errors.New("session was already submitted")
+)
+
+// sessions issues the tokens runs are started with, see
+// scoreapi.SessionResponse. A token is "level.issued.nonce.mac", signed with
+// a key made up when the server starts, so a restart invalidates the runs
+// under way. Redeemed tokens are remembered until they expire, so each can
+// be used once.
+type sessions struct {
+	key []byte
+
+	mu   sync.Mutex
+	used map[string]time.Time // Redeemed tokens, by when they expire
+}
+
+func newSessions() *sessions {
+	key := make([]byte, 32)
+	rand.Read(key) // Never fails
+	return &sessions{key: key, used: make(map[string]time.Time)}
+}
+
+// issue returns a token for a run of level starting now.
+func (s *sessions) issue(level int, now time.Time) string {
+	nonce := make([]byte, 8)
+	rand.Read(nonce)
+	payload := fmt.Sprintf("%d.%d.%s", level, now.Unix(), hex.EncodeToString(nonce))
+	return payload + "." + s.sign(payload)
+}
+
+// redeem checks a token of a run of level and marks it used. It returns
+// when the run started.
+func (s *sessions) redeem(token string, level int, now time.Time) (time.Time, error) {
+	payload, mac, ok := cutLast(token, ".")
+	if !ok || !hmac.Equal([]byte(mac), []byte(s.sign(payload))) {
+		return time.Time{}, ErrBadSession
+	}
+	fields := strings.Split(payload, ".")
+	if len(fields) != 3 || fields[0] != strconv.Itoa(level) {
+		return time.Time{}, ErrBadSession
+	}
+	unix, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		return time.Time{}, ErrBadSession
+	}
+	issued := time.Unix(unix, 0)
+	expires := issued.Add(scoreapi.SessionLifetime)
+	if now.After(expires) {
+		return time.Time{}, ErrExpiredSession
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.used[token]; ok {
+		return time.Time{}, ErrSessionUsed
+	}
+	s.used[token] = expires
+	return issued, nil
+}
+
+// cleanup forgets redeemed tokens that have expired anyway.
+func (s *sessions) cleanup(now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for token, expires := range s.used {
+		if now.After(expires) {
+			delete(s.used, token)
+		}
+	}
+}
+
+func (s *sessions) sign(payload string) string {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// cutLast slices s around the last instance of sep.
+func cutLast(s, sep string) (before, after string, found bool) {
+	if i := strings.LastIndex(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, "", false
+}
+
+// checkRun checks the run behind a submission started with a session
+// issued at the given time. Returns why it's implausible, or "" if it's fine.
+func checkRun(req *scoreapi.SubmitRequest, issued, now time.Time) string {
+	if len(req.RunHash) != sha256.Size*2 {
+		return "missing run hash"
+	}
+	if _, err := hex.DecodeString(req.RunHash); err != nil {
+		return "missing run hash"
+	}
+	if req.Elapsed <= 0 || req.Catches < 0 {
+		return "missing run duration"
+	}
+	// Game time runs at most as fast as the wall clock
+	if req.Elapsed > now.Sub(issued).Seconds()+scoreapi.MaxClockSkew.Seconds() {
+		return "run lasted longer than its session"
+	}
+	if float64(req.Catches) > req.Elapsed*MaxCatchRate {
+		return "too many catches for the run's duration"
+	}
+	return ""
+}
@@ -0,0 +1,315 @@
+package scoreserver
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/model"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/persistence"
+)
+
+// ErrNoSuchScore is returned when deleting a rank that isn't on the leaderboard.
+var ErrNoSuchScore = errors.New("no such score")
+
+// DatabaseFile is the name of the score database in a Store's directory.
+const DatabaseFile = "scores.db"
+
+// levelBucketPrefix starts the name of every level's bucket, followed by the level.
+const levelBucketPrefix = "level_"
+
+// Store keeps the top scores of every level in a bbolt database, a bucket
+// per level holding every score under its ID, encoded like a one-entry high
+// score file. A submission only writes the score it adds and the ones it
+// pushes off the board, in a transaction a crash can't leave half done.
+//
+// High score files of the game's format found in the directory when it's
+// opened, e.g. an existing assets/highscores directory, are imported for
+// the levels the database doesn't have yet. They're left as they are.
+type Store struct {
+	dir   string
+	db    *bolt.DB
+	limit int // Scores kept per level
+
+	mu       sync.Mutex
+	levels   map[int][]model.Score // Cache of levels already read from the database
+	watchers []func(level int)     // Called after a level's scores change
+}
+
+// NewStore opens (and creates if needed) the score database in dir, keeping
+// up to limit scores per level.
+func NewStore(dir string, limit int) (*Store, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("could not create score directory %s: %w", dir, err)
+	}
+	path := filepath.Join(dir, DatabaseFile)
+	// Only one server can have the database open, a second one gives up rather than waiting forever
+	db, err := bolt.Open(path, 0644, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("could not open score database %s: %w", path, err)
+	}
+	s := &Store{dir: dir, db: db, limit: limit, levels: make(map[int][]model.Score)}
+	if err := s.importFiles(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// Close closes the database. The store can't be used afterwards.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Watch registers fn to be called with the level whenever its scores change.
+// fn runs with the store locked, so it must return quickly and not call back into the store.
+func (s *Store) Watch(fn func(level int)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.watchers = append(s.watchers, fn)
+}
+
+// Scores returns a copy of the leaderboard of a level, best score first.
+func (s *Store) Scores(level int) ([]model.Score, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	scores, err := s.load(level)
+	if err != nil {
+		return nil, err
+	}
+	return slices.Clone(scores), nil
+}
+
+// Levels returns a copy of the leaderboard of every level that has one.
+func (s *Store) Levels() (map[int][]model.Score, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var levels []int
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.ForEach(func(name []byte, _ *bolt.Bucket) error {
+			if level, ok := bucketLevel(name); ok {
+				levels = append(levels, level)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing levels: %w", err)
+	}
+	boards := make(map[int][]model.Score, len(levels))
+	for _, level := range levels {
+		scores, err := s.load(level)
+		if err != nil {
+			return nil, err
+		}
+		boards[level] = slices.Clone(scores)
+	}
+	return boards, nil
+}
+
+// Add submits a score and returns its 1-based rank, or 0 if it didn't make the leaderboard.
+func (s *Store) Add(level int, score model.Score) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	scores, err := s.load(level)
+	if err != nil {
+		return 0, err
+	}
+	// Work on a copy: AddScoreLimit may reuse the cached slice's backing array
+	now := time.Now()
+	score = score.Stamp(now)
+	updated, added := model.AddScoreLimit(slices.Clone(scores), score, s.limit, now)
+	if !added {
+		return 0, nil
+	}
+	var dropped []model.Score
+	for _, sc := range scores {
+		if !slices.ContainsFunc(updated, func(u model.Score) bool { return u.ID == sc.ID }) {
+			dropped = append(dropped, sc)
+		}
+	}
+	if err := s.write(level, updated, []model.Score{score}, dropped); err != nil {
+		return 0, err
+	}
+	for i, sc := range updated {
+		if sc.ID == score.ID {
+			return i + 1, nil
+		}
+	}
+	return 0, nil
+}
+
+// Delete removes the score at the given 1-based rank of a level.
+func (s *Store) Delete(level, rank int) (model.Score, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	scores, err := s.load(level)
+	if err != nil {
+		return model.Score{}, err
+	}
+	if rank < 1 || rank > len(scores) {
+		return model.Score{}, ErrNoSuchScore
+	}
+	removed := scores[rank-1]
+	updated := slices.Delete(slices.Clone(scores), rank-1, rank)
+	if err := s.write(level, updated, nil, []model.Score{removed}); err != nil {
+		return model.Score{}, err
+	}
+	return removed, nil
+}
+
+// bucketName returns the name of a level's bucket.
+func bucketName(level int) []byte {
+	return []byte(levelBucketPrefix + strconv.Itoa(level))
+}
+
+// bucketLevel returns the level of a bucket, and whether it is a level's.
+func bucketLevel(name []byte) (int, bool) {
+	level, err := strconv.Atoi(strings.TrimPrefix(string(name), levelBucketPrefix))
+	return level, err == nil && strings.HasPrefix(string(name), levelBucketPrefix)
+}
+
+// load returns the cached scores of a level, reading them from the database
+// on first access. Caller must hold s.mu.
+func (s *Store) load(level int) ([]model.Score, error) {
+	if scores, ok := s.levels[level]; ok {
+		return scores, nil
+	}
+
+	scores := []model.Score{}
+	err := s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketName(level))
+		if b == nil {
+			return nil // No scores for this level yet
+		}
+		return b.ForEach(func(id, data []byte) error {
+			entry, err := persistence.DecodeHighScores(data)
+			if err != nil || len(entry) != 1 {
+				return fmt.Errorf("invalid score %s: %v", id, err)
+			}
+			scores = append(scores, entry[0])
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error reading scores of level %d: %w", level, err)
+	}
+	sort.Sort(model.ByScore(scores))
+
+	s.levels[level] = scores
+	return scores, nil
+}
+
+// write stores the scores added to a level and deletes the ones removed
+// from it in one transaction, leaving it with scores, and tells the
+// watchers. Caller must hold s.mu.
+func (s *Store) write(level int, scores, added, removed []model.Score) error {
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists(bucketName(level))
+		if err != nil {
+			return err
+		}
+		for _, sc := range removed {
+			if err := b.Delete([]byte(sc.ID)); err != nil {
+				return err
+			}
+		}
+		for _, sc := range added {
+			data, err := persistence.EncodeHighScores([]model.Score{sc})
+			if err != nil {
+				return err
+			}
+			if err := b.Put([]byte(sc.ID), data); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("error writing scores of level %d: %w", level, err)
+	}
+
+	s.levels[level] = scores
+	for _, fn := range s.watchers {
+		fn(level)
+	}
+	return nil
+}
+
+// importFiles imports the high score files in the store's directory for
+// the levels the database has no scores of yet.
+func (s *Store) importFiles() error {
+	files, err := filepath.Glob(filepath.Join(s.dir, "highscores_*"))
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	imported := make(map[int]bool)
+	for _, file := range files {
+		var level int
+		if _, err := fmt.Sscanf(filepath.Base(file), "highscores_%d.", &level); err != nil || imported[level] {
+			continue
+		}
+		imported[level] = true
+		if existing, err := s.load(level); err != nil || len(existing) > 0 {
+			continue
+		}
+		scores, err := s.readFile(level)
+		if err != nil {
+			return err
+		}
+		if len(scores) == 0 {
+			continue
+		}
+		for i, sc := range scores {
+			// Entries older than IDs get one, keyed by it like any other. Their
+			// time stays unknown: stamping at the epoch leaves SetAt 0.
+			scores[i] = sc.Stamp(time.Unix(0, 0))
+		}
+		sort.Sort(model.ByScore(scores))
+		if len(scores) > s.limit {
+			scores = scores[:s.limit]
+		}
+		if err := s.write(level, scores, scores, nil); err != nil {
+			return err
+		}
+		log.Printf("Imported %d scores of level %d from its high score file", len(scores), level)
+	}
+	return nil
+}
+
+// readFile reads the high score file of a level in the store's directory,
+// in the current JSON format or the legacy gob one. Caller must hold s.mu.
+func (s *Store) readFile(level int) ([]model.Score, error) {
+	path := filepath.Join(s.dir, fmt.Sprintf("highscores_%d.json", level))
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		data, err = os.ReadFile(persistence.LegacyHighScorePath(path))
+	}
+	switch {
+	case os.IsNotExist(err):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("error opening score file for level %d: %w", level, err)
+	}
+	scores, err := persistence.DecodeHighScores(data)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding scores for level %d: %w", level, err)
+	}
+	return scores, nil
+}
@@ -0,0 +1,100 @@
+package scoresync
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/model"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/scoresync/scoresyncpb"
+)
+
+// Delay before a dropped watch stream is reopened.
+const reconnectDelay = 5 * time.Second
+
+// Watcher follows one level's leaderboard on a score-sync server.
+type Watcher struct {
+	conn   *grpc.ClientConn
+	client scoresyncpb.ScoreSyncClient
+
+	mu     sync.Mutex
+	cancel context.CancelFunc // Stops the current watch, nil if none
+}
+
+// NewWatcher creates a watcher for the score-sync server at addr (host:port).
+// The connection is established lazily by the first Watch.
+func NewWatcher(addr string) (*Watcher, error) {
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("could not create score-sync client for %s: %w", addr, err)
+	}
+	return &Watcher{conn: conn, client: scoresyncpb.NewScoreSyncClient(conn)}, nil
+}
+
+// Watch starts following a level, replacing any level watched before. onUpdate
+// is called from a background goroutine with the top model.MaxHighScores scores
+// every time they change. Dropped streams are reopened until the next Watch,
+// Close or ctx is canceled.
+func (w *Watcher) Watch(ctx context.Context, level int, onUpdate func([]model.Score)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.cancel != nil {
+		w.cancel()
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	w.cancel = cancel
+
+	go func() {
+		for {
+			err := w.follow(ctx, level, onUpdate)
+			if ctx.Err() != nil {
+				return
+			}
+			log.Printf("Score-sync stream for level %d dropped: %v. Retrying in %v.", level, err, reconnectDelay)
+			select {
+			case <-time.After(reconnectDelay):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// follow reads one watch stream until it fails or ctx is canceled.
+func (w *Watcher) follow(ctx context.Context, level int, onUpdate func([]model.Score)) error {
+	stream, err := w.client.WatchScores(ctx, &scoresyncpb.WatchScoresRequest{
+		Level: int32(level),
+		Limit: model.MaxHighScores,
+	})
+	if err != nil {
+		return err
+	}
+	for {
+		board, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		scores := make([]model.Score, len(board.GetScores()))
+		for i, sc := range board.GetScores() {
+			scores[i] = model.Score{Name: sc.GetName(), Score: int(sc.GetScore())}
+		}
+		onUpdate(scores)
+	}
+}
+
+// Close stops watching and closes the connection.
+func (w *Watcher) Close() error {
+	w.mu.Lock()
+	if w.cancel != nil {
+		w.cancel()
+		w.cancel = nil
+	}
+	w.mu.Unlock()
+	return w.conn.Close()
+}
@@ -0,0 +1,5 @@
+// Package scoresyncpb holds the generated protobuf and gRPC code of the
+// score-sync protocol. Edit scoresync.proto and regenerate, never the .pb.go files.
+package scoresyncpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative scoresync.proto
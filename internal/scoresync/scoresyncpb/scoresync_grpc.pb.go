@@ -0,0 +1,173 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: scoresync.proto
+
+package scoresyncpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ScoreSync_GetScores_FullMethodName   = "/scoresync.v1.ScoreSync/GetScores"
+	ScoreSync_WatchScores_FullMethodName = "/scoresync.v1.ScoreSync/WatchScores"
+)
+
+// ScoreSyncClient is the client API for ScoreSync service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ScoreSync lets game clients follow a level's leaderboard as it changes.
+type ScoreSyncClient interface {
+	// GetScores returns the current top scores of a level.
+	GetScores(ctx context.Context, in *GetScoresRequest, opts ...grpc.CallOption) (*Leaderboard, error)
+	// WatchScores sends the current top scores of a level, then the updated
+	// list every time a score is added or removed.
+	WatchScores(ctx context.Context, in *WatchScoresRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Leaderboard], error)
+}
+
+type scoreSyncClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewScoreSyncClient(cc grpc.ClientConnInterface) ScoreSyncClient {
+	return &scoreSyncClient{cc}
+}
+
+func (c *scoreSyncClient) GetScores(ctx context.Context, in *GetScoresRequest, opts ...grpc.CallOption) (*Leaderboard, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Leaderboard)
+	err := c.cc.Invoke(ctx, ScoreSync_GetScores_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scoreSyncClient) WatchScores(ctx context.Context, in *WatchScoresRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Leaderboard], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ScoreSync_ServiceDesc.Streams[0], ScoreSync_WatchScores_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchScoresRequest, Leaderboard]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ScoreSync_WatchScoresClient = grpc.ServerStreamingClient[Leaderboard]
+
+// ScoreSyncServer is the server API for ScoreSync service.
+// All implementations must embed UnimplementedScoreSyncServer
+// for forward compatibility.
+//
+// ScoreSync lets game clients follow a level's leaderboard as it changes.
+type ScoreSyncServer interface {
+	// GetScores returns the current top scores of a level.
+	GetScores(context.Context, *GetScoresRequest) (*Leaderboard, error)
+	// WatchScores sends the current top scores of a level, then the updated
+	// list every time a score is added or removed.
+	WatchScores(*WatchScoresRequest, grpc.ServerStreamingServer[Leaderboard]) error
+	mustEmbedUnimplementedScoreSyncServer()
+}
+
+// UnimplementedScoreSyncServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedScoreSyncServer struct{}
+
+func (UnimplementedScoreSyncServer) GetScores(context.Context, *GetScoresRequest) (*Leaderboard, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetScores not implemented")
+}
+func (UnimplementedScoreSyncServer) WatchScores(*WatchScoresRequest, grpc.ServerStreamingServer[Leaderboard]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchScores not implemented")
+}
+func (UnimplementedScoreSyncServer) mustEmbedUnimplementedScoreSyncServer() {}
+func (UnimplementedScoreSyncServer) testEmbeddedByValue()                   {}
+
+// UnsafeScoreSyncServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ScoreSyncServer will
+// result in compilation errors.
+type UnsafeScoreSyncServer interface {
+	mustEmbedUnimplementedScoreSyncServer()
+}
+
+func RegisterScoreSyncServer(s grpc.ServiceRegistrar, srv ScoreSyncServer) {
+	// If the following call pancis, it indicates UnimplementedScoreSyncServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ScoreSync_ServiceDesc, srv)
+}
+
+func _ScoreSync_GetScores_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetScoresRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScoreSyncServer).GetScores(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScoreSync_GetScores_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScoreSyncServer).GetScores(ctx, req.(*GetScoresRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScoreSync_WatchScores_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchScoresRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ScoreSyncServer).WatchScores(m, &grpc.GenericServerStream[WatchScoresRequest, Leaderboard]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ScoreSync_WatchScoresServer = grpc.ServerStreamingServer[Leaderboard]
+
+// ScoreSync_ServiceDesc is the grpc.ServiceDesc for ScoreSync service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ScoreSync_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "scoresync.v1.ScoreSync",
+	HandlerType: (*ScoreSyncServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetScores",
+			Handler:    _ScoreSync_GetScores_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchScores",
+			Handler:       _ScoreSync_WatchScores_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "scoresync.proto",
+}
@@ -0,0 +1,135 @@
+// Package scoresync implements the gRPC score-sync protocol: a score server
+// pushes leaderboard changes to connected game clients so the Hall of Fame
+// updates live.
+package scoresync
+
+import (
+	"context"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/scoreapi"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/scoreserver"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/scoresync/scoresyncpb"
+)
+
+// Server implements the ScoreSync gRPC service on top of a score store.
+type Server struct {
+	scoresyncpb.UnimplementedScoreSyncServer
+
+	store *scoreserver.Store
+
+	mu       sync.Mutex
+	watchers map[int]map[chan struct{}]struct{} // Per level, one channel per open WatchScores stream
+}
+
+// NewServer creates the service and subscribes it to the store's changes.
+func NewServer(store *scoreserver.Store) *Server {
+	s := &Server{
+		store:    store,
+		watchers: make(map[int]map[chan struct{}]struct{}),
+	}
+	store.Watch(s.notify)
+	return s
+}
+
+// GetScores returns the current top scores of a level.
+func (s *Server) GetScores(ctx context.Context, req *scoresyncpb.GetScoresRequest) (*scoresyncpb.Leaderboard, error) {
+	if err := validate(req.GetLevel(), req.GetLimit()); err != nil {
+		return nil, err
+	}
+	return s.leaderboard(int(req.GetLevel()), int(req.GetLimit()))
+}
+
+// WatchScores streams the top scores of a level every time they change.
+func (s *Server) WatchScores(req *scoresyncpb.WatchScoresRequest, stream scoresyncpb.ScoreSync_WatchScoresServer) error {
+	if err := validate(req.GetLevel(), req.GetLimit()); err != nil {
+		return err
+	}
+	level, limit := int(req.GetLevel()), int(req.GetLimit())
+
+	changed := s.subscribe(level)
+	defer s.unsubscribe(level, changed)
+
+	for {
+		board, err := s.leaderboard(level, limit)
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(board); err != nil {
+			return err
+		}
+
+		select {
+		case <-changed:
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
+}
+
+// notify wakes every stream watching the level. Channels hold at most one
+// pending wake-up, so a burst of changes collapses into a single resend.
+func (s *Server) notify(level int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for ch := range s.watchers[level] {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (s *Server) subscribe(level int) chan struct{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ch := make(chan struct{}, 1)
+	if s.watchers[level] == nil {
+		s.watchers[level] = make(map[chan struct{}]struct{})
+	}
+	s.watchers[level][ch] = struct{}{}
+	return ch
+}
+
+func (s *Server) unsubscribe(level int, ch chan struct{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.watchers[level], ch)
+	if len(s.watchers[level]) == 0 {
+		delete(s.watchers, level)
+	}
+}
+
+// leaderboard builds the message for the first limit scores of a level.
+func (s *Server) leaderboard(level, limit int) (*scoresyncpb.Leaderboard, error) {
+	if limit == 0 {
+		limit = scoreapi.DefaultPageSize
+	}
+	scores, err := s.store.Scores(level)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "could not read scores: %v", err)
+	}
+
+	board := &scoresyncpb.Leaderboard{Level: int32(level), Total: int32(len(scores))}
+	for i := 0; i < len(scores) && i < limit; i++ {
+		board.Scores = append(board.Scores, &scoresyncpb.Score{
+			Rank:  int32(i + 1),
+			Name:  scores[i].Name,
+			Score: int32(scores[i].Score),
+		})
+	}
+	return board, nil
+}
+
+func validate(level, limit int32) error {
+	if level < 0 || level > scoreserver.MaxLevel {
+		return status.Error(codes.InvalidArgument, "invalid level")
+	}
+	if limit < 0 || limit > scoreapi.MaxPageSize {
+		return status.Error(codes.InvalidArgument, "invalid limit")
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package spectate
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/gorilla/websocket"
+)
+
+// Client receives the snapshot stream of a hub, for frontends in spectate mode.
+type Client struct {
+	conn *websocket.Conn
+
+	mu     sync.Mutex
+	latest Snapshot
+	have   bool
+	err    error // Why the stream ended, nil while it's running
+}
+
+// Dial connects to a hub's stream, e.g. "ws://host:8090/ws".
+func Dial(url string) (*Client, error) {
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not connect to spectator stream %s: %w", url, err)
+	}
+	c := &Client{conn: conn}
+	go c.readLoop()
+	return c, nil
+}
+
+// Latest returns the newest snapshot received, false if none arrived yet.
+// Once the stream has ended it returns the error that ended it.
+func (c *Client) Latest() (Snapshot, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.latest, c.have, c.err
+}
+
+// Close disconnects from the hub.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+func (c *Client) readLoop() {
+	for {
+		_, data, err := c.conn.ReadMessage()
+		if err != nil {
+			c.mu.Lock()
+			c.err = err
+			c.mu.Unlock()
+			return
+		}
+		var s Snapshot
+		if err := json.Unmarshal(data, &s); err != nil {
+			log.Printf("Ignoring malformed spectator snapshot: %v", err)
+			continue
+		}
+		c.mu.Lock()
+		c.latest, c.have = s, true
+		c.mu.Unlock()
+	}
+}
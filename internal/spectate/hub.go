@@ -0,0 +1,144 @@
+package spectate
+
+import (
+	_ "embed"
+	"encoding/json"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+//go:embed spectate.html
+var spectatePage []byte
+
+const (
+	writeTimeout = 5 * time.Second
+	pingInterval = 30 * time.Second
+)
+
+// Hub fans snapshots out to every connected spectator.
+//
+//	GET /    a page that draws the stream on a canvas
+//	GET /ws  the WebSocket stream, one JSON Snapshot per message
+type Hub struct {
+	upgrader websocket.Upgrader
+	mux      *http.ServeMux
+
+	mu      sync.Mutex
+	clients map[chan []byte]struct{}
+	last    []byte // Most recent snapshot, sent to spectators as soon as they join
+}
+
+// NewHub creates a hub with no spectators.
+func NewHub() *Hub {
+	h := &Hub{
+		// Overlays are usually served from another origin (OBS, a tournament site)
+		upgrader: websocket.Upgrader{CheckOrigin: func(*http.Request) bool { return true }},
+		mux:      http.NewServeMux(),
+		clients:  make(map[chan []byte]struct{}),
+	}
+	h.mux.HandleFunc("GET /{$}", h.handlePage)
+	h.mux.HandleFunc("GET /ws", h.handleStream)
+	return h
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Hub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+// Publish sends a snapshot to every spectator. It never blocks: a spectator
+// that hasn't taken the previous snapshot yet only gets the newest one.
+func (h *Hub) Publish(s Snapshot) {
+	data, err := json.Marshal(s)
+	if err != nil {
+		log.Printf("Could not encode spectator snapshot: %v", err)
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.last = data
+	for ch := range h.clients {
+		select {
+		case <-ch: // Drop the stale snapshot
+		default:
+		}
+		ch <- data
+	}
+}
+
+// Spectators returns the number of connected spectators.
+func (h *Hub) Spectators() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.clients)
+}
+
+func (h *Hub) handlePage(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(spectatePage)
+}
+
+func (h *Hub) handleStream(w http.ResponseWriter, r *http.Request) {
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return // Upgrade already replied with an error
+	}
+	defer conn.Close()
+
+	ch := h.subscribe()
+	defer h.unsubscribe(ch)
+	log.Printf("Spectator connected from %s", r.RemoteAddr)
+
+	// Spectators never send anything meaningful; reading only notices when they leave
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	ping := time.NewTicker(pingInterval)
+	defer ping.Stop()
+	for {
+		select {
+		case data := <-ch:
+			conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+			if err := conn.WriteMessage(websocket.TextMessage, data); err != nil {
+				return
+			}
+		case <-ping.C:
+			conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+			if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		case <-closed:
+			log.Printf("Spectator %s disconnected", r.RemoteAddr)
+			return
+		}
+	}
+}
+
+func (h *Hub) subscribe() chan []byte {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	ch := make(chan []byte, 1)
+	if h.last != nil {
+		ch <- h.last
+	}
+	h.clients[ch] = struct{}{}
+	return ch
+}
+
+func (h *Hub) unsubscribe(ch chan []byte) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.clients, ch)
+}
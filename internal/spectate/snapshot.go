@@ -0,0 +1,15 @@
+// Package spectate streams compact game snapshots over WebSocket so a browser
+// page or another client in spectate mode can watch a run live.
+package spectate
+
+// Snapshot is the state of a run at one instant, kept small for the wire:
+// positions are rounded to whole pixels and stopped Pacmans are left out.
+type Snapshot struct {
+	Level   int      `json:"l"`
+	Bounces int      `json:"b"`
+	State   int      `json:"s"` // game.GameState value
+	Pacmans []Entity `json:"p"`
+}
+
+// Entity is one moving Pacman, encoded as [x, y, radius, animFrame].
+type Entity [4]int
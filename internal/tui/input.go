@@ -0,0 +1,85 @@
+package tui
+
+import (
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/frontend"
+	"github.com/gdamore/tcell/v2"
+)
+
+// specialKeys are the keys that aren't characters, e.g. the function keys
+// bound to level selection.
+var specialKeys = map[tcell.Key][]frontend.Key{
+	tcell.KeyEnter:  {frontend.KeyConfirm},
+	tcell.KeyCtrlC:  {frontend.KeyQuit}, // The terminal's signal is off while the screen is up
+	tcell.KeyEscape: {frontend.KeyBack, frontend.KeyPause},
+	tcell.KeyF1:     {frontend.KeyLevel0},
+	tcell.KeyF2:     {frontend.KeyLevel1},
+	tcell.KeyF3:     {frontend.KeyLevel2},
+	tcell.KeyUp:     {frontend.KeyUp},
+	tcell.KeyDown:   {frontend.KeyDown},
+	tcell.KeyLeft:   {frontend.KeyLeft},
+	tcell.KeyRight:  {frontend.KeyRight},
+}
+
+// parseEvent adds a key press or mouse report to in.
+func (s *Screen) parseEvent(ev tcell.Event, in *frontend.Input) {
+	switch ev := ev.(type) {
+	case *tcell.EventKey:
+		switch ev.Key() {
+		case tcell.KeyRune:
+			parseRune(ev.Rune(), in)
+		case tcell.KeyBackspace, tcell.KeyBackspace2:
+			in.Backspace = true
+		default:
+			in.Keys = append(in.Keys, specialKeys[ev.Key()]...)
+		}
+	case *tcell.EventMouse:
+		// Every report, motion included, tells where the pointer is
+		col, row := ev.Position()
+		in.HasCursor = true
+		in.CursorX, in.CursorY = s.toLogical(col, row)
+		buttons := ev.Buttons()
+		if buttons&tcell.Button1 != 0 && s.buttons&tcell.Button1 == 0 { // Left button press
+			in.Clicked = true
+			in.ClickX, in.ClickY = in.CursorX, in.CursorY
+		}
+		s.buttons = buttons
+	}
+}
+
+// parseRune adds a typed character to in, along with the key it's bound to.
+func parseRune(r rune, in *frontend.Input) {
+	switch r {
+	case 'q', 'Q':
+		in.Keys = append(in.Keys, frontend.KeyQuit)
+	case 's', 'S':
+		in.Keys = append(in.Keys, frontend.KeySave)
+	case 'l', 'L':
+		in.Keys = append(in.Keys, frontend.KeyLoad)
+	case 't', 'T':
+		in.Keys = append(in.Keys, frontend.KeySettings)
+	case ' ':
+		in.Keys = append(in.Keys, frontend.KeySwitch)
+	case 'p', 'P':
+		in.Keys = append(in.Keys, frontend.KeyPause)
+	case '.':
+		in.Keys = append(in.Keys, frontend.KeyStep)
+	case 'h', 'H':
+		in.Keys = append(in.Keys, frontend.KeyStats)
+	case 'c', 'C':
+		in.Keys = append(in.Keys, frontend.KeyCampaign)
+	case 'm', 'M':
+		in.Keys = append(in.Keys, frontend.KeyMagnet)
+	case 'd', 'D':
+		in.Keys = append(in.Keys, frontend.KeyDisplay)
+	case 'a', 'A':
+		in.Keys = append(in.Keys, frontend.KeyAudio)
+	case ']':
+		in.Keys = append(in.Keys, frontend.KeyNextTrack)
+	case '[':
+		in.Keys = append(in.Keys, frontend.KeyPrevTrack)
+	case 'r', 'R':
+		// Terminals don't report key releases, holding R auto-repeats it instead
+		in.Held = append(in.Held, frontend.KeyRewind)
+	}
+	in.Chars = append(in.Chars, r)
+}
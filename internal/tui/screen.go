@@ -0,0 +1,224 @@
+package tui
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/frontend"
+	"github.com/Y1m4r/Catch-The-PacMan-Game/internal/game"
+	"github.com/gdamore/tcell/v2"
+)
+
+// cell is one character position on the terminal grid.
+type cell struct {
+	ch rune
+	fg color.Color
+	bg color.Color
+}
+
+// Screen is a terminal frontend: it renders the logical 640x480 screen onto a
+// character grid and reads keyboard and mouse input, through tcell, which
+// handles the terminal's modes, its resizes and the Windows console.
+// It implements frontend.Renderer and frontend.InputSource.
+type Screen struct {
+	screen     tcell.Screen
+	cols, rows int
+	cells      []cell
+	bg         color.Color
+
+	events  chan tcell.Event
+	quit    chan struct{}
+	buttons tcell.ButtonMask // Mouse buttons held at the last report, to tell presses apart
+}
+
+// NewScreen takes over the terminal and prepares the character grid.
+func NewScreen() (*Screen, error) {
+	screen, err := tcell.NewScreen()
+	if err != nil {
+		return nil, fmt.Errorf("could not open terminal: %w", err)
+	}
+	return newScreen(screen)
+}
+
+// newScreen prepares the character grid on screen.
+func newScreen(screen tcell.Screen) (*Screen, error) {
+	if err := screen.Init(); err != nil {
+		return nil, fmt.Errorf("could not set up terminal: %w", err)
+	}
+	screen.HideCursor()
+	screen.EnableMouse(tcell.MouseMotionEvents)
+
+	s := &Screen{
+		screen: screen,
+		bg:     frontend.ColorBlack,
+		events: make(chan tcell.Event, 64),
+		quit:   make(chan struct{}),
+	}
+	s.resize()
+	go screen.ChannelEvents(s.events, s.quit)
+	return s, nil
+}
+
+// Close gives the terminal back in the mode it was in before NewScreen.
+func (s *Screen) Close() error {
+	close(s.quit)
+	s.screen.Fini()
+	return nil
+}
+
+// resize fits the grid to the terminal's size.
+func (s *Screen) resize() {
+	s.cols, s.rows = s.screen.Size()
+	s.cells = make([]cell, s.cols*s.rows)
+}
+
+// Fill clears the grid to the given background color.
+func (s *Screen) Fill(clr color.Color) {
+	s.bg = clr
+	for i := range s.cells {
+		s.cells[i] = cell{ch: ' ', bg: clr}
+	}
+}
+
+// DrawEntity draws a Pacman as a single character, alternating the mouth frame.
+func (s *Screen) DrawEntity(p game.PacmanDrawData) {
+	col, row := s.toCell(p.PosX, p.PosY)
+	ch := 'C'
+	if p.AnimFrame == 1 {
+		ch = 'c'
+	}
+	var clr color.Color = frontend.ColorYellow
+	if p.Tint.A != 0 {
+		clr = p.Tint
+	}
+	s.set(col, row, ch, clr)
+}
+
+// DrawText writes a string starting at (or centered on) the given logical position.
+func (s *Screen) DrawText(str string, x, y float64, clr color.Color, center bool) {
+	col, row := s.toCell(x, y)
+	runes := []rune(str)
+	if center {
+		col -= len(runes) / 2
+	}
+	for i, r := range runes {
+		s.set(col+i, row, r, clr)
+	}
+}
+
+// DrawRect colors the background of every cell the rectangle covers.
+func (s *Screen) DrawRect(x, y, width, height float64, clr color.Color) {
+	col0, row0 := s.toCell(x, y)
+	col1, row1 := s.toCell(x+width, y+height)
+	for row := max(row0, 0); row <= min(row1, s.rows-1); row++ {
+		for col := max(col0, 0); col <= min(col1, s.cols-1); col++ {
+			s.cells[row*s.cols+col].bg = clr
+		}
+	}
+}
+
+// DrawRing draws a circle outline with 'o' characters.
+func (s *Screen) DrawRing(x, y, radius float64, clr color.Color) {
+	const points = 24
+	for i := 0; i < points; i++ {
+		angle := 2 * math.Pi * float64(i) / points
+		col, row := s.toCell(x+radius*math.Cos(angle), y+radius*math.Sin(angle))
+		s.set(col, row, 'o', clr)
+	}
+}
+
+// DrawLine draws a line of '.' characters.
+func (s *Screen) DrawLine(x1, y1, x2, y2 float64, clr color.Color) {
+	col1, row1 := s.toCell(x1, y1)
+	col2, row2 := s.toCell(x2, y2)
+	steps := max(abs(col2-col1), abs(row2-row1))
+	for i := 1; i <= steps; i++ { // The first cell is left to what the line starts at
+		t := float64(i) / float64(steps)
+		s.set(col1+int(math.Round(t*float64(col2-col1))), row1+int(math.Round(t*float64(row2-row1))), '.', clr)
+	}
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// Show writes the current grid to the terminal. Only the cells that changed
+// since the last frame are sent.
+func (s *Screen) Show() error {
+	for row := 0; row < s.rows; row++ {
+		for col := 0; col < s.cols; col++ {
+			c := s.cells[row*s.cols+col]
+			s.screen.SetContent(col, row, c.ch, nil, style(c.fg, c.bg))
+		}
+	}
+	s.screen.Show()
+	return nil
+}
+
+// PollInput drains the input read since the last tick. The grid follows the
+// terminal's size from the frame after it's resized.
+func (s *Screen) PollInput() frontend.Input {
+	var in frontend.Input
+	for {
+		select {
+		case ev, ok := <-s.events:
+			if !ok {
+				// The terminal is gone, nothing more can be read: treat it as a quit request
+				s.events = nil
+				in.Keys = append(in.Keys, frontend.KeyQuit)
+				return in
+			}
+			if _, ok := ev.(*tcell.EventResize); ok {
+				s.resize()
+				s.screen.Sync()
+				continue
+			}
+			s.parseEvent(ev, &in)
+		default:
+			return in
+		}
+	}
+}
+
+// toCell maps logical screen coordinates to a grid cell.
+func (s *Screen) toCell(x, y float64) (col, row int) {
+	col = int(x * float64(s.cols) / frontend.ScreenWidth)
+	row = int(y * float64(s.rows) / frontend.ScreenHeight)
+	return col, row
+}
+
+// toLogical maps a terminal cell to the logical coordinates of its center.
+func (s *Screen) toLogical(col, row int) (x, y float64) {
+	x = (float64(col) + 0.5) * frontend.ScreenWidth / float64(s.cols)
+	y = (float64(row) + 0.5) * frontend.ScreenHeight / float64(s.rows)
+	return x, y
+}
+
+func (s *Screen) set(col, row int, ch rune, fg color.Color) {
+	if col < 0 || col >= s.cols || row < 0 || row >= s.rows {
+		return
+	}
+	c := &s.cells[row*s.cols+col]
+	c.ch = ch
+	c.fg = fg
+	if c.bg == nil {
+		c.bg = s.bg
+	}
+}
+
+// style builds the tcell style of a foreground/background pair, nil
+// leaving the terminal's own.
+func style(fg, bg color.Color) tcell.Style {
+	st := tcell.StyleDefault
+	if fg != nil {
+		st = st.Foreground(tcell.FromImageColor(fg))
+	}
+	if bg != nil {
+		st = st.Background(tcell.FromImageColor(bg))
+	}
+	return st
+}
@@ -0,0 +1,189 @@
+// Package twitch lets a streamer's chat interact with the game: a small IRC
+// client listens to chat commands and hands the accepted ones to the game.
+package twitch
+
+import (
+	"bufio"
+	"crypto/tls"
+	"fmt"
+	"log"
+	"math/rand"
+	"net"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	serverAddr     = "irc.chat.twitch.tv:6697"
+	reconnectDelay = 10 * time.Second
+
+	// Rate limits for chat commands
+	commandCooldown = 5 * time.Second
+	userCooldown    = 30 * time.Second
+)
+
+// Commands viewers can type in chat.
+const (
+	CommandSpawn = "spawn" // !spawn: an extra Pacman joins the level
+	CommandSlow  = "slow"  // !slow: slow-motion power-up for the streamer
+)
+
+var knownCommands = map[string]bool{CommandSpawn: true, CommandSlow: true}
+
+// Command is a chat command that passed the rate limits.
+type Command struct {
+	User string
+	Name string // One of the Command* constants
+}
+
+// Client listens to a channel's chat until closed, reconnecting when dropped.
+type Client struct {
+	settings  Settings
+	commands  chan Command
+	cooldowns *cooldowns
+
+	mu     sync.Mutex
+	conn   net.Conn
+	closed bool
+}
+
+// Connect starts listening to the chat of the configured channel.
+func Connect(settings Settings) (*Client, error) {
+	settings.Channel = normalizeChannel(settings.Channel)
+	if settings.Channel == "" {
+		return nil, fmt.Errorf("no twitch channel configured")
+	}
+	c := &Client{
+		settings:  settings,
+		commands:  make(chan Command, 16),
+		cooldowns: newCooldowns(commandCooldown, userCooldown),
+	}
+	go c.run()
+	return c, nil
+}
+
+// Channel returns the channel being listened to.
+func (c *Client) Channel() string {
+	return c.settings.Channel
+}
+
+// Poll returns the commands accepted since the last call without blocking.
+func (c *Client) Poll() []Command {
+	var cmds []Command
+	for {
+		select {
+		case cmd := <-c.commands:
+			cmds = append(cmds, cmd)
+		default:
+			return cmds
+		}
+	}
+}
+
+// Close disconnects from chat.
+func (c *Client) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closed = true
+	if c.conn != nil {
+		return c.conn.Close()
+	}
+	return nil
+}
+
+func (c *Client) isClosed() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.closed
+}
+
+func (c *Client) run() {
+	for !c.isClosed() {
+		err := c.listen()
+		if c.isClosed() {
+			return
+		}
+		log.Printf("Twitch chat connection for #%s lost: %v. Reconnecting in %v.", c.settings.Channel, err, reconnectDelay)
+		time.Sleep(reconnectDelay)
+	}
+}
+
+// listen runs one IRC session until the connection fails.
+func (c *Client) listen() error {
+	conn, err := tls.Dial("tcp", serverAddr, nil)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		conn.Close()
+		return nil
+	}
+	c.conn = conn
+	c.mu.Unlock()
+	defer conn.Close()
+
+	nick := fmt.Sprintf("justinfan%d", 10000+rand.Intn(90000)) // Anonymous read-only login
+	if c.settings.Token != "" {
+		nick = c.settings.Channel // Tokens belong to the streamer's own account
+		token := c.settings.Token
+		if !strings.HasPrefix(token, "oauth:") {
+			token = "oauth:" + token
+		}
+		fmt.Fprintf(conn, "PASS %s\r\n", token)
+	}
+	fmt.Fprintf(conn, "NICK %s\r\n", nick)
+	fmt.Fprintf(conn, "JOIN #%s\r\n", c.settings.Channel)
+	log.Printf("Listening to Twitch chat of #%s", c.settings.Channel)
+
+	scanner := bufio.NewScanner(conn)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "PING") {
+			fmt.Fprintf(conn, "PONG%s\r\n", strings.TrimPrefix(line, "PING"))
+			continue
+		}
+		if user, text, ok := parsePrivmsg(line); ok {
+			c.handleMessage(user, text)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return fmt.Errorf("server closed the connection")
+}
+
+func (c *Client) handleMessage(user, text string) {
+	fields := strings.Fields(text)
+	if len(fields) == 0 || !strings.HasPrefix(fields[0], "!") {
+		return
+	}
+	name := strings.ToLower(strings.TrimPrefix(fields[0], "!"))
+	if !knownCommands[name] || !c.cooldowns.allow(user, name, time.Now()) {
+		return
+	}
+	select {
+	case c.commands <- Command{User: user, Name: name}:
+	default: // The game isn't keeping up, drop it
+	}
+}
+
+// parsePrivmsg extracts the sender and text of a chat line like
+// ":nick!nick@nick.tmi.twitch.tv PRIVMSG #channel :hello".
+func parsePrivmsg(line string) (user, text string, ok bool) {
+	if !strings.HasPrefix(line, ":") {
+		return "", "", false
+	}
+	prefix, rest, found := strings.Cut(line[1:], " ")
+	if !found || !strings.HasPrefix(rest, "PRIVMSG ") {
+		return "", "", false
+	}
+	_, text, found = strings.Cut(rest, " :")
+	if !found {
+		return "", "", false
+	}
+	user, _, _ = strings.Cut(prefix, "!")
+	return user, text, true
+}
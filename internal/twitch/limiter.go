@@ -0,0 +1,39 @@
+package twitch
+
+import (
+	"sync"
+	"time"
+)
+
+// cooldowns rate limits chat commands: each command has a global cooldown so
+// chat can't flood the game, and each viewer has a personal one on top.
+type cooldowns struct {
+	global  time.Duration
+	perUser time.Duration
+
+	mu       sync.Mutex
+	commands map[string]time.Time // Command -> last accepted
+	users    map[string]time.Time // Viewer -> last accepted
+}
+
+func newCooldowns(global, perUser time.Duration) *cooldowns {
+	return &cooldowns{
+		global:   global,
+		perUser:  perUser,
+		commands: make(map[string]time.Time),
+		users:    make(map[string]time.Time),
+	}
+}
+
+// allow reports whether user may trigger command now, recording it if so.
+func (c *cooldowns) allow(user, command string, now time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if now.Sub(c.commands[command]) < c.global || now.Sub(c.users[user]) < c.perUser {
+		return false
+	}
+	c.commands[command] = now
+	c.users[user] = now
+	return true
+}
@@ -0,0 +1,53 @@
+package twitch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Settings configures the chat interaction mode.
+type Settings struct {
+	Enabled bool   `json:"enabled"`
+	Channel string `json:"channel"` // Channel to listen to, without the leading '#'
+	Token   string `json:"token"`   // OAuth token ("oauth:..."); empty joins anonymously, which is enough to read chat
+}
+
+// LoadSettings reads the settings file. A missing file yields disabled defaults.
+func LoadSettings(path string) (Settings, error) {
+	var s Settings
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return s, fmt.Errorf("error reading twitch settings %s: %w", path, err)
+	}
+	if err := json.Unmarshal(data, &s); err != nil {
+		return s, fmt.Errorf("error decoding twitch settings %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// Save writes the settings file. It's only readable by the user since it may hold a token.
+func (s Settings) Save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("could not create twitch settings directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("error writing twitch settings %s: %w", path, err)
+	}
+	return nil
+}
+
+// normalizeChannel turns "#SomeStreamer" into "somestreamer" as IRC expects.
+func normalizeChannel(channel string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(channel), "#"))
+}
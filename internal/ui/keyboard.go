@@ -0,0 +1,153 @@
+package ui
+
+// KeyboardLayout is the keys of a Keyboard without Rows.
+var KeyboardLayout = []string{"ABCDEFGHIJ", "KLMNOPQRST", "UVWXYZ-_.!", "0123456789"}
+
+// Labels of the keys on a Keyboard's last row.
+const (
+	keyErase = "DEL"
+	keyDone  = "OK"
+)
+
+// Keyboard is an on-screen keyboard for typing without a physical one, with
+// a gamepad's d-pad, the arrow keys or a touch screen. Left, Right, Up and
+// Down move a cursor over its keys, and activating presses the one under
+// it, as does clicking a key. Up from the top row and Down from the bottom
+// row leave the keyboard.
+//
+// The cursor only shows once the keyboard is used, so that until then
+// Activate is left to whatever a physical keyboard's Enter does.
+type Keyboard struct {
+	Rows    []string // Characters of each row of keys; KeyboardLayout if empty
+	OnType  func(r rune)
+	OnErase func()
+	OnDone  func()
+
+	Active   bool // The cursor was moved or a key clicked
+	row, col int  // Key under the cursor
+}
+
+func (k *Keyboard) Height() float64 { return RowHeight * float64(len(k.keys())) }
+
+func (k *Keyboard) Update(in Input, width float64) bool {
+	keys := k.keys()
+	switch {
+	case in.Clicked:
+		row := int(in.PointerY / RowHeight)
+		if row < 0 || row >= len(keys) {
+			return false
+		}
+		col, ok := k.keyAt(keys, row, in.PointerX, width)
+		if !ok {
+			return false
+		}
+		k.row, k.col, k.Active = row, col, true
+		k.press(keys[row][col])
+	case in.Left || in.Right:
+		k.Active = true
+		n := len(keys[k.row])
+		if in.Left {
+			k.col = (k.col + n - 1) % n
+		} else {
+			k.col = (k.col + 1) % n
+		}
+		return true
+	case in.Up || in.Down:
+		wasActive := k.Active
+		k.Active = true
+		row := k.row + 1
+		if in.Up {
+			row = k.row - 1
+		}
+		if row < 0 || row >= len(keys) {
+			return !wasActive
+		}
+		// Go to the key right above or below
+		x, w := k.keyBox(keys, k.row, k.col, width)
+		k.col, _ = k.keyAt(keys, row, x+w/2, width)
+		k.row = row
+		return true
+	case in.Activate && k.Active:
+		k.press(keys[k.row][k.col])
+	}
+	return false
+}
+
+func (k *Keyboard) Draw(r Renderer, x, y, width float64, focused bool, style Style) {
+	keys := k.keys()
+	for row, labels := range keys {
+		for col, label := range labels {
+			kx, kw := k.keyBox(keys, row, col, width)
+			ky := y + float64(row)*RowHeight
+			clr := style.Text
+			if focused && k.Active && row == k.row && col == k.col {
+				r.DrawRect(x+kx+1, ky-4, kw-2, RowHeight-2, style.Highlight)
+				clr = style.Focused
+			}
+			r.DrawText(label, x+kx+kw/2, ky, clr, true)
+		}
+	}
+}
+
+// keys returns the labels of the keys, row by row.
+func (k *Keyboard) keys() [][]string {
+	rows := k.Rows
+	if len(rows) == 0 {
+		rows = KeyboardLayout
+	}
+	keys := make([][]string, 0, len(rows)+1)
+	for _, row := range rows {
+		var labels []string
+		for _, r := range row {
+			labels = append(labels, string(r))
+		}
+		keys = append(keys, labels)
+	}
+	return append(keys, []string{keyErase, keyDone})
+}
+
+// keyBox returns where a key starts, relative to the keyboard's left edge,
+// and how wide it is. Character keys are all as wide, with shorter rows
+// centered; the last row's keys share it.
+func (k *Keyboard) keyBox(keys [][]string, row, col int, width float64) (x, w float64) {
+	if row == len(keys)-1 {
+		w = width / float64(len(keys[row]))
+		return float64(col) * w, w
+	}
+	cols := 0
+	for _, labels := range keys[:len(keys)-1] {
+		cols = max(cols, len(labels))
+	}
+	w = width / float64(cols)
+	return (width-w*float64(len(keys[row])))/2 + float64(col)*w, w
+}
+
+// keyAt returns the key of a row at x, or the nearest one if x is past
+// either end of the row. It reports whether x is on the key.
+func (k *Keyboard) keyAt(keys [][]string, row int, x, width float64) (int, bool) {
+	first, w := k.keyBox(keys, row, 0, width)
+	col := int((x - first) / w)
+	if x < first {
+		col = -1
+	}
+	n := len(keys[row])
+	return min(max(col, 0), n-1), col >= 0 && col < n
+}
+
+// press does what a key is for.
+func (k *Keyboard) press(label string) {
+	switch label {
+	case keyErase:
+		if k.OnErase != nil {
+			k.OnErase()
+		}
+	case keyDone:
+		if k.OnDone != nil {
+			k.OnDone()
+		}
+	default:
+		if k.OnType != nil {
+			k.OnType([]rune(label)[0])
+		}
+	}
+}
@@ -0,0 +1,169 @@
+// Package ui is a small widget toolkit for the game's menus: buttons,
+// sliders, toggles, text fields, lists and an on-screen keyboard, stacked
+// in a Menu that is navigated with the keyboard, a gamepad's d-pad or the
+// mouse. Widgets draw with the same primitives as the game, so menus look
+// alike in a window and in a terminal.
+package ui
+
+import "image/color"
+
+// RowHeight is the height of a single-line widget, spacing included.
+const RowHeight = 25
+
+// Renderer is the part of a frontend's renderer widgets draw with.
+type Renderer interface {
+	DrawText(str string, x, y float64, clr color.Color, center bool)
+	DrawRect(x, y, width, height float64, clr color.Color)
+}
+
+// Input is the menu input of a single tick. Pointer positions are in the
+// renderer's coordinates; a Menu hands its widgets positions relative to
+// their top-left corner.
+type Input struct {
+	Up, Down, Left, Right bool
+	Activate              bool // Enter, a gamepad's A button, or a switch
+
+	HasPointer         bool
+	PointerX, PointerY float64
+	Clicked            bool   // The pointer was just pressed, at PointerX, PointerY
+	Chars              []rune // Typed characters
+	Composing          string // Text an input method is still composing, not typed yet
+	Backspace          bool
+}
+
+// Style is the colors widgets are drawn in.
+type Style struct {
+	Text      color.Color // Labels
+	Focused   color.Color // The focused widget's label
+	Dim       color.Color // Hints and unfocused values
+	Highlight color.Color // Bar behind the focused widget, and filled slider tracks
+}
+
+// Widget is an element of a Menu. Widgets lay out from their top-left
+// corner, taking the Menu's full width.
+type Widget interface {
+	// Height is how much vertical space the widget takes.
+	Height() float64
+	// Update handles input while the widget is focused. It reports whether
+	// it used the navigation keys, which then don't move the focus.
+	Update(in Input, width float64) bool
+	Draw(r Renderer, x, y, width float64, focused bool, style Style)
+}
+
+// Menu stacks widgets vertically, centered on X, and moves the focus
+// between them with Up and Down or by pointing at one.
+type Menu struct {
+	Widgets []Widget
+	Focus   int     // Index of the focused widget
+	X, Y    float64 // Center of the top edge
+	Width   float64
+	Style   Style
+
+	pointerX, pointerY float64 // Last pointer position, to tell when it moves
+}
+
+// Update hands this tick's input to the focused widget, and moves the focus
+// if the widget didn't use it.
+func (m *Menu) Update(in Input) {
+	if len(m.Widgets) == 0 {
+		return
+	}
+	m.Focus = min(max(m.Focus, 0), len(m.Widgets)-1)
+
+	// Pointing at a widget focuses it, but a still pointer doesn't keep
+	// stealing the focus from the keyboard
+	if in.HasPointer && (in.Clicked || in.PointerX != m.pointerX || in.PointerY != m.pointerY) {
+		m.pointerX, m.pointerY = in.PointerX, in.PointerY
+		if i, ok := m.widgetAt(in.PointerX, in.PointerY); ok {
+			m.Focus = i
+		}
+	}
+
+	x, y := m.widgetPos(m.Focus)
+	w := m.Widgets[m.Focus]
+	local := in
+	local.PointerX, local.PointerY = in.PointerX-x, in.PointerY-y
+	local.Clicked = in.Clicked && inside(local.PointerX, local.PointerY, m.Width, w.Height())
+	if w.Update(local, m.Width) {
+		return
+	}
+
+	n := len(m.Widgets)
+	switch {
+	case in.Up:
+		m.Focus = (m.Focus + n - 1) % n
+	case in.Down:
+		m.Focus = (m.Focus + 1) % n
+	}
+}
+
+// Draw renders every widget.
+func (m *Menu) Draw(r Renderer) {
+	for i, w := range m.Widgets {
+		x, y := m.widgetPos(i)
+		w.Draw(r, x, y, m.Width, i == m.Focus, m.Style)
+	}
+}
+
+// Height is how much vertical space the widgets take.
+func (m *Menu) Height() float64 {
+	var h float64
+	for _, w := range m.Widgets {
+		h += w.Height()
+	}
+	return h
+}
+
+// WantsText reports whether typed characters go to a text field.
+func (m *Menu) WantsText() bool {
+	if m.Focus < 0 || m.Focus >= len(m.Widgets) {
+		return false
+	}
+	_, ok := m.Widgets[m.Focus].(*TextField)
+	return ok
+}
+
+// TextPosition returns where the focused text field shows its text, for an
+// input method's candidate window to go next to it.
+func (m *Menu) TextPosition() (x, y float64) {
+	x, y = m.widgetPos(max(m.Focus, 0))
+	return x + m.Width/2, y + 2*RowHeight
+}
+
+// widgetPos returns the top-left corner of the i-th widget.
+func (m *Menu) widgetPos(i int) (float64, float64) {
+	y := m.Y
+	for _, w := range m.Widgets[:i] {
+		y += w.Height()
+	}
+	return m.X - m.Width/2, y
+}
+
+// widgetAt returns the widget under a point.
+func (m *Menu) widgetAt(px, py float64) (int, bool) {
+	for i, w := range m.Widgets {
+		x, y := m.widgetPos(i)
+		if inside(px-x, py-y, m.Width, w.Height()) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// inside reports whether a point relative to a box's top-left corner is in it.
+func inside(x, y, width, height float64) bool {
+	return x >= 0 && x < width && y >= 0 && y < height
+}
+
+// drawFocus draws the bar behind a focused row.
+func drawFocus(r Renderer, x, y, width float64, style Style) {
+	r.DrawRect(x, y-4, width, RowHeight-2, style.Highlight)
+}
+
+// labelColor is the color of a widget's label.
+func labelColor(focused bool, style Style) color.Color {
+	if focused {
+		return style.Focused
+	}
+	return style.Text
+}
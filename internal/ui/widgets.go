@@ -0,0 +1,264 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Button runs OnPress when activated or clicked.
+type Button struct {
+	Label   string
+	OnPress func()
+}
+
+func (b *Button) Height() float64 { return RowHeight }
+
+func (b *Button) Update(in Input, width float64) bool {
+	if (in.Activate || in.Clicked) && b.OnPress != nil {
+		b.OnPress()
+	}
+	return false
+}
+
+func (b *Button) Draw(r Renderer, x, y, width float64, focused bool, style Style) {
+	if focused {
+		drawFocus(r, x, y, width, style)
+	}
+	r.DrawText(b.Label, x+width/2, y, labelColor(focused, style), true)
+}
+
+// Toggle is an on/off setting, flipped by activating or clicking it, or
+// with Left and Right.
+type Toggle struct {
+	Label    string
+	On       bool
+	OnChange func(on bool)
+}
+
+func (t *Toggle) Height() float64 { return RowHeight }
+
+func (t *Toggle) Update(in Input, width float64) bool {
+	if !in.Activate && !in.Clicked && !in.Left && !in.Right {
+		return false
+	}
+	t.On = !t.On
+	if t.OnChange != nil {
+		t.OnChange(t.On)
+	}
+	return in.Left || in.Right
+}
+
+func (t *Toggle) Draw(r Renderer, x, y, width float64, focused bool, style Style) {
+	if focused {
+		drawFocus(r, x, y, width, style)
+	}
+	state := "off"
+	if t.On {
+		state = "on"
+	}
+	r.DrawText(t.Label+": "+state, x+width/2, y, labelColor(focused, style), true)
+}
+
+// Slider picks a whole number between Min and Max with Left and Right, or
+// by clicking on its track, going Step at a time from Min.
+type Slider struct {
+	Label    string
+	Value    int
+	Min, Max int
+	Step     int    // 0 is 1
+	Unit     string // Shown after the value, e.g. "%"
+	OnChange func(value int)
+}
+
+func (s *Slider) Height() float64 { return RowHeight }
+
+func (s *Slider) Update(in Input, width float64) bool {
+	step := max(s.Step, 1)
+	value := s.Value
+	switch {
+	case in.Left:
+		value -= step
+	case in.Right:
+		value += step
+	case in.Clicked && in.PointerX >= width/2:
+		// The track takes the right half of the row
+		value = s.Min + step*int((in.PointerX-width/2)/(width/2)*float64((s.Max-s.Min)/step+1))
+	}
+	value = min(max(value, s.Min), s.Max)
+	if value != s.Value {
+		s.Value = value
+		if s.OnChange != nil {
+			s.OnChange(value)
+		}
+	}
+	return in.Left || in.Right
+}
+
+func (s *Slider) Draw(r Renderer, x, y, width float64, focused bool, style Style) {
+	if focused {
+		drawFocus(r, x, y, width, style)
+	}
+	r.DrawText(fmt.Sprintf("%s: %d%s", s.Label, s.Value, s.Unit), x+width/4, y, labelColor(focused, style), true)
+
+	trackX, trackWidth := x+width/2, width/2-10
+	r.DrawRect(trackX, y+6, trackWidth, 4, style.Dim)
+	if s.Max > s.Min {
+		filled := trackWidth * float64(s.Value-s.Min) / float64(s.Max-s.Min)
+		r.DrawRect(trackX, y+4, filled, 8, labelColor(focused, style))
+	}
+}
+
+// TextField edits a line of text while focused. Activating it submits the text.
+type TextField struct {
+	Label    string
+	Text     []rune
+	MaxLen   int  // 0 is unlimited
+	Masked   bool // Shown as asterisks, for secrets
+	OnSubmit func(text string)
+
+	composing string // See Input.Composing
+}
+
+func (f *TextField) Height() float64 { return 2 * RowHeight }
+
+func (f *TextField) Update(in Input, width float64) bool {
+	f.composing = in.Composing
+	for _, r := range in.Chars {
+		if r != ' ' && (f.MaxLen == 0 || len(f.Text) < f.MaxLen) {
+			f.Text = append(f.Text, r)
+		}
+	}
+	if in.Backspace && len(f.Text) > 0 {
+		f.Text = f.Text[:len(f.Text)-1]
+	}
+	if in.Activate && f.OnSubmit != nil {
+		f.OnSubmit(string(f.Text))
+	}
+	return false
+}
+
+func (f *TextField) Draw(r Renderer, x, y, width float64, focused bool, style Style) {
+	clr := style.Dim
+	if focused {
+		clr = style.Focused
+	}
+	value := string(f.Text)
+	if f.Masked {
+		value = strings.Repeat("*", len(f.Text))
+	}
+	if focused {
+		value = ComposedText(value, f.composing, f.Masked)
+	}
+	r.DrawText(f.Label, x+width/2, y, clr, true)
+	r.DrawText(value, x+width/2, y+RowHeight, clr, true)
+}
+
+// List shows items, Rows at a time, one of them selected. Up and Down move
+// the selection, leaving the list at either end. Activating or clicking an
+// item runs OnSelect.
+type List struct {
+	Label    string // Optional heading above the items
+	Items    []string
+	Selected int
+	Rows     int // Items shown at once; 0 shows them all
+	OnSelect func(index int)
+
+	top int // First item shown
+}
+
+func (l *List) Height() float64 {
+	return l.headingHeight() + RowHeight*float64(max(1, l.visible()))
+}
+
+func (l *List) Update(in Input, width float64) bool {
+	if len(l.Items) == 0 {
+		return false
+	}
+	used := false
+	switch {
+	case in.Up && l.Selected > 0:
+		l.Selected--
+		used = true
+	case in.Down && l.Selected < len(l.Items)-1:
+		l.Selected++
+		used = true
+	case in.Clicked:
+		row := in.PointerY - l.headingHeight()
+		if i := l.top + int(row/RowHeight); row >= 0 && i < len(l.Items) {
+			l.Selected = i
+		} else {
+			in.Clicked = false // On the heading
+		}
+	}
+	l.scroll()
+	if (in.Activate || in.Clicked) && l.OnSelect != nil {
+		l.OnSelect(l.Selected)
+	}
+	return used
+}
+
+func (l *List) Draw(r Renderer, x, y, width float64, focused bool, style Style) {
+	l.scroll()
+	if l.Label != "" {
+		r.DrawText(l.Label, x+width/2, y, style.Dim, true)
+		y += RowHeight
+	}
+	for i := l.top; i < l.top+l.visible(); i++ {
+		row := y + float64(i-l.top)*RowHeight
+		clr := style.Dim
+		if i == l.Selected {
+			clr = style.Text
+			if focused {
+				drawFocus(r, x, row, width, style)
+				clr = style.Focused
+			}
+		}
+		r.DrawText(l.Items[i], x+width/2, row, clr, true)
+	}
+	if l.top > 0 {
+		r.DrawText("^", x+width-10, y, style.Dim, false)
+	}
+	if l.top+l.visible() < len(l.Items) {
+		r.DrawText("v", x+width-10, y+float64(l.visible()-1)*RowHeight, style.Dim, false)
+	}
+}
+
+// headingHeight is the space the Label takes.
+func (l *List) headingHeight() float64 {
+	if l.Label == "" {
+		return 0
+	}
+	return RowHeight
+}
+
+// visible is how many items are shown.
+func (l *List) visible() int {
+	if l.Rows == 0 {
+		return len(l.Items)
+	}
+	return min(l.Rows, len(l.Items))
+}
+
+// scroll keeps the selected item in view.
+func (l *List) scroll() {
+	l.Selected = min(max(l.Selected, 0), max(len(l.Items)-1, 0))
+	if l.Selected < l.top {
+		l.top = l.Selected
+	}
+	if n := l.visible(); n > 0 && l.Selected >= l.top+n {
+		l.top = l.Selected - n + 1
+	}
+}
+
+// ComposedText returns typed text as shown while typing: followed by what an
+// input method is still composing, in brackets, and the cursor.
+func ComposedText(text, composing string, masked bool) string {
+	if composing == "" {
+		return text + "_"
+	}
+	if masked {
+		composing = strings.Repeat("*", utf8.RuneCountInString(composing))
+	}
+	return text + "[" + composing + "]_"
+}